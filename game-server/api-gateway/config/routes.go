@@ -6,6 +6,7 @@ import (
 	"github.com/darkphotonKN/cosmic-void-server/api-gateway/internal/auth"
 	authService "github.com/darkphotonKN/cosmic-void-server/api-gateway/internal/gateway/auth"
 	"github.com/darkphotonKN/cosmic-void-server/api-gateway/internal/gateway/example"
+	"github.com/darkphotonKN/cosmic-void-server/api-gateway/internal/gateway/game"
 	"github.com/darkphotonKN/cosmic-void-server/common/discovery"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -68,11 +69,33 @@ func SetupRouter(registry discovery.Registry, db *sqlx.DB) *gin.Engine {
 	memberRoutes.PATCH("/update-info", authHandler.UpdateInfoMemberHandler)
 
 	// --- GAME SERVICE ---
-	// TODO: Add game service routes when implemented
-	// gameClient := game.NewClient(registry)
-	// gameHandler := game.NewHandler(gameClient)
-	// gameRoutes := api.Group("/game")
-	// gameRoutes.GET("/items", gameHandler.GetItemsHandler)
+
+	// -- Game Setup --
+	gameClient := game.NewClient(registry)
+	gameHandler := game.NewHandler(gameClient)
+
+	// -- Game Routes --
+	gameRoutes := api.Group("/game")
+
+	// Public Routes
+	gameRoutes.GET("/rooms", gameHandler.GetRoomsHandler)
+	gameRoutes.GET("/rooms/:id", gameHandler.GetRoomHandler)
+	gameRoutes.GET("/items", gameHandler.GetItemsHandler)
+	gameRoutes.GET("/items/:id", gameHandler.GetItemHandler)
+
+	// Private Routes
+	roomRoutes := gameRoutes.Group("/rooms")
+	roomRoutes.Use(auth.AuthMiddleware())
+	roomRoutes.POST("", gameHandler.CreateRoomHandler)
+	roomRoutes.POST("/:id/join", gameHandler.JoinRoomHandler)
+	roomRoutes.POST("/:id/leave", gameHandler.LeaveRoomHandler)
+	roomRoutes.POST("/:id/start", gameHandler.StartGameHandler)
+	roomRoutes.POST("/:id/end", gameHandler.EndGameHandler)
+
+	memberGameRoutes := gameRoutes.Group("/member")
+	memberGameRoutes.Use(auth.AuthMiddleware())
+	memberGameRoutes.GET("/items", gameHandler.GetMemberItemsHandler)
+	memberGameRoutes.GET("/items/:id", gameHandler.GetMemberItemHandler)
 
 	return router
 }
\ No newline at end of file
@@ -0,0 +1,177 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/game"
+	"github.com/darkphotonKN/cosmic-void-server/common/discovery"
+)
+
+const (
+	serviceName = "game"
+)
+
+type Client struct {
+	registry discovery.Registry
+}
+
+func NewClient(registry discovery.Registry) GameClient {
+	return &Client{
+		registry: registry,
+	}
+}
+
+func (c *Client) CreateRoom(ctx context.Context, req *pb.CreateRoomRequest) (*pb.Room, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	room, err := client.CreateRoom(ctx, req)
+	return room, err
+}
+
+func (c *Client) GetRoom(ctx context.Context, req *pb.GetRoomRequest) (*pb.Room, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	room, err := client.GetRoom(ctx, req)
+	return room, err
+}
+
+func (c *Client) ListRooms(ctx context.Context, req *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	response, err := client.ListRooms(ctx, req)
+	return response, err
+}
+
+func (c *Client) JoinRoom(ctx context.Context, req *pb.JoinRoomRequest) (*pb.JoinRoomResponse, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	response, err := client.JoinRoom(ctx, req)
+	return response, err
+}
+
+func (c *Client) LeaveRoom(ctx context.Context, req *pb.LeaveRoomRequest) (*pb.LeaveRoomResponse, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	response, err := client.LeaveRoom(ctx, req)
+	return response, err
+}
+
+func (c *Client) StartGame(ctx context.Context, req *pb.StartGameRequest) (*pb.StartGameResponse, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	response, err := client.StartGame(ctx, req)
+	return response, err
+}
+
+func (c *Client) EndGame(ctx context.Context, req *pb.EndGameRequest) (*pb.EndGameResponse, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	response, err := client.EndGame(ctx, req)
+	return response, err
+}
+
+func (c *Client) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	response, err := client.ListItems(ctx, req)
+	return response, err
+}
+
+func (c *Client) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.BaseItem, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	item, err := client.GetItem(ctx, req)
+	return item, err
+}
+
+func (c *Client) GetItemInstance(ctx context.Context, req *pb.GetItemInstanceRequest) (*pb.ItemInstance, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	item, err := client.GetItemInstance(ctx, req)
+	return item, err
+}
+
+func (c *Client) ListMemberItems(ctx context.Context, req *pb.ListMemberItemsRequest) (*pb.ListMemberItemsResponse, error) {
+	conn, err := discovery.ServiceConnection(ctx, serviceName, c.registry)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGameServiceClient(conn)
+
+	response, err := client.ListMemberItems(ctx, req)
+	return response, err
+}
@@ -0,0 +1,407 @@
+package game
+
+import (
+	"net/http"
+	"strconv"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/game"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Handler struct {
+	client GameClient
+}
+
+func NewHandler(client GameClient) *Handler {
+	return &Handler{
+		client: client,
+	}
+}
+
+/**
+* --- Rooms ---
+**/
+
+func (h *Handler) CreateRoomHandler(c *gin.Context) {
+	userIdStr, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var body CreateRoomRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"statusCode": http.StatusBadRequest, "message": "Error parsing payload as JSON"})
+		return
+	}
+
+	req := &pb.CreateRoomRequest{
+		Name:       body.Name,
+		CreatorId:  userIdStr,
+		MaxPlayers: body.MaxPlayers,
+		GameMode:   body.GameMode,
+	}
+
+	room, err := h.client.CreateRoom(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"statusCode": http.StatusCreated,
+		"message":    "Successfully created room",
+		"result":     room,
+	})
+}
+
+func (h *Handler) GetRoomsHandler(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"statusCode": http.StatusBadRequest, "message": "limit must be a number"})
+		return
+	}
+
+	offset, err := queryInt(c, "offset")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"statusCode": http.StatusBadRequest, "message": "offset must be a number"})
+		return
+	}
+
+	req := &pb.ListRoomsRequest{
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+		GameMode: c.Query("game_mode"),
+	}
+
+	response, err := h.client.ListRooms(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully retrieved rooms",
+		"result":     response,
+	})
+}
+
+func (h *Handler) GetRoomHandler(c *gin.Context) {
+	req := &pb.GetRoomRequest{
+		Id: c.Param("id"),
+	}
+
+	room, err := h.client.GetRoom(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully retrieved room",
+		"result":     room,
+	})
+}
+
+func (h *Handler) JoinRoomHandler(c *gin.Context) {
+	userIdStr, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	req := &pb.JoinRoomRequest{
+		RoomId: c.Param("id"),
+		UserId: userIdStr,
+	}
+
+	response, err := h.client.JoinRoom(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully joined room",
+		"result":     response,
+	})
+}
+
+func (h *Handler) LeaveRoomHandler(c *gin.Context) {
+	userIdStr, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	req := &pb.LeaveRoomRequest{
+		RoomId: c.Param("id"),
+		UserId: userIdStr,
+	}
+
+	response, err := h.client.LeaveRoom(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    response.Message,
+		"success":    response.Success,
+	})
+}
+
+func (h *Handler) StartGameHandler(c *gin.Context) {
+	if !h.requireRoomCreator(c) {
+		return
+	}
+
+	req := &pb.StartGameRequest{
+		RoomId: c.Param("id"),
+	}
+
+	response, err := h.client.StartGame(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully started game",
+		"result":     response,
+	})
+}
+
+func (h *Handler) EndGameHandler(c *gin.Context) {
+	if !h.requireRoomCreator(c) {
+		return
+	}
+
+	// winner is optional, games can end in a draw
+	var body EndGameRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"statusCode": http.StatusBadRequest, "message": "Error parsing payload as JSON"})
+			return
+		}
+	}
+
+	req := &pb.EndGameRequest{
+		RoomId:   c.Param("id"),
+		WinnerId: body.WinnerID,
+	}
+
+	response, err := h.client.EndGame(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully ended game",
+		"result":     response,
+	})
+}
+
+/**
+* only the room's creator controls when its game starts and ends.
+**/
+func (h *Handler) requireRoomCreator(c *gin.Context) bool {
+	userIdStr, ok := userIDFromContext(c)
+	if !ok {
+		return false
+	}
+
+	room, err := h.client.GetRoom(c.Request.Context(), &pb.GetRoomRequest{Id: c.Param("id")})
+	if err != nil {
+		writeGRPCError(c, err)
+		return false
+	}
+
+	if room.CreatorId != userIdStr {
+		c.JSON(http.StatusForbidden, gin.H{
+			"statusCode": http.StatusForbidden,
+			"message":    "Only the room creator can do this",
+		})
+		return false
+	}
+
+	return true
+}
+
+/**
+* --- Items ---
+**/
+
+func (h *Handler) GetItemsHandler(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"statusCode": http.StatusBadRequest, "message": "limit must be a number"})
+		return
+	}
+
+	offset, err := queryInt(c, "offset")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"statusCode": http.StatusBadRequest, "message": "offset must be a number"})
+		return
+	}
+
+	req := &pb.ListItemsRequest{
+		Category: c.Query("category"),
+		Slot:     c.Query("slot"),
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+	}
+
+	response, err := h.client.ListItems(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully retrieved items",
+		"result":     response,
+	})
+}
+
+func (h *Handler) GetItemHandler(c *gin.Context) {
+	req := &pb.GetItemRequest{
+		Id: c.Param("id"),
+	}
+
+	item, err := h.client.GetItem(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully retrieved item",
+		"result":     item,
+	})
+}
+
+func (h *Handler) GetMemberItemsHandler(c *gin.Context) {
+	userIdStr, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	req := &pb.ListMemberItemsRequest{
+		MemberId: userIdStr,
+	}
+
+	response, err := h.client.ListMemberItems(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully retrieved member items",
+		"result":     response,
+	})
+}
+
+func (h *Handler) GetMemberItemHandler(c *gin.Context) {
+	userIdStr, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	req := &pb.GetItemInstanceRequest{
+		Id: c.Param("id"),
+	}
+
+	item, err := h.client.GetItemInstance(c.Request.Context(), req)
+	if err != nil {
+		writeGRPCError(c, err)
+		return
+	}
+
+	// members can only look at their own items
+	if item.MemberId != userIdStr {
+		c.JSON(http.StatusNotFound, gin.H{
+			"statusCode": http.StatusNotFound,
+			"message":    "Item not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"message":    "Successfully retrieved member item",
+		"result":     item,
+	})
+}
+
+/**
+* gets the member ID set by the auth middleware, responding with unauthorized
+* when it's missing.
+**/
+func userIDFromContext(c *gin.Context) (string, bool) {
+	userIdStr, exists := c.Get("userIdStr")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"statusCode": http.StatusUnauthorized,
+			"message":    "User ID not found in context",
+		})
+		return "", false
+	}
+
+	return userIdStr.(string), true
+}
+
+/**
+* maps a grpc error from the game service onto an http response.
+**/
+func writeGRPCError(c *gin.Context, err error) {
+	status, ok := status.FromError(err)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"statusCode": http.StatusInternalServerError,
+			"message":    "Internal server error",
+		})
+		return
+	}
+
+	httpStatus := http.StatusInternalServerError
+	switch status.Code() {
+	case codes.InvalidArgument:
+		httpStatus = http.StatusBadRequest
+	case codes.NotFound:
+		httpStatus = http.StatusNotFound
+	case codes.AlreadyExists:
+		httpStatus = http.StatusConflict
+	case codes.FailedPrecondition:
+		httpStatus = http.StatusConflict
+	case codes.PermissionDenied:
+		httpStatus = http.StatusForbidden
+	case codes.Unauthenticated:
+		httpStatus = http.StatusUnauthorized
+	}
+
+	c.JSON(httpStatus, gin.H{
+		"statusCode": httpStatus,
+		"message":    status.Message(),
+	})
+}
+
+// empty query params fall back to zero so the service can apply its defaults
+func queryInt(c *gin.Context, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
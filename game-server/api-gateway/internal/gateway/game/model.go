@@ -0,0 +1,34 @@
+package game
+
+import (
+	"context"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/game"
+)
+
+type GameClient interface {
+	// rooms
+	CreateRoom(ctx context.Context, req *pb.CreateRoomRequest) (*pb.Room, error)
+	GetRoom(ctx context.Context, req *pb.GetRoomRequest) (*pb.Room, error)
+	ListRooms(ctx context.Context, req *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error)
+	JoinRoom(ctx context.Context, req *pb.JoinRoomRequest) (*pb.JoinRoomResponse, error)
+	LeaveRoom(ctx context.Context, req *pb.LeaveRoomRequest) (*pb.LeaveRoomResponse, error)
+	StartGame(ctx context.Context, req *pb.StartGameRequest) (*pb.StartGameResponse, error)
+	EndGame(ctx context.Context, req *pb.EndGameRequest) (*pb.EndGameResponse, error)
+
+	// items
+	ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error)
+	GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.BaseItem, error)
+	GetItemInstance(ctx context.Context, req *pb.GetItemInstanceRequest) (*pb.ItemInstance, error)
+	ListMemberItems(ctx context.Context, req *pb.ListMemberItemsRequest) (*pb.ListMemberItemsResponse, error)
+}
+
+type CreateRoomRequest struct {
+	Name       string `json:"name"`
+	MaxPlayers int32  `json:"maxPlayers"`
+	GameMode   string `json:"gameMode"`
+}
+
+type EndGameRequest struct {
+	WinnerID string `json:"winnerId"`
+}
@@ -19,26 +19,50 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GameService_CreateRoom_FullMethodName = "/game.GameService/CreateRoom"
-	GameService_GetRoom_FullMethodName    = "/game.GameService/GetRoom"
-	GameService_ListRooms_FullMethodName  = "/game.GameService/ListRooms"
-	GameService_JoinRoom_FullMethodName   = "/game.GameService/JoinRoom"
-	GameService_LeaveRoom_FullMethodName  = "/game.GameService/LeaveRoom"
-	GameService_StartGame_FullMethodName  = "/game.GameService/StartGame"
-	GameService_EndGame_FullMethodName    = "/game.GameService/EndGame"
+	GameService_CreateRoom_FullMethodName         = "/game.GameService/CreateRoom"
+	GameService_GetRoom_FullMethodName            = "/game.GameService/GetRoom"
+	GameService_ListRooms_FullMethodName          = "/game.GameService/ListRooms"
+	GameService_JoinRoom_FullMethodName           = "/game.GameService/JoinRoom"
+	GameService_LeaveRoom_FullMethodName          = "/game.GameService/LeaveRoom"
+	GameService_StartGame_FullMethodName          = "/game.GameService/StartGame"
+	GameService_EndGame_FullMethodName            = "/game.GameService/EndGame"
+	GameService_ListItems_FullMethodName          = "/game.GameService/ListItems"
+	GameService_GetItem_FullMethodName            = "/game.GameService/GetItem"
+	GameService_CreateItemInstance_FullMethodName = "/game.GameService/CreateItemInstance"
+	GameService_GetItemInstance_FullMethodName    = "/game.GameService/GetItemInstance"
+	GameService_ListMemberItems_FullMethodName    = "/game.GameService/ListMemberItems"
 )
 
 // GameServiceClient is the client API for GameService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Game service definition
 type GameServiceClient interface {
+	// Create a new room
 	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	// Get room by ID
 	GetRoom(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	// List open rooms
 	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	// Join a room
 	JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error)
+	// Leave a room
 	LeaveRoom(ctx context.Context, in *LeaveRoomRequest, opts ...grpc.CallOption) (*LeaveRoomResponse, error)
+	// Start the game in a room
 	StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error)
+	// End the game in a room
 	EndGame(ctx context.Context, in *EndGameRequest, opts ...grpc.CallOption) (*EndGameResponse, error)
+	// List base item definitions from the catalog
+	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error)
+	// Get a base item definition by ID
+	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*BaseItem, error)
+	// Create a member owned item, rolling its modifiers
+	CreateItemInstance(ctx context.Context, in *CreateItemInstanceRequest, opts ...grpc.CallOption) (*ItemInstance, error)
+	// Get a member owned item by ID
+	GetItemInstance(ctx context.Context, in *GetItemInstanceRequest, opts ...grpc.CallOption) (*ItemInstance, error)
+	// List every item a member owns
+	ListMemberItems(ctx context.Context, in *ListMemberItemsRequest, opts ...grpc.CallOption) (*ListMemberItemsResponse, error)
 }
 
 type gameServiceClient struct {
@@ -119,17 +143,86 @@ func (c *gameServiceClient) EndGame(ctx context.Context, in *EndGameRequest, opt
 	return out, nil
 }
 
+func (c *gameServiceClient) ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListItemsResponse)
+	err := c.cc.Invoke(ctx, GameService_ListItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*BaseItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BaseItem)
+	err := c.cc.Invoke(ctx, GameService_GetItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) CreateItemInstance(ctx context.Context, in *CreateItemInstanceRequest, opts ...grpc.CallOption) (*ItemInstance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ItemInstance)
+	err := c.cc.Invoke(ctx, GameService_CreateItemInstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetItemInstance(ctx context.Context, in *GetItemInstanceRequest, opts ...grpc.CallOption) (*ItemInstance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ItemInstance)
+	err := c.cc.Invoke(ctx, GameService_GetItemInstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) ListMemberItems(ctx context.Context, in *ListMemberItemsRequest, opts ...grpc.CallOption) (*ListMemberItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMemberItemsResponse)
+	err := c.cc.Invoke(ctx, GameService_ListMemberItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameServiceServer is the server API for GameService service.
 // All implementations must embed UnimplementedGameServiceServer
 // for forward compatibility.
+//
+// Game service definition
 type GameServiceServer interface {
+	// Create a new room
 	CreateRoom(context.Context, *CreateRoomRequest) (*Room, error)
+	// Get room by ID
 	GetRoom(context.Context, *GetRoomRequest) (*Room, error)
+	// List open rooms
 	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	// Join a room
 	JoinRoom(context.Context, *JoinRoomRequest) (*JoinRoomResponse, error)
+	// Leave a room
 	LeaveRoom(context.Context, *LeaveRoomRequest) (*LeaveRoomResponse, error)
+	// Start the game in a room
 	StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error)
+	// End the game in a room
 	EndGame(context.Context, *EndGameRequest) (*EndGameResponse, error)
+	// List base item definitions from the catalog
+	ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error)
+	// Get a base item definition by ID
+	GetItem(context.Context, *GetItemRequest) (*BaseItem, error)
+	// Create a member owned item, rolling its modifiers
+	CreateItemInstance(context.Context, *CreateItemInstanceRequest) (*ItemInstance, error)
+	// Get a member owned item by ID
+	GetItemInstance(context.Context, *GetItemInstanceRequest) (*ItemInstance, error)
+	// List every item a member owns
+	ListMemberItems(context.Context, *ListMemberItemsRequest) (*ListMemberItemsResponse, error)
 	mustEmbedUnimplementedGameServiceServer()
 }
 
@@ -161,6 +254,21 @@ func (UnimplementedGameServiceServer) StartGame(context.Context, *StartGameReque
 func (UnimplementedGameServiceServer) EndGame(context.Context, *EndGameRequest) (*EndGameResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EndGame not implemented")
 }
+func (UnimplementedGameServiceServer) ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListItems not implemented")
+}
+func (UnimplementedGameServiceServer) GetItem(context.Context, *GetItemRequest) (*BaseItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedGameServiceServer) CreateItemInstance(context.Context, *CreateItemInstanceRequest) (*ItemInstance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateItemInstance not implemented")
+}
+func (UnimplementedGameServiceServer) GetItemInstance(context.Context, *GetItemInstanceRequest) (*ItemInstance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItemInstance not implemented")
+}
+func (UnimplementedGameServiceServer) ListMemberItems(context.Context, *ListMemberItemsRequest) (*ListMemberItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMemberItems not implemented")
+}
 func (UnimplementedGameServiceServer) mustEmbedUnimplementedGameServiceServer() {}
 func (UnimplementedGameServiceServer) testEmbeddedByValue()                     {}
 
@@ -308,6 +416,96 @@ func _GameService_EndGame_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_ListItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ListItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListItems(ctx, req.(*ListItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetItem(ctx, req.(*GetItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_CreateItemInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateItemInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).CreateItemInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_CreateItemInstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).CreateItemInstance(ctx, req.(*CreateItemInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetItemInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetItemInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetItemInstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetItemInstance(ctx, req.(*GetItemInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_ListMemberItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMemberItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListMemberItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ListMemberItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListMemberItems(ctx, req.(*ListMemberItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GameService_ServiceDesc is the grpc.ServiceDesc for GameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -343,6 +541,26 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EndGame",
 			Handler:    _GameService_EndGame_Handler,
 		},
+		{
+			MethodName: "ListItems",
+			Handler:    _GameService_ListItems_Handler,
+		},
+		{
+			MethodName: "GetItem",
+			Handler:    _GameService_GetItem_Handler,
+		},
+		{
+			MethodName: "CreateItemInstance",
+			Handler:    _GameService_CreateItemInstance_Handler,
+		},
+		{
+			MethodName: "GetItemInstance",
+			Handler:    _GameService_GetItemInstance_Handler,
+		},
+		{
+			MethodName: "ListMemberItems",
+			Handler:    _GameService_ListMemberItems_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/game/game.proto",
@@ -21,6 +21,9 @@ const (
 
 	// Item events
 	ItemCreatedItemEvent = "item.created" // when item is created
+
+	// Matchmaking events
+	MatchAllocatedEvent = "matchmaking.match_allocated" // when a match is given to a game node
 )
 
 /**
@@ -2,9 +2,13 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCacheMiss 當 key 不存在時由 Get 回傳
+var ErrCacheMiss = errors.New("cache: key does not exist")
+
 type Cache interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Get(ctx context.Context, key string) (string, error)
@@ -13,4 +17,7 @@ type Cache interface {
 	Close() error
 	Lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
 	Unlock(ctx context.Context, key string) error
+	// 以 token 獲取的鎖只有持有同一個 token 才能釋放
+	LockWithToken(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
+	UnlockWithToken(ctx context.Context, key string, token string) error
 }
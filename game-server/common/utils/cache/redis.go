@@ -2,12 +2,21 @@ package cache
 
 import (
 	context "context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// 只在鎖仍屬於同一個 token 時才刪除，避免刪掉過期後被別人取得的鎖
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
 type redisClient struct {
 	client redis.UniversalClient
 }
@@ -21,7 +30,11 @@ func (r *redisClient) Set(ctx context.Context, key string, value interface{}, ex
 }
 
 func (r *redisClient) Get(ctx context.Context, key string) (string, error) {
-	return r.client.Get(ctx, key).Result()
+	value, err := r.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrCacheMiss
+	}
+	return value, err
 }
 
 func (r *redisClient) Del(ctx context.Context, keys ...string) error {
@@ -57,3 +70,20 @@ func (r *redisClient) Unlock(ctx context.Context, key string) error {
 	lockKey := fmt.Sprintf("lock:%s", key)
 	return r.client.Del(ctx, lockKey).Err()
 }
+
+// LockWithToken 以 token 獲取分散式鎖
+func (r *redisClient) LockWithToken(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	lockKey := fmt.Sprintf("lock:%s", key)
+	result, err := r.client.SetNX(ctx, lockKey, token, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	return result, nil
+}
+
+// UnlockWithToken 只釋放仍由該 token 持有的鎖
+func (r *redisClient) UnlockWithToken(ctx context.Context, key string, token string) error {
+	lockKey := fmt.Sprintf("lock:%s", key)
+	return unlockScript.Run(ctx, r.client, []string{lockKey}, token).Err()
+}
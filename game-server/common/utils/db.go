@@ -1,6 +1,7 @@
 package commonhelpers
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
@@ -15,11 +16,19 @@ import (
 * with the initiation, passing, and error checking of that transaction.
 **/
 func ExecTx(db *sqlx.DB, fn func(tx *sqlx.Tx) error) (err error) {
-	tx, txBeginErr := db.Beginx() // NOTE: use "beginx" for the **sqlx* version
+	return ExecTxContext(context.Background(), db, fn)
+}
+
+/**
+* Same as ExecTx, with the transaction bound to ctx. It's rolled back if ctx
+* is done before it commits.
+**/
+func ExecTxContext(ctx context.Context, db *sqlx.DB, fn func(tx *sqlx.Tx) error) (err error) {
+	tx, txBeginErr := db.BeginTxx(ctx, nil) // NOTE: use "begintxx" for the *sqlx* version
 
 	if txBeginErr != nil {
 		fmt.Printf("Error when attempting to start transaction: %v\n", txBeginErr)
-		return fmt.Errorf("Error when attempting to start transaction: %v", txBeginErr)
+		return fmt.Errorf("Error when attempting to start transaction: %w", txBeginErr)
 	}
 
 	// handles panics and errors and rollback if they occur
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/google/uuid"
+)
+
+/**
+* Actions the client sends, one method per action with its payload typed.
+**/
+
+/**
+* queues for a match, an empty queue id joins the default queue.
+**/
+func (c *Client) FindGame(queueID string) error {
+	payload := map[string]interface{}{}
+	if queueID != "" {
+		payload["queue_id"] = queueID
+	}
+
+	return c.Send(constants.ActionFindGame, payload)
+}
+
+/**
+* asks the server for a pong, Latency has the round trip once it's back.
+**/
+func (c *Client) Ping() error {
+	return c.Send(constants.ActionPing, map[string]interface{}{
+		"sent_at": time.Now().UnixMicro(),
+	})
+}
+
+func (c *Client) LeaveQueue() error {
+	return c.Send(constants.ActionLeaveQueue, nil)
+}
+
+func (c *Client) RespondToReadyCheck(checkID uuid.UUID, accept bool) error {
+	return c.Send(constants.ActionReadyCheckResponse, map[string]interface{}{
+		"check_id": checkID.String(),
+		"accept":   accept,
+	})
+}
+
+/**
+* sets the player's velocity, they keep moving until it's changed.
+**/
+func (c *Client) Move(vx, vy float64) error {
+	return c.sendInSession(constants.ActionMove, map[string]interface{}{
+		"vx": vx,
+		"vy": vy,
+	})
+}
+
+/**
+* interacts with an entity, an empty verb uses the entity's default one.
+**/
+func (c *Client) Interact(entityID uuid.UUID, verb string) error {
+	payload := map[string]interface{}{
+		"entity_id": entityID.String(),
+	}
+	if verb != "" {
+		payload["verb"] = verb
+	}
+
+	return c.sendInSession(constants.ActionInteract, payload)
+}
+
+/**
+* fires the projectile from the player's position in the direction given.
+**/
+func (c *Client) Attack(projectile string, dx, dy float64) error {
+	return c.sendInSession(constants.ActionAttack, map[string]interface{}{
+		"projectile": projectile,
+		"dx":         dx,
+		"dy":         dy,
+	})
+}
+
+/**
+* sends a chat message to everyone in the game, or constants.ChatChannelTeam
+* for the player's team only.
+**/
+func (c *Client) Chat(channel, message string) error {
+	if channel == "" {
+		channel = constants.ChatChannelAll
+	}
+
+	return c.sendInSession(constants.ActionChat, map[string]interface{}{
+		"channel": channel,
+		"message": message,
+	})
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+/**
+* signing in through the api gateway for an access token.
+**/
+
+type signInRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// the gateway's response envelope around the auth service's login response
+type signInResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+	Result     struct {
+		AccessToken string `json:"access_token"`
+		MemberInfo  struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"member_info"`
+	} `json:"result"`
+}
+
+/**
+* signs in with the configured email and password, keeping the access token
+* for connecting.
+**/
+func (c *Client) Login(ctx context.Context) error {
+	if c.config.Email == "" {
+		return fmt.Errorf("sign in needs an email and password: %w", ErrUnauthorized)
+	}
+
+	body, err := json.Marshal(signInRequest{Email: c.config.Email, Password: c.config.Password})
+	if err != nil {
+		return err
+	}
+
+	endpoint := strings.TrimSuffix(c.config.GatewayURL, "/") + "/api/member/signin"
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid gateway url %s: %w", c.config.GatewayURL, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.config.HTTPClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to reach the gateway: %w", err)
+	}
+	defer response.Body.Close()
+
+	var signIn signInResponse
+	if err := json.NewDecoder(response.Body).Decode(&signIn); err != nil {
+		return fmt.Errorf("failed to decode sign in response: %w", err)
+	}
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("sign in failed, %s: %w", signIn.Message, ErrUnauthorized)
+	case response.StatusCode != http.StatusOK:
+		return fmt.Errorf("sign in failed with status %d: %s", response.StatusCode, signIn.Message)
+	case signIn.Result.AccessToken == "":
+		return fmt.Errorf("sign in response had no access token")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = signIn.Result.AccessToken
+	if playerID, err := uuid.Parse(signIn.Result.MemberInfo.ID); err == nil {
+		c.playerID = playerID
+	}
+	c.username = signIn.Result.MemberInfo.Name
+
+	return nil
+}
@@ -0,0 +1,482 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/protocol"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+/**
+* Game client
+*
+* Headless client for the game service websocket protocol. It signs in
+* through the api gateway, connects to /game/ws with the access token and
+* turns the protocol's action and payload maps into typed calls and
+* callbacks, so tools, bots and tests can drive the server like a real
+* player. Dropped connections are redialed and the server resumes any game
+* the player was in.
+**/
+
+var (
+	ErrNotConnected = errors.New("Client is not connected to the game service.")
+	ErrNotInSession = errors.New("Client is not in a game session.")
+	ErrClosed       = errors.New("Client has been closed.")
+	ErrUnauthorized = errors.New("Credentials or token were rejected.")
+)
+
+type Config struct {
+	// api gateway used to sign in, e.g. http://localhost:8080
+	GatewayURL string
+	// game service the websocket connects to, e.g. ws://localhost:5555
+	GameURL string
+
+	Email    string
+	Password string
+	// an access token from an earlier sign in, skips signing in
+	Token string
+
+	// times a dropped connection is redialed before giving up, 0 never
+	// redials
+	MaxReconnects int
+	// wait before the first redial, doubled after every failed one
+	ReconnectDelay time.Duration
+
+	HTTPClient *http.Client
+	Dialer     *websocket.Dialer
+
+	// asks the server for the binary protobuf encoding, JSON is used when
+	// unset or when the server doesn't offer it
+	Protobuf bool
+}
+
+const (
+	defaultReconnectDelay = time.Second
+	dialTimeout           = 10 * time.Second
+)
+
+type Client struct {
+	config Config
+
+	mu        sync.RWMutex
+	conn      *websocket.Conn
+	token     string
+	playerID  uuid.UUID
+	username  string
+	sessionID uuid.UUID
+	closed    bool
+	// round trip of the last ping answered
+	latency time.Duration
+
+	// [action] to the callbacks for events with that action
+	handlers     map[constants.Action][]func(Event)
+	onError      []func(*ServerError)
+	onDisconnect []func(error)
+	onReconnect  []func()
+
+	// a websocket connection takes one writer at a time
+	writeMu sync.Mutex
+	done    chan struct{}
+}
+
+func New(config Config) *Client {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: dialTimeout}
+	}
+
+	if config.Dialer == nil {
+		config.Dialer = websocket.DefaultDialer
+	}
+
+	if config.ReconnectDelay <= 0 {
+		config.ReconnectDelay = defaultReconnectDelay
+	}
+
+	c := &Client{
+		config:   config,
+		token:    config.Token,
+		handlers: make(map[constants.Action][]func(Event)),
+		done:     make(chan struct{}),
+	}
+
+	// the client keeps track of who it is and which game it's in
+	c.On(constants.ActionFindGame, c.trackPlayer)
+	c.On(constants.ActionGameFound, c.trackSession)
+	c.On(constants.ActionSessionResume, c.trackSession)
+	c.On(constants.ActionPing, c.trackLatency)
+
+	return c
+}
+
+/**
+* signs in when the client has no token yet and opens the websocket.
+**/
+func (c *Client) Connect(ctx context.Context) error {
+	if c.Token() == "" {
+		if err := c.Login(ctx); err != nil {
+			return err
+		}
+	}
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !c.attach(conn) {
+		conn.Close()
+		return ErrClosed
+	}
+
+	go c.readLoop(conn)
+	return nil
+}
+
+/**
+* closes the connection for good, the client won't redial.
+**/
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+
+	conn := c.conn
+	c.conn = nil
+	c.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+
+	c.writeMu.Lock()
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.writeMu.Unlock()
+
+	return conn.Close()
+}
+
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.token
+}
+
+/**
+* the signed in player, nil until the gateway or the server said who it is.
+**/
+func (c *Client) PlayerID() uuid.UUID {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.playerID
+}
+
+func (c *Client) Username() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.username
+}
+
+/**
+* the game the player is in, nil when they're not in one.
+**/
+func (c *Client) SessionID() uuid.UUID {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sessionID
+}
+
+/**
+* points the client at a game, e.g. one joined through a lobby.
+**/
+func (c *Client) SetSessionID(sessionID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sessionID = sessionID
+}
+
+func (c *Client) Connected() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.conn != nil
+}
+
+func (c *Client) dial(ctx context.Context) (*websocket.Conn, error) {
+	endpoint, err := url.Parse(c.config.GameURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid game url %s: %w", c.config.GameURL, err)
+	}
+
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/game/ws"
+	query := endpoint.Query()
+	query.Set("token", c.Token())
+	endpoint.RawQuery = query.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	dialer := *c.config.Dialer
+	if c.config.Protobuf {
+		dialer.Subprotocols = []string{protocol.SubprotocolProtobuf}
+	}
+
+	conn, response, err := dialer.DialContext(ctx, endpoint.String(), nil)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusUnauthorized {
+			return nil, fmt.Errorf("failed to connect to the game service: %w", ErrUnauthorized)
+		}
+		return nil, fmt.Errorf("failed to connect to the game service: %w", err)
+	}
+
+	return conn, nil
+}
+
+/**
+* makes conn the client's connection, false once the client is closed.
+**/
+func (c *Client) attach(conn *websocket.Conn) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return false
+	}
+
+	c.conn = conn
+	return true
+}
+
+func (c *Client) readLoop(conn *websocket.Conn) {
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			c.dropped(conn, err)
+			return
+		}
+
+		var response types.ServerResponse
+		if err := protocol.For(conn).Decode(raw, &response); err != nil {
+			fmt.Printf("Game client received a message it couldn't decode: %v\n", err)
+			continue
+		}
+
+		c.dispatch(Event{
+			Action:    constants.Action(response.Action),
+			Payload:   response.Payload,
+			Success:   response.Success,
+			Error:     response.Error,
+			RequestID: response.RequestID,
+		})
+	}
+}
+
+/**
+* lets everyone know the connection dropped and redials, unless the client
+* closed it or already replaced it.
+**/
+func (c *Client) dropped(conn *websocket.Conn, err error) {
+	c.mu.Lock()
+	if c.conn != conn {
+		c.mu.Unlock()
+		return
+	}
+	c.conn = nil
+	closed := c.closed
+	handlers := append([]func(error){}, c.onDisconnect...)
+	c.mu.Unlock()
+
+	conn.Close()
+
+	if closed {
+		return
+	}
+
+	for _, handler := range handlers {
+		handler(err)
+	}
+
+	c.reconnect()
+}
+
+func (c *Client) reconnect() {
+	delay := c.config.ReconnectDelay
+
+	for attempt := 1; attempt <= c.config.MaxReconnects; attempt++ {
+		select {
+		case <-c.done:
+			return
+		case <-time.After(delay):
+		}
+
+		conn, err := c.dial(context.Background())
+
+		// the token ran out, signing in again gets a new one
+		if errors.Is(err, ErrUnauthorized) && c.config.Email != "" {
+			if loginErr := c.Login(context.Background()); loginErr == nil {
+				conn, err = c.dial(context.Background())
+			}
+		}
+
+		if err != nil {
+			fmt.Printf("Game client reconnect attempt %d/%d failed: %v\n", attempt, c.config.MaxReconnects, err)
+			delay *= 2
+			continue
+		}
+
+		if !c.attach(conn) {
+			conn.Close()
+			return
+		}
+
+		go c.readLoop(conn)
+
+		c.mu.RLock()
+		handlers := append([]func(){}, c.onReconnect...)
+		c.mu.RUnlock()
+
+		for _, handler := range handlers {
+			handler()
+		}
+		return
+	}
+}
+
+func (c *Client) dispatch(event Event) {
+	c.mu.RLock()
+	handlers := append([]func(Event){}, c.handlers[event.Action]...)
+	errorHandlers := append([]func(*ServerError){}, c.onError...)
+	c.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+
+	if failure := event.Failure(); failure != nil {
+		for _, handler := range errorHandlers {
+			handler(failure)
+		}
+	}
+}
+
+/**
+* sends an action with its payload to the server.
+**/
+func (c *Client) Send(action constants.Action, payload map[string]interface{}) error {
+	return c.send(types.Message{Action: string(action), Payload: payload})
+}
+
+/**
+* sends an action tagged with a fresh request id and returns it. The server
+* echoes it on the reply, success or failure, so results can be matched to
+* the message that caused them.
+**/
+func (c *Client) SendRequest(action constants.Action, payload map[string]interface{}) (string, error) {
+	requestID := uuid.NewString()
+	return requestID, c.send(types.Message{Action: string(action), Payload: payload, RequestID: requestID})
+}
+
+func (c *Client) send(message types.Message) error {
+	c.mu.RLock()
+	conn := c.conn
+	c.mu.RUnlock()
+
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	if message.Payload == nil {
+		message.Payload = map[string]interface{}{}
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return protocol.Write(conn, message)
+}
+
+/**
+* sends an action for the game the player is in, filling in the session and
+* player ids the server routes it by.
+**/
+func (c *Client) sendInSession(action constants.Action, payload map[string]interface{}) error {
+	c.mu.RLock()
+	sessionID, playerID := c.sessionID, c.playerID
+	c.mu.RUnlock()
+
+	if sessionID == uuid.Nil || playerID == uuid.Nil {
+		return ErrNotInSession
+	}
+
+	payload["session_id"] = sessionID.String()
+	payload["player_id"] = playerID.String()
+
+	return c.Send(action, payload)
+}
+
+func (c *Client) trackPlayer(event Event) {
+	var queued Queued
+	if event.Failure() != nil || event.Decode(&queued) != nil || queued.PlayerID == uuid.Nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.playerID = queued.PlayerID
+	if queued.Username != "" {
+		c.username = queued.Username
+	}
+}
+
+func (c *Client) trackSession(event Event) {
+	var found struct {
+		SessionID uuid.UUID `json:"session_id"`
+	}
+	if event.Decode(&found) != nil || found.SessionID == uuid.Nil {
+		return
+	}
+
+	c.SetSessionID(found.SessionID)
+}
+
+func (c *Client) trackLatency(event Event) {
+	var pong Pong
+	if event.Failure() != nil || event.Decode(&pong) != nil || pong.SentAt == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.latency = time.Since(time.UnixMicro(pong.SentAt))
+}
+
+/**
+* the round trip measured by the last ping the server answered, zero before
+* any were.
+**/
+func (c *Client) Latency() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.latency
+}
@@ -0,0 +1,325 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/protocol"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing the client against a fake gateway and game service.
+**/
+
+// fakeGame accepts websocket connections and records what clients send
+type fakeGame struct {
+	t        *testing.T
+	upgrader websocket.Upgrader
+
+	mu       sync.Mutex
+	conns    []*websocket.Conn
+	tokens   []string
+	received chan types.Message
+}
+
+func newFakeGame(t *testing.T) (*fakeGame, *httptest.Server) {
+	game := &fakeGame{t: t, received: make(chan types.Message, 10)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/ws" || r.URL.Query().Get("token") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := game.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		game.mu.Lock()
+		game.conns = append(game.conns, conn)
+		game.tokens = append(game.tokens, r.URL.Query().Get("token"))
+		game.mu.Unlock()
+
+		for {
+			var message types.Message
+			if err := conn.ReadJSON(&message); err != nil {
+				return
+			}
+			game.received <- message
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return game, server
+}
+
+func (g *fakeGame) latest() *websocket.Conn {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.conns[len(g.conns)-1]
+}
+
+func (g *fakeGame) connections() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return len(g.conns)
+}
+
+func (g *fakeGame) send(response interface{}) {
+	require.NoError(g.t, g.latest().WriteJSON(response))
+}
+
+func (g *fakeGame) expect(action constants.Action) types.Message {
+	g.t.Helper()
+
+	select {
+	case message := <-g.received:
+		require.Equal(g.t, string(action), message.Action)
+		return message
+	case <-time.After(2 * time.Second):
+		g.t.Fatalf("timed out waiting for %s", action)
+		return types.Message{}
+	}
+}
+
+func newFakeGateway(t *testing.T, playerID uuid.UUID) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request signInRequest
+		json.NewDecoder(r.Body).Decode(&request)
+
+		if r.URL.Path != "/api/member/signin" || request.Password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{"statusCode": 401, "message": "invalid credentials"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"statusCode": 200,
+			"message":    "Successfully logged in",
+			"result": map[string]interface{}{
+				"access_token": "access-token",
+				"member_info":  map[string]interface{}{"id": playerID.String(), "name": "pilot"},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// TestClientPlaysThroughProtocol tests signing in, queueing and sending game actions
+func TestClientPlaysThroughProtocol(t *testing.T) {
+	playerID := uuid.New()
+	gateway := newFakeGateway(t, playerID)
+	game, gameServer := newFakeGame(t)
+
+	c := New(Config{GatewayURL: gateway.URL, GameURL: wsURL(gameServer), Email: "pilot@test.com", Password: "secret"})
+	defer c.Close()
+
+	checks := make(chan ReadyCheck, 1)
+	found := make(chan GameFound, 1)
+	failures := make(chan *ServerError, 1)
+	c.OnReadyCheck(func(check ReadyCheck) { checks <- check })
+	c.OnGameFound(func(game GameFound) { found <- game })
+	c.OnError(func(err *ServerError) { failures <- err })
+
+	require.NoError(t, c.Connect(context.Background()))
+	assert.Equal(t, playerID, c.PlayerID())
+	assert.Equal(t, "access-token", c.Token())
+
+	// nothing to move in yet
+	assert.ErrorIs(t, c.Move(1, 0), ErrNotInSession)
+
+	require.NoError(t, c.FindGame("coop_duo"))
+	assert.Equal(t, "coop_duo", game.expect(constants.ActionFindGame).Payload["queue_id"])
+
+	checkID := uuid.New()
+	game.send(types.Message{
+		Action:  string(constants.ActionReadyCheck),
+		Payload: map[string]interface{}{"check_id": checkID.String(), "queue_id": "coop_duo", "players": 2},
+	})
+
+	check := <-checks
+	assert.Equal(t, checkID, check.CheckID)
+	assert.Equal(t, 2, check.Players)
+
+	require.NoError(t, c.RespondToReadyCheck(check.CheckID, true))
+	answer := game.expect(constants.ActionReadyCheckResponse)
+	assert.Equal(t, true, answer.Payload["accept"])
+
+	sessionID := uuid.New()
+	game.send(types.Message{
+		Action:  string(constants.ActionGameFound),
+		Payload: map[string]interface{}{"session_id": sessionID.String(), "queue_id": "coop_duo"},
+	})
+	assert.Equal(t, sessionID, (<-found).SessionID)
+	assert.Equal(t, sessionID, c.SessionID())
+
+	require.NoError(t, c.Move(1, 0.5))
+	move := game.expect(constants.ActionMove)
+	assert.Equal(t, sessionID.String(), move.Payload["session_id"])
+	assert.Equal(t, playerID.String(), move.Payload["player_id"])
+	assert.Equal(t, 0.5, move.Payload["vy"])
+
+	require.NoError(t, c.Attack("Fireball", 0, 1))
+	assert.Equal(t, "Fireball", game.expect(constants.ActionAttack).Payload["projectile"])
+
+	pongs := make(chan Pong, 1)
+	c.OnPong(func(pong Pong) { pongs <- pong })
+
+	require.NoError(t, c.Ping())
+	ping := game.expect(constants.ActionPing)
+	game.send(types.Message{
+		Action:  string(constants.ActionPing),
+		Payload: map[string]interface{}{"success": true, "sent_at": ping.Payload["sent_at"], "latency_ms": 12.5},
+	})
+	assert.Equal(t, 12.5, (<-pongs).LatencyMs)
+	assert.Greater(t, c.Latency(), time.Duration(0))
+
+	// both failure shapes reach the error callbacks
+	game.send(types.ServerResponse{
+		Action: string(constants.ActionChat),
+		Error:  &types.ErrorResponse{Code: string(constants.ErrorSessionBusy), Message: "busy"},
+	})
+	assert.Equal(t, constants.ErrorSessionBusy, (<-failures).Code)
+
+	requestID, err := c.SendRequest(constants.ActionAttack, map[string]interface{}{"projectile": "Fireball"})
+	require.NoError(t, err)
+	assert.Equal(t, requestID, game.expect(constants.ActionAttack).RequestID)
+
+	game.send(types.Message{
+		Action:    string(constants.ActionAttack),
+		RequestID: requestID,
+		Payload:   map[string]interface{}{"success": false, "reason": string(constants.ErrorInvalidPayload), "message": "bad"},
+	})
+	failure := <-failures
+	assert.Equal(t, constants.ErrorInvalidPayload, failure.Code)
+	assert.Equal(t, requestID, failure.RequestID)
+}
+
+// TestClientReconnects tests dropped connections are redialed with the same token
+func TestClientReconnects(t *testing.T) {
+	game, gameServer := newFakeGame(t)
+
+	c := New(Config{GameURL: wsURL(gameServer), Token: "saved-token", MaxReconnects: 3, ReconnectDelay: 10 * time.Millisecond})
+	defer c.Close()
+
+	reconnected := make(chan struct{}, 1)
+	resumed := make(chan SessionResume, 1)
+	c.OnReconnect(func() { reconnected <- struct{}{} })
+	c.OnSessionResume(func(resume SessionResume) { resumed <- resume })
+
+	require.NoError(t, c.Connect(context.Background()))
+	require.Eventually(t, func() bool { return game.connections() == 1 }, time.Second, 10*time.Millisecond)
+
+	game.latest().Close()
+
+	select {
+	case <-reconnected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not reconnect")
+	}
+	require.Eventually(t, func() bool { return game.connections() == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"saved-token", "saved-token"}, game.tokens)
+
+	sessionID := uuid.New()
+	game.send(types.Message{
+		Action:  string(constants.ActionSessionResume),
+		Payload: map[string]interface{}{"session_id": sessionID.String()},
+	})
+	assert.Equal(t, sessionID, (<-resumed).SessionID)
+	assert.Equal(t, sessionID, c.SessionID())
+
+	// closing for good doesn't redial
+	require.NoError(t, c.Close())
+	assert.False(t, c.Connected())
+	assert.ErrorIs(t, c.FindGame(""), ErrNotConnected)
+}
+
+// TestClientLoginRejected tests bad credentials fail to connect
+func TestClientLoginRejected(t *testing.T) {
+	gateway := newFakeGateway(t, uuid.New())
+	_, gameServer := newFakeGame(t)
+
+	c := New(Config{GatewayURL: gateway.URL, GameURL: wsURL(gameServer), Email: "pilot@test.com", Password: "wrong"})
+	defer c.Close()
+
+	assert.ErrorIs(t, c.Connect(context.Background()), ErrUnauthorized)
+	assert.False(t, c.Connected())
+}
+
+// TestClientNegotiatesProtobuf tests the client speaks protobuf when asked and offered
+func TestClientNegotiatesProtobuf(t *testing.T) {
+	upgrader := websocket.Upgrader{Subprotocols: protocol.Subprotocols}
+	received := make(chan types.Message, 1)
+	conns := make(chan *websocket.Conn, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns <- conn
+
+		for {
+			frameType, data, err := conn.ReadMessage()
+			if err != nil || frameType != websocket.BinaryMessage {
+				return
+			}
+
+			var message types.Message
+			if protocol.Protobuf.Decode(data, &message) == nil {
+				received <- message
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(Config{GameURL: wsURL(server), Token: "token", Protobuf: true})
+	defer c.Close()
+
+	found := make(chan GameFound, 1)
+	c.OnGameFound(func(game GameFound) { found <- game })
+
+	require.NoError(t, c.Connect(context.Background()))
+	conn := <-conns
+	assert.Equal(t, protocol.SubprotocolProtobuf, conn.Subprotocol())
+
+	requestID, err := c.SendRequest(constants.ActionFindGame, map[string]interface{}{"queue_id": "coop_duo"})
+	require.NoError(t, err)
+
+	select {
+	case message := <-received:
+		assert.Equal(t, string(constants.ActionFindGame), message.Action)
+		assert.Equal(t, requestID, message.RequestID)
+		assert.Equal(t, "coop_duo", message.Payload["queue_id"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("server never got the find_game")
+	}
+
+	sessionID := uuid.New()
+	require.NoError(t, protocol.Write(conn, types.Message{
+		Action:  string(constants.ActionGameFound),
+		Payload: map[string]interface{}{"session_id": sessionID.String(), "queue_id": "coop_duo"},
+	}))
+	assert.Equal(t, sessionID, (<-found).SessionID)
+}
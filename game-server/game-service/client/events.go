@@ -0,0 +1,281 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Events the server sends and the callbacks registered for them.
+**/
+
+/**
+* a message from the server. Replies to a request keep the request's action,
+* failures carry either an error or a payload with success false.
+**/
+type Event struct {
+	Action  constants.Action       `json:"action"`
+	Payload map[string]interface{} `json:"payload"`
+	Success bool                   `json:"success,omitempty"`
+	Error   *types.ErrorResponse   `json:"error,omitempty"`
+	// the request id of the message this replies to, if it was sent with one
+	RequestID string `json:"request_id,omitempty"`
+}
+
+/**
+* decodes the payload into one of the typed events.
+**/
+func (e Event) Decode(v interface{}) error {
+	raw, err := json.Marshal(e.Payload)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+/**
+* what went wrong when the event reports a failure, nil otherwise.
+**/
+func (e Event) Failure() *ServerError {
+	if e.Error != nil {
+		return &ServerError{
+			Action:    e.Action,
+			RequestID: e.RequestID,
+			Code:      constants.ErrorCode(e.Error.Code),
+			Message:   e.Error.Message,
+			Fields:    e.Error.Fields,
+		}
+	}
+
+	if success, ok := e.Payload["success"].(bool); ok && !success {
+		reason, _ := e.Payload["reason"].(string)
+		message, _ := e.Payload["message"].(string)
+
+		var rejected struct {
+			Fields []types.FieldError `json:"fields"`
+		}
+		e.Decode(&rejected)
+
+		return &ServerError{
+			Action:    e.Action,
+			RequestID: e.RequestID,
+			Code:      constants.ErrorCode(reason),
+			Message:   message,
+			Fields:    rejected.Fields,
+		}
+	}
+
+	return nil
+}
+
+type ServerError struct {
+	Action    constants.Action
+	RequestID string
+	Code      constants.ErrorCode
+	Message   string
+	// the fields at fault when a payload was rejected
+	Fields []types.FieldError
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("%s failed with %s: %s", e.Action, e.Code, e.Message)
+}
+
+// the player joined a matchmaking queue
+type Queued struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Username string    `json:"username"`
+	QueueID  string    `json:"queue_id"`
+}
+
+// a match was found and has to be accepted
+type ReadyCheck struct {
+	CheckID     uuid.UUID `json:"check_id"`
+	QueueID     string    `json:"queue_id"`
+	GameMode    string    `json:"game_mode"`
+	Players     int       `json:"players"`
+	TimeoutSecs int       `json:"timeout_secs"`
+	// the match fills open slots in a game already being played
+	Backfill  bool      `json:"backfill"`
+	SessionID uuid.UUID `json:"session_id"`
+}
+
+// the player's game started, or they joined one in progress
+type GameFound struct {
+	SessionID uuid.UUID `json:"session_id"`
+	QueueID   string    `json:"queue_id"`
+	Backfill  bool      `json:"backfill"`
+	// only sent when joining a game in progress
+	State *types.ClientGameState `json:"state,omitempty"`
+}
+
+// the player reconnected into the game they were in
+type SessionResume struct {
+	SessionID uuid.UUID              `json:"session_id"`
+	State     *types.ClientGameState `json:"state"`
+}
+
+type ChatMessage struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Username string    `json:"username"`
+	Channel  string    `json:"channel"`
+	Message  string    `json:"message"`
+}
+
+// the outcome of an interact, the rest of the payload depends on the verb
+type InteractResult struct {
+	Success  bool      `json:"success"`
+	EntityID uuid.UUID `json:"entity_id"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+}
+
+// the server's answer to a ping
+type Pong struct {
+	// the client's clock when it sent the ping, in microseconds
+	SentAt int64 `json:"sent_at"`
+	// the server's clock when it answered, in milliseconds
+	ServerTime int64 `json:"server_time"`
+	// the round trip the server measured with websocket pings, zero until
+	// it has one
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+/**
+* registers a callback for every event with the action. Callbacks run on the
+* client's read goroutine, one at a time, and must not block.
+**/
+func (c *Client) On(action constants.Action, handler func(Event)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.handlers[action] = append(c.handlers[action], handler)
+}
+
+/**
+* registers a callback for every failure the server reports.
+**/
+func (c *Client) OnError(handler func(*ServerError)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onError = append(c.onError, handler)
+}
+
+/**
+* registers a callback for when the connection drops, before redialing.
+**/
+func (c *Client) OnDisconnect(handler func(error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onDisconnect = append(c.onDisconnect, handler)
+}
+
+func (c *Client) OnReconnect(handler func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onReconnect = append(c.onReconnect, handler)
+}
+
+/**
+* registers a callback for the action's successful events, failures only go
+* to the error callbacks.
+**/
+func (c *Client) onDecoded(action constants.Action, decode func(Event) error) {
+	c.On(action, func(event Event) {
+		if event.Failure() != nil {
+			return
+		}
+
+		if err := decode(event); err != nil {
+			fmt.Printf("Game client failed to decode %s: %v\n", action, err)
+		}
+	})
+}
+
+func (c *Client) OnQueued(handler func(Queued)) {
+	c.onDecoded(constants.ActionFindGame, func(event Event) error {
+		var queued Queued
+		if err := event.Decode(&queued); err != nil {
+			return err
+		}
+		handler(queued)
+		return nil
+	})
+}
+
+func (c *Client) OnReadyCheck(handler func(ReadyCheck)) {
+	c.onDecoded(constants.ActionReadyCheck, func(event Event) error {
+		var check ReadyCheck
+		if err := event.Decode(&check); err != nil {
+			return err
+		}
+		handler(check)
+		return nil
+	})
+}
+
+func (c *Client) OnGameFound(handler func(GameFound)) {
+	c.onDecoded(constants.ActionGameFound, func(event Event) error {
+		var found GameFound
+		if err := event.Decode(&found); err != nil {
+			return err
+		}
+		handler(found)
+		return nil
+	})
+}
+
+func (c *Client) OnSessionResume(handler func(SessionResume)) {
+	c.onDecoded(constants.ActionSessionResume, func(event Event) error {
+		var resume SessionResume
+		if err := event.Decode(&resume); err != nil {
+			return err
+		}
+		handler(resume)
+		return nil
+	})
+}
+
+func (c *Client) OnChat(handler func(ChatMessage)) {
+	c.onDecoded(constants.ActionChat, func(event Event) error {
+		var chat ChatMessage
+		if err := event.Decode(&chat); err != nil {
+			return err
+		}
+		handler(chat)
+		return nil
+	})
+}
+
+func (c *Client) OnPong(handler func(Pong)) {
+	c.onDecoded(constants.ActionPing, func(event Event) error {
+		var pong Pong
+		if err := event.Decode(&pong); err != nil {
+			return err
+		}
+		handler(pong)
+		return nil
+	})
+}
+
+/**
+* interact results come in whether they worked or not.
+**/
+func (c *Client) OnInteract(handler func(InteractResult)) {
+	c.On(constants.ActionInteract, func(event Event) {
+		var result InteractResult
+		if err := event.Decode(&result); err != nil {
+			fmt.Printf("Game client failed to decode %s: %v\n", event.Action, err)
+			return
+		}
+		handler(result)
+	})
+}
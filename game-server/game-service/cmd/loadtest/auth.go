@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/auth"
+	"github.com/google/uuid"
+)
+
+/**
+* Stand-in for the auth service so the load test runs without one. Tokens
+* are simply the member's id.
+**/
+type stubAuthClient struct{}
+
+func (a stubAuthClient) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
+	if _, err := uuid.Parse(req.Token); err != nil {
+		return &pb.ValidateTokenResponse{Valid: false}, nil
+	}
+
+	return &pb.ValidateTokenResponse{Valid: true, MemberId: req.Token}, nil
+}
+
+func (a stubAuthClient) GetMember(ctx context.Context, req *pb.GetMemberRequest) (*pb.Member, error) {
+	return &pb.Member{
+		Id:    req.Id,
+		Name:  fmt.Sprintf("loadtest-%s", req.Id[:8]),
+		Email: fmt.Sprintf("%s@loadtest.local", req.Id),
+	}, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/config"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/gameserver"
+	"github.com/gin-gonic/gin"
+)
+
+/**
+* Load test for the game service. Starts the websocket server in process
+* with a stand-in auth service, connects simulated players through the game
+* client and reports latencies, dropped messages, tick overruns and memory.
+*
+* go run ./cmd/loadtest -players 500 -duration 1m
+* go run ./cmd/loadtest -players 500 -duration 1m -protobuf
+**/
+
+type loadConfig struct {
+	players          int
+	duration         time.Duration
+	ramp             time.Duration
+	queue            string
+	maxSessions      int
+	moveInterval     time.Duration
+	interactInterval time.Duration
+	chatInterval     time.Duration
+	protobuf         bool
+	quiet            bool
+}
+
+func main() {
+	var cfg loadConfig
+	flag.IntVar(&cfg.players, "players", 200, "simulated players to connect")
+	flag.DurationVar(&cfg.duration, "duration", 30*time.Second, "how long players play once all are connected")
+	flag.DurationVar(&cfg.ramp, "ramp", 5*time.Second, "how long to spread the connections over")
+	flag.StringVar(&cfg.queue, "queue", "coop_duo", "matchmaking queue the players join")
+	flag.IntVar(&cfg.maxSessions, "max-sessions", 1000, "concurrent sessions the server allows")
+	flag.DurationVar(&cfg.moveInterval, "move-interval", 200*time.Millisecond, "how often each player moves, 0 to never")
+	flag.DurationVar(&cfg.interactInterval, "interact-interval", time.Second, "how often each player interacts, 0 to never")
+	flag.DurationVar(&cfg.chatInterval, "chat-interval", 5*time.Second, "how often each player chats, 0 to never")
+	flag.BoolVar(&cfg.protobuf, "protobuf", false, "connect with the protobuf encoding instead of JSON")
+	flag.BoolVar(&cfg.quiet, "quiet", true, "hide the server's logging")
+	flag.Parse()
+
+	if cfg.players <= 0 {
+		log.Fatal("-players must be at least 1")
+	}
+
+	// the report goes to the real stdout, the server's logging may not
+	out := os.Stdout
+	if cfg.quiet {
+		devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", os.DevNull, err)
+		}
+		os.Stdout = devNull
+		log.SetOutput(devNull)
+		gin.DefaultWriter = devNull
+	}
+	gin.SetMode(gin.ReleaseMode)
+
+	// --- server under test ---
+	auth := stubAuthClient{}
+	server := gameserver.NewServer(auth, nil, nil)
+	server.SetMaxSessions(cfg.maxSessions)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+	go http.Serve(listener, config.SetupRouter(server, auth))
+
+	gameURL := fmt.Sprintf("ws://%s", listener.Addr())
+	fmt.Fprintf(out, "Load testing %s with %d players in queue %s...\n", gameURL, cfg.players, cfg.queue)
+
+	// --- players ---
+	stats := newStats()
+	sampling := make(chan struct{})
+	go stats.sampleRuntime(time.Second, sampling)
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ramp+cfg.duration)
+	defer cancel()
+
+	players := make([]*player, cfg.players)
+	var wg sync.WaitGroup
+	for i := range players {
+		players[i] = newPlayer(gameURL, cfg, stats)
+
+		wg.Add(1)
+		go func(p *player) {
+			defer wg.Done()
+			p.run(ctx)
+		}(players[i])
+
+		if cfg.ramp > 0 {
+			time.Sleep(cfg.ramp / time.Duration(cfg.players))
+		}
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	close(sampling)
+
+	for _, p := range players {
+		p.settle()
+	}
+
+	stats.write(out, cfg, elapsed, server.SessionCount(), server.TickOverruns())
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/client"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/google/uuid"
+)
+
+/**
+* A simulated player. Queues for a match, accepts the ready check and then
+* moves, interacts and chats on timers until the test ends.
+**/
+
+// chat messages carry when they were sent so the echo can be timed
+const chatPrefix = "loadtest "
+
+// how long players wait for replies still on the way before disconnecting
+const drainWait = 500 * time.Millisecond
+
+type player struct {
+	client *client.Client
+	config loadConfig
+	stats  *stats
+
+	mu       sync.Mutex
+	queuedAt time.Time
+	// interact replies come back in the order they were sent
+	interacts []time.Time
+	chats     int
+	inGame    chan struct{}
+}
+
+func newPlayer(gameURL string, config loadConfig, stats *stats) *player {
+	p := &player{
+		client: client.New(client.Config{
+			GameURL:        gameURL,
+			Token:          uuid.New().String(),
+			MaxReconnects:  3,
+			ReconnectDelay: 200 * time.Millisecond,
+			Protobuf:       config.protobuf,
+		}),
+		config: config,
+		stats:  stats,
+		inGame: make(chan struct{}),
+	}
+
+	p.client.OnQueued(p.queued)
+	p.client.OnReadyCheck(p.readyCheck)
+	p.client.OnGameFound(p.gameFound)
+	p.client.OnInteract(p.interacted)
+	p.client.OnChat(p.chatted)
+	p.client.OnError(p.failed)
+	p.client.OnDisconnect(func(error) { p.stats.disconnects.Add(1) })
+
+	return p
+}
+
+/**
+* plays until ctx is done, then closes the connection.
+**/
+func (p *player) run(ctx context.Context) {
+	defer p.client.Close()
+
+	if err := p.client.Connect(ctx); err != nil {
+		p.stats.connectFails.Add(1)
+		return
+	}
+	p.stats.connected.Add(1)
+
+	p.mu.Lock()
+	p.queuedAt = time.Now()
+	p.mu.Unlock()
+	p.send(p.client.FindGame(p.config.queue))
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-p.inGame:
+	}
+
+	// spread the players out so they don't all act on the same instant
+	move := newJitteredTicker(p.config.moveInterval)
+	interact := newJitteredTicker(p.config.interactInterval)
+	chat := newJitteredTicker(p.config.chatInterval)
+	defer move.Stop()
+	defer interact.Stop()
+	defer chat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			time.Sleep(drainWait)
+			return
+
+		case <-move.C:
+			p.send(p.client.Move(rand.Float64()*2-1, rand.Float64()*2-1))
+
+		case <-interact.C:
+			p.mu.Lock()
+			p.interacts = append(p.interacts, time.Now())
+			p.mu.Unlock()
+
+			// no entity has the nil id, the failure still comes back as a reply
+			p.send(p.client.Interact(uuid.Nil, ""))
+
+		case <-chat.C:
+			p.mu.Lock()
+			p.chats++
+			p.mu.Unlock()
+
+			p.send(p.client.Chat(constants.ChatChannelAll, chatPrefix+strconv.FormatInt(time.Now().UnixNano(), 10)))
+		}
+	}
+}
+
+func (p *player) send(err error) {
+	p.stats.sent.Add(1)
+	if err != nil {
+		p.stats.sendFails.Add(1)
+	}
+}
+
+/**
+* counts what was sent and never answered. Called once the player stopped.
+**/
+func (p *player) settle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.unanswered.Add(int64(len(p.interacts) + p.chats))
+}
+
+func (p *player) queued(client.Queued) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.record(latencyQueued, time.Since(p.queuedAt))
+}
+
+func (p *player) readyCheck(check client.ReadyCheck) {
+	p.send(p.client.RespondToReadyCheck(check.CheckID, true))
+}
+
+func (p *player) gameFound(client.GameFound) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-p.inGame:
+		// backfilled or redirected into another game, already playing
+		return
+	default:
+	}
+
+	p.stats.record(latencyMatched, time.Since(p.queuedAt))
+	p.stats.inGame.Add(1)
+	close(p.inGame)
+}
+
+func (p *player) interacted(client.InteractResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.interacts) == 0 {
+		return
+	}
+
+	p.stats.record(latencyInteract, time.Since(p.interacts[0]))
+	p.interacts = p.interacts[1:]
+}
+
+func (p *player) chatted(chat client.ChatMessage) {
+	// everyone in the game hears each message, only time our own
+	if chat.PlayerID != p.client.PlayerID() || !strings.HasPrefix(chat.Message, chatPrefix) {
+		return
+	}
+
+	sentAt, err := strconv.ParseInt(strings.TrimPrefix(chat.Message, chatPrefix), 10, 64)
+	if err != nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.chats > 0 {
+		p.chats--
+	}
+	p.stats.record(latencyChat, time.Since(time.Unix(0, sentAt)))
+}
+
+func (p *player) failed(err *client.ServerError) {
+	// interacting with the nil entity is expected to fail
+	if err.Action == constants.ActionInteract {
+		return
+	}
+
+	if err.Action == constants.ActionChat {
+		p.mu.Lock()
+		if p.chats > 0 {
+			p.chats--
+		}
+		p.mu.Unlock()
+	}
+
+	p.stats.recordError(err.Code)
+}
+
+/**
+* a ticker whose first tick lands somewhere within the first interval.
+**/
+type jitteredTicker struct {
+	C    <-chan time.Time
+	stop chan struct{}
+}
+
+func newJitteredTicker(interval time.Duration) *jitteredTicker {
+	ticks := make(chan time.Time, 1)
+	t := &jitteredTicker{C: ticks, stop: make(chan struct{})}
+
+	if interval <= 0 {
+		// never ticks
+		return t
+	}
+
+	go func() {
+		select {
+		case <-t.stop:
+			return
+		case <-time.After(time.Duration(rand.Int63n(int64(interval)))):
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-t.stop:
+				return
+			case now := <-ticker.C:
+				select {
+				case ticks <- now:
+				default:
+					// the player is behind, skip the tick like time.Ticker does
+				}
+			}
+		}
+	}()
+
+	return t
+}
+
+func (t *jitteredTicker) Stop() {
+	close(t.stop)
+}
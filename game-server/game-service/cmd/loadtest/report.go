@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+)
+
+/**
+* What the simulated players measured, shared between all of them.
+**/
+
+// latency samples by what was timed
+const (
+	latencyQueued   = "find_game ack"
+	latencyMatched  = "queue to game"
+	latencyInteract = "interact reply"
+	latencyChat     = "chat echo"
+)
+
+type stats struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	// [code] to how many times the server reported it
+	errors map[constants.ErrorCode]int
+
+	connected    atomic.Int64
+	connectFails atomic.Int64
+	inGame       atomic.Int64
+	disconnects  atomic.Int64
+
+	sent       atomic.Int64
+	sendFails  atomic.Int64
+	unanswered atomic.Int64
+
+	peakHeap       atomic.Uint64
+	peakGoroutines atomic.Int64
+}
+
+func newStats() *stats {
+	return &stats{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[constants.ErrorCode]int),
+	}
+}
+
+func (s *stats) record(name string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latencies[name] = append(s.latencies[name], latency)
+}
+
+func (s *stats) recordError(code constants.ErrorCode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors[code]++
+}
+
+/**
+* samples memory and goroutines every interval until done is closed. The
+* server runs in this process, so both include the simulated players.
+**/
+func (s *stats) sampleRuntime(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var memory runtime.MemStats
+		runtime.ReadMemStats(&memory)
+
+		if memory.HeapAlloc > s.peakHeap.Load() {
+			s.peakHeap.Store(memory.HeapAlloc)
+		}
+		if goroutines := int64(runtime.NumGoroutine()); goroutines > s.peakGoroutines.Load() {
+			s.peakGoroutines.Store(goroutines)
+		}
+
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dropped messages are the ones never sent, rejected as busy or lost, or
+// never answered
+func (s *stats) dropped() int64 {
+	s.mu.Lock()
+	busy := s.errors[constants.ErrorSessionBusy] + s.errors[constants.ErrorSessionNotFound]
+	s.mu.Unlock()
+
+	return s.sendFails.Load() + s.unanswered.Load() + int64(busy)
+}
+
+func (s *stats) write(out io.Writer, config loadConfig, elapsed time.Duration, sessions int, tickOverruns int64) {
+	fmt.Fprintf(out, "\n=== load test: %d players for %s ===\n\n", config.players, elapsed.Round(time.Millisecond))
+
+	fmt.Fprintf(out, "players      connected %d, failed to connect %d, reached a game %d, disconnects %d\n",
+		s.connected.Load(), s.connectFails.Load(), s.inGame.Load(), s.disconnects.Load())
+	fmt.Fprintf(out, "sessions     %d running at the end\n", sessions)
+	fmt.Fprintf(out, "messages     sent %d, dropped %d (send failures %d, unanswered %d)\n",
+		s.sent.Load(), s.dropped(), s.sendFails.Load(), s.unanswered.Load())
+	fmt.Fprintf(out, "ticks        %d overruns\n", tickOverruns)
+	fmt.Fprintf(out, "memory       peak heap %.1f MiB, peak goroutines %d\n\n",
+		float64(s.peakHeap.Load())/(1<<20), s.peakGoroutines.Load())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(out, "%-16s %8s %10s %10s %10s %10s\n", "latency", "samples", "p50", "p90", "p99", "max")
+	for _, name := range []string{latencyQueued, latencyMatched, latencyInteract, latencyChat} {
+		samples := s.latencies[name]
+		if len(samples) == 0 {
+			fmt.Fprintf(out, "%-16s %8d\n", name, 0)
+			continue
+		}
+
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		fmt.Fprintf(out, "%-16s %8d %10s %10s %10s %10s\n", name, len(samples),
+			percentile(samples, 0.50), percentile(samples, 0.90), percentile(samples, 0.99), samples[len(samples)-1])
+	}
+
+	if len(s.errors) > 0 {
+		fmt.Fprintf(out, "\nserver errors\n")
+
+		codes := make([]string, 0, len(s.errors))
+		for code := range s.errors {
+			codes = append(codes, string(code))
+		}
+		sort.Strings(codes)
+
+		for _, code := range codes {
+			fmt.Fprintf(out, "  %-24s %d\n", code, s.errors[constants.ErrorCode(code)])
+		}
+	}
+}
+
+/**
+* nearest rank percentile of samples sorted in ascending order.
+**/
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(float64(len(sorted))*p+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+
+	return sorted[rank].Round(time.Microsecond)
+}
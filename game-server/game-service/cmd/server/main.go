@@ -5,13 +5,25 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strconv"
 	"time"
 
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/game"
 	"github.com/darkphotonKN/cosmic-void-server/common/broker"
+	commonconstants "github.com/darkphotonKN/cosmic-void-server/common/constants"
 	"github.com/darkphotonKN/cosmic-void-server/common/discovery"
 	"github.com/darkphotonKN/cosmic-void-server/common/discovery/consul"
 	commonhelpers "github.com/darkphotonKN/cosmic-void-server/common/utils"
+	"github.com/darkphotonKN/cosmic-void-server/common/utils/cache"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/config"
+	grpcauth "github.com/darkphotonKN/cosmic-void-server/game-service/grpc/auth"
+	grpcgame "github.com/darkphotonKN/cosmic-void-server/game-service/grpc/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/character"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/gameserver"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/item"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/matchmaking"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/rating"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/room"
 	_ "github.com/joho/godotenv/autoload"
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
@@ -32,6 +44,14 @@ var (
 	amqpPassword = commonhelpers.GetEnvString("RABBITMQ_PASS", "guest")
 	amqpHost     = commonhelpers.GetEnvString("RABBITMQ_HOST", "localhost")
 	amqpPort     = commonhelpers.GetEnvString("RABBITMQ_PORT", "5672")
+
+	// matchmaking, "distributed" shares the queues between instances
+	matchmakingMode = commonhelpers.GetEnvString("MATCHMAKING_MODE", "local")
+	publicAddr      = commonhelpers.GetEnvString("GAME_PUBLIC_ADDR", "localhost"+gamePort)
+	maxSessions     = commonhelpers.GetEnvString("GAME_MAX_SESSIONS", "100")
+
+	// how long disconnected players have to get back into their game
+	reconnectGrace = commonhelpers.GetEnvString("RECONNECT_GRACE_PERIOD", "30s")
 )
 
 func main() {
@@ -89,21 +109,78 @@ func main() {
 		ch.Close()
 	}()
 
-	// TODO: Declare your exchanges here
-	// broker.DeclareExchange(ch, "your.event.name", "fanout")
+	broker.DeclareExchange(ch, commonconstants.MatchAllocatedEvent, "fanout")
+
+	// --- game server ---
+	authClient := grpcauth.NewClient(registry)
+
+	characterRepo := character.NewRepository(db)
+	characterService := character.NewService(characterRepo)
+
+	ratingRepo := rating.NewRepository(db)
+	ratingService := rating.NewService(ratingRepo)
+
+	// shared by the websocket routes and the rooms grpc api
+	gameServer := gameserver.NewServer(authClient, characterService, ratingService)
+
+	grace, err := time.ParseDuration(reconnectGrace)
+	if err != nil {
+		log.Fatalf("RECONNECT_GRACE_PERIOD must be a duration such as 30s: %v", err)
+	}
+	gameServer.SetReconnectGracePeriod(grace)
+
+	sessionLimit, err := strconv.Atoi(maxSessions)
+	if err != nil {
+		log.Fatalf("GAME_MAX_SESSIONS must be a number: %v", err)
+	}
+	gameServer.SetMaxSessions(sessionLimit)
+
+	// --- distributed matchmaking ---
+	if matchmakingMode == "distributed" {
+		err := config.InitRedis(config.RedisConfig{
+			Mode:         commonhelpers.GetEnvString("REDIS_MODE", "standalone"),
+			Addrs:        []string{commonhelpers.GetEnvString("REDIS_ADDR", "localhost:6379")},
+			Password:     commonhelpers.GetEnvString("REDIS_PASSWORD", ""),
+			DB:           0,
+			PoolSize:     10,
+			MinIdleConns: 5,
+		})
+		if err != nil {
+			log.Fatalf("Failed to initialize Redis: %v", err)
+		}
+		defer config.CloseRedis()
+		cacheService := cache.NewRedisCache(config.GetClient())
+
+		node := matchmaking.Node{ID: instanceID, Addr: publicAddr, MaxSessions: sessionLimit}
+		distributed, err := matchmaking.NewDistributed(
+			cacheService, gameserver.DefaultQueues(), node, gameServer, matchmaking.NewPublisher(ch),
+		)
+		if err != nil {
+			log.Fatalf("Failed to set up distributed matchmaking: %v", err)
+		}
+
+		if err := distributed.Listen(ch); err != nil {
+			log.Fatalf("Failed to listen for allocated matches: %v", err)
+		}
+
+		gameServer.UseMatchmaking(distributed)
+		distributed.Start()
+	}
+
+	// --- game service grpc api ---
+	roomService := room.NewService(gameServer)
+
+	itemRepo := item.NewRepository(db)
+	itemService := item.NewService(itemRepo)
+
+	handler := grpcgame.NewHandler(roomService, itemService)
 
-	// TODO: Initialize your services and handlers
-	// When ready, import the proto package and uncomment:
-	// pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/game"
-	// repo := yourpackage.NewRepository(db)
-	// service := yourpackage.NewService(repo, ch)
-	// handler := yourpackage.NewHandler(service)
-	// pb.RegisterGameServiceServer(grpcServer, handler)
+	pb.RegisterGameServiceServer(grpcServer, handler)
 
 	log.Printf("grpc Game Server started on PORT: %s\n", grpcAddr)
 
 	// routes setup
-	routes := config.SetupRouter(db, registry)
+	routes := config.SetupRouter(gameServer, authClient)
 
 	fmt.Printf("Server listening on port %s.\n", gamePort)
 
@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 type Action string
 type ErrorCode string
 
@@ -8,6 +10,54 @@ const (
 	ActionQueue      Action = "queue"
 	ActionFindGame   Action = "find_game"
 	ActionLeaveQueue Action = "leave_queue"
+	ActionQueueStats Action = "queue_stats"
+
+	// ready check, the server sends ready_check and players answer with
+	// ready_check_response
+	ActionReadyCheck         Action = "ready_check"
+	ActionReadyCheckResponse Action = "ready_check_response"
+	ActionReadyCheckUpdate   Action = "ready_check_update"
+	ActionReadyCheckFailed   Action = "ready_check_failed"
+	ActionGameFound          Action = "game_found"
+	// the match is hosted by another game service instance, players
+	// reconnect there to answer its ready check
+	ActionMatchRedirect Action = "match_redirect"
+
+	// reconnecting to a session in progress
+	ActionSessionResume      Action = "session_resume"
+	ActionPlayerDisconnected Action = "player_disconnected"
+	ActionPlayerReconnected  Action = "player_reconnected"
+	ActionPlayerLeft         Action = "player_left"
+	// a queued player filled an open slot in a game already being played
+	ActionPlayerJoined Action = "player_joined"
+
+	// spectating a session in progress, the server streams spectate_state
+	ActionSpectate       Action = "spectate"
+	ActionStopSpectating Action = "stop_spectating"
+	ActionSpectateFollow Action = "spectate_follow"
+	ActionSpectateState  Action = "spectate_state"
+
+	// party actions
+	ActionInviteParty        Action = "invite_party"
+	ActionAcceptPartyInvite  Action = "accept_party_invite"
+	ActionDeclinePartyInvite Action = "decline_party_invite"
+	ActionLeaveParty         Action = "leave_party"
+	ActionGetParty           Action = "get_party"
+	ActionPartyInvite        Action = "party_invite"
+	ActionPartyUpdate        Action = "party_update"
+	ActionPartyDeclined      Action = "party_invite_declined"
+
+	// lobby actions
+	ActionCreateLobby Action = "create_lobby"
+	ActionJoinLobby   Action = "join_lobby"
+	ActionLeaveLobby  Action = "leave_lobby"
+	ActionKickPlayer  Action = "kick_player"
+	ActionSetReady    Action = "set_ready"
+	ActionUpdateLobby Action = "update_lobby"
+	ActionStartLobby  Action = "start_lobby"
+	ActionListLobbies Action = "list_lobbies"
+	ActionLobbyUpdate Action = "lobby_update"
+	ActionLobbyKicked Action = "lobby_kicked"
 
 	// active game actions
 	ActionMove     Action = "move"
@@ -18,9 +68,21 @@ const (
 	ActionDropItem Action = "drop_item"
 	ActionChat     Action = "chat"
 
+	ActionAllocateStat Action = "allocate_stat"
+
+	// game events
+	ActionProjectileHit Action = "projectile_hit"
+	ActionGameOver      Action = "game_over"
+
+	// progression events
+	ActionExperienceGained Action = "experience_gained"
+	ActionLevelUp          Action = "level_up"
+
 	// system actions
 	ActionError   Action = "error"
 	ActionSuccess Action = "success"
+	// clients measuring their round trip to the server
+	ActionPing Action = "ping"
 )
 
 const (
@@ -29,8 +91,129 @@ const (
 	ErrorPlayerNotFound      ErrorCode = "player_not_found"
 	ErrorInvalidPayload      ErrorCode = "invalid_payload"
 	ErrorInternalServerError ErrorCode = "internal_server_error"
+	ErrorSessionBusy         ErrorCode = "session_busy"
+	ErrorServerFull          ErrorCode = "server_full"
+	ErrorSessionFull         ErrorCode = "session_full"
+	ErrorPlayerMismatch      ErrorCode = "player_mismatch"
+
+	// interaction failures
+	ErrorOutOfRange        ErrorCode = "out_of_range"
+	ErrorLocked            ErrorCode = "locked"
+	ErrorWrongKey          ErrorCode = "wrong_key"
+	ErrorNoLineOfSight     ErrorCode = "no_line_of_sight"
+	ErrorInteractCooldown  ErrorCode = "cooldown"
+	ErrorUnsupportedVerb   ErrorCode = "unsupported_verb"
+	ErrorNotInteractable   ErrorCode = "not_interactable"
+	ErrorNothingToLoot     ErrorCode = "nothing_to_loot"
+	ErrorInteractionFailed ErrorCode = "interaction_failed"
+	ErrorEntityNotFound    ErrorCode = "entity_not_found"
+
+	// stat allocation failures, bad stats or points are invalid payloads
+	ErrorNotEnoughPoints ErrorCode = "not_enough_points"
+
+	// matchmaking failures
+	ErrorQueueNotFound ErrorCode = "queue_not_found"
+	ErrorAlreadyQueued ErrorCode = "already_queued"
+	ErrorNotInQueue    ErrorCode = "not_in_queue"
+	ErrorQueuePenalty  ErrorCode = "queue_penalty"
+	ErrorReadyCheck    ErrorCode = "ready_check_not_found"
+	ErrorInReadyCheck  ErrorCode = "in_ready_check"
+	ErrorAlreadyInGame ErrorCode = "already_in_game"
+	ErrorAlreadyInRoom ErrorCode = "already_in_room"
+	// the shared queues couldn't be reached
+	ErrorQueueUnavailable ErrorCode = "queue_unavailable"
+
+	// party failures
+	ErrorPartyNotFound      ErrorCode = "party_not_found"
+	ErrorAlreadyInParty     ErrorCode = "already_in_party"
+	ErrorNotPartyLeader     ErrorCode = "not_party_leader"
+	ErrorPartyFull          ErrorCode = "party_full"
+	ErrorNoPartyInvite      ErrorCode = "no_party_invite"
+	ErrorNotInParty         ErrorCode = "not_in_party"
+	ErrorPartyMemberOffline ErrorCode = "party_member_offline"
+	ErrorPartyTooLarge      ErrorCode = "party_too_large"
+	ErrorPartyFailed        ErrorCode = "party_error"
+
+	// spectator failures
+	ErrorSpectatorsFull      ErrorCode = "spectators_full"
+	ErrorAlreadyInSession    ErrorCode = "already_in_session"
+	ErrorNotSpectating       ErrorCode = "not_spectating"
+	ErrorFollowTargetMissing ErrorCode = "follow_target_missing"
+	ErrorSpectateFailed      ErrorCode = "spectate_error"
+
+	// lobby failures
+	ErrorLobbyNotFound      ErrorCode = "lobby_not_found"
+	ErrorLobbyFull          ErrorCode = "lobby_full"
+	ErrorNotHost            ErrorCode = "not_host"
+	ErrorPlayersNotReady    ErrorCode = "players_not_ready"
+	ErrorKicked             ErrorCode = "kicked"
+	ErrorInvalidInviteCode  ErrorCode = "invalid_invite_code"
+	ErrorInvalidLobbyConfig ErrorCode = "invalid_lobby_settings"
+	ErrorLobbyFailed        ErrorCode = "lobby_error"
 )
 
 const DefaultSpeed float64 = 1
 const DefaultInteractableRange float64 = 1
+const DefaultInteractCooldown = 100 * time.Millisecond
+const DefaultColliderRadius float64 = 0.5
 const DefautMaxSessionPlayers = 2
+
+// ready checks for matched players
+const (
+	ReadyCheckTimeout = 15 * time.Second
+	// how long players who decline or miss a ready check can't queue
+	ReadyCheckPenalty = 2 * time.Minute
+)
+
+// how long a disconnected player's entity stays in their session waiting
+// for them to reconnect
+const ReconnectGracePeriod = 30 * time.Second
+
+// websocket heartbeats
+const (
+	// connections that send nothing this long, not even a pong, are closed
+	ConnectionIdleTimeout = 60 * time.Second
+	// pings go out often enough for live connections to never go idle
+	PingInterval = (ConnectionIdleTimeout * 9) / 10
+	// how long a write can block on a slow connection before it's dropped
+	WriteTimeout = 10 * time.Second
+)
+
+// sessions
+const (
+	// sessions one instance runs at once
+	MaxConcurrentSessions = 100
+	// how often ended and empty sessions are cleaned up
+	SessionReapInterval = 10 * time.Second
+)
+
+// how long a game waits on saved characters and ratings before carrying on
+// without them
+const StoreTimeout = 2 * time.Second
+
+// parties
+const (
+	MaxPartySize       = 4
+	PartyInviteTimeout = time.Minute
+)
+
+// player limits for custom rooms
+const (
+	DefaultRoomMaxPlayers = 4
+	MaxRoomPlayers        = 16
+)
+
+// starting values for new characters
+const (
+	DefaultPlayerHealth     = 100
+	DefaultStartingItem     = "Health Potion"
+	DefaultStartingQuantity = 3
+)
+
+// chat channels
+const (
+	ChatChannelAll  = "all"
+	ChatChannelTeam = "team"
+	// longest chat message accepted, in characters
+	MaxChatMessageLength = 500
+)
@@ -3,19 +3,17 @@ package config
 import (
 	"fmt"
 
-	"github.com/darkphotonKN/cosmic-void-server/common/discovery"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/auth"
 	grpcauth "github.com/darkphotonKN/cosmic-void-server/game-service/grpc/auth"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/gameserver"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/jmoiron/sqlx"
 )
 
 /**
 * Sets up API prefix route and all routers.
 **/
-func SetupRouter(db *sqlx.DB, registry discovery.Registry) *gin.Engine {
+func SetupRouter(server *gameserver.Server, authClient grpcauth.AuthClient) *gin.Engine {
 	router := gin.Default()
 
 	// NOTE: debugging middleware
@@ -35,15 +33,14 @@ func SetupRouter(db *sqlx.DB, registry discovery.Registry) *gin.Engine {
 	// base route
 	api := router.Group("/api")
 
-	// --- AUTH CLIENT ---
-	authClient := grpcauth.NewClient(registry)
-
 	// --- WEBSOCKET CONNECTION ---
-	server := gameserver.NewServer(authClient)
 
 	// -- routes --
 	router.GET("/game/ws", auth.WSAuthMiddleware(authClient), server.HandleWebSocketConnection)
 
+	// --- MATCHMAKING ---
+	api.GET("/queues", server.HandleGetQueues)
+
 	// --- HEALTH CHECK ---
 	api.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -0,0 +1,99 @@
+package grpcgame
+
+import (
+	"context"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/game"
+)
+
+/**
+* Serves the GameService gRPC API, handing each call to the service that
+* owns it.
+**/
+
+type Handler struct {
+	pb.UnimplementedGameServiceServer
+	roomService RoomService
+	itemService ItemService
+}
+
+type RoomService interface {
+	CreateRoom(ctx context.Context, req *pb.CreateRoomRequest) (*pb.Room, error)
+	GetRoom(ctx context.Context, req *pb.GetRoomRequest) (*pb.Room, error)
+	ListRooms(ctx context.Context, req *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error)
+	JoinRoom(ctx context.Context, req *pb.JoinRoomRequest) (*pb.JoinRoomResponse, error)
+	LeaveRoom(ctx context.Context, req *pb.LeaveRoomRequest) (*pb.LeaveRoomResponse, error)
+	StartGame(ctx context.Context, req *pb.StartGameRequest) (*pb.StartGameResponse, error)
+	EndGame(ctx context.Context, req *pb.EndGameRequest) (*pb.EndGameResponse, error)
+}
+
+type ItemService interface {
+	ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error)
+	GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.BaseItem, error)
+	CreateItemInstance(ctx context.Context, req *pb.CreateItemInstanceRequest) (*pb.ItemInstance, error)
+	GetItemInstance(ctx context.Context, req *pb.GetItemInstanceRequest) (*pb.ItemInstance, error)
+	ListMemberItems(ctx context.Context, req *pb.ListMemberItemsRequest) (*pb.ListMemberItemsResponse, error)
+}
+
+func NewHandler(roomService RoomService, itemService ItemService) *Handler {
+	return &Handler{
+		roomService: roomService,
+		itemService: itemService,
+	}
+}
+
+/**
+* --- Rooms ---
+**/
+
+func (h *Handler) CreateRoom(ctx context.Context, req *pb.CreateRoomRequest) (*pb.Room, error) {
+	return h.roomService.CreateRoom(ctx, req)
+}
+
+func (h *Handler) GetRoom(ctx context.Context, req *pb.GetRoomRequest) (*pb.Room, error) {
+	return h.roomService.GetRoom(ctx, req)
+}
+
+func (h *Handler) ListRooms(ctx context.Context, req *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	return h.roomService.ListRooms(ctx, req)
+}
+
+func (h *Handler) JoinRoom(ctx context.Context, req *pb.JoinRoomRequest) (*pb.JoinRoomResponse, error) {
+	return h.roomService.JoinRoom(ctx, req)
+}
+
+func (h *Handler) LeaveRoom(ctx context.Context, req *pb.LeaveRoomRequest) (*pb.LeaveRoomResponse, error) {
+	return h.roomService.LeaveRoom(ctx, req)
+}
+
+func (h *Handler) StartGame(ctx context.Context, req *pb.StartGameRequest) (*pb.StartGameResponse, error) {
+	return h.roomService.StartGame(ctx, req)
+}
+
+func (h *Handler) EndGame(ctx context.Context, req *pb.EndGameRequest) (*pb.EndGameResponse, error) {
+	return h.roomService.EndGame(ctx, req)
+}
+
+/**
+* --- Items ---
+**/
+
+func (h *Handler) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	return h.itemService.ListItems(ctx, req)
+}
+
+func (h *Handler) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.BaseItem, error) {
+	return h.itemService.GetItem(ctx, req)
+}
+
+func (h *Handler) CreateItemInstance(ctx context.Context, req *pb.CreateItemInstanceRequest) (*pb.ItemInstance, error) {
+	return h.itemService.CreateItemInstance(ctx, req)
+}
+
+func (h *Handler) GetItemInstance(ctx context.Context, req *pb.GetItemInstanceRequest) (*pb.ItemInstance, error) {
+	return h.itemService.GetItemInstance(ctx, req)
+}
+
+func (h *Handler) ListMemberItems(ctx context.Context, req *pb.ListMemberItemsRequest) (*pb.ListMemberItemsResponse, error) {
+	return h.itemService.ListMemberItems(ctx, req)
+}
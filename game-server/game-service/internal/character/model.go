@@ -0,0 +1,35 @@
+package character
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Character struct {
+	ID            uuid.UUID `db:"id" json:"id"`
+	MemberID      uuid.UUID `db:"member_id" json:"memberId"`
+	Name          string    `db:"name" json:"name"`
+	Level         int       `db:"level" json:"level"`
+	Experience    int       `db:"experience" json:"experience"`
+	Strength      int       `db:"strength" json:"strength"`
+	Agility       int       `db:"agility" json:"agility"`
+	Intelligence  int       `db:"intelligence" json:"intelligence"`
+	UnspentPoints int       `db:"unspent_points" json:"unspentPoints"`
+	CurrentHealth int       `db:"current_health" json:"currentHealth"`
+	MaxHealth     int       `db:"max_health" json:"maxHealth"`
+	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt     time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type CharacterItem struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	CharacterID uuid.UUID `db:"character_id" json:"characterId"`
+	ItemType    string    `db:"item_type" json:"itemType"`
+	Name        string    `db:"name" json:"name"`
+	Quantity    int       `db:"quantity" json:"quantity"`
+	LockID      string    `db:"lock_id" json:"lockId"`
+	// nil when the item sits in the inventory
+	EquippedSlot *string   `db:"equipped_slot" json:"equippedSlot"`
+	CreatedAt    time.Time `db:"created_at" json:"createdAt"`
+}
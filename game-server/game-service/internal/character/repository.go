@@ -0,0 +1,141 @@
+package character
+
+import (
+	"context"
+	"fmt"
+
+	commonhelpers "github.com/darkphotonKN/cosmic-void-server/common/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository struct {
+	DB *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{
+		DB: db,
+	}
+}
+
+func (r *Repository) GetByMemberID(ctx context.Context, memberID uuid.UUID) (*Character, error) {
+	query := `SELECT * FROM characters WHERE characters.member_id = $1`
+
+	var character Character
+	err := r.DB.GetContext(ctx, &character, query, memberID)
+	if err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return &character, nil
+}
+
+/**
+* Creates a character along with the items it starts with, in one
+* transaction.
+**/
+func (r *Repository) Create(ctx context.Context, character *Character, items []CharacterItem) error {
+	query := `
+	INSERT INTO characters(member_id, name, level, experience, strength, agility, intelligence, unspent_points, current_health, max_health)
+	VALUES(:member_id, :name, :level, :experience, :strength, :agility, :intelligence, :unspent_points, :current_health, :max_health)
+	RETURNING id, created_at, updated_at
+	`
+
+	return commonhelpers.ExecTxContext(ctx, r.DB, func(tx *sqlx.Tx) error {
+		rows, err := sqlx.NamedQueryContext(ctx, tx, query, character)
+		if err != nil {
+			fmt.Println("Error when creating character:", err)
+			return commonhelpers.AnalyzeDBErr(err)
+		}
+
+		if rows.Next() {
+			if err := rows.Scan(&character.ID, &character.CreatedAt, &character.UpdatedAt); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		return insertItemsTx(ctx, tx, character.ID, items)
+	})
+}
+
+func (r *Repository) GetItems(ctx context.Context, characterID uuid.UUID) ([]CharacterItem, error) {
+	query := `SELECT * FROM character_items WHERE character_items.character_id = $1 ORDER BY created_at`
+
+	items := make([]CharacterItem, 0)
+	err := r.DB.SelectContext(ctx, &items, query, characterID)
+	if err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return items, nil
+}
+
+/**
+* Writes a character and replaces all of its items inside the transaction
+* provided.
+**/
+func (r *Repository) SaveTx(ctx context.Context, tx *sqlx.Tx, character *Character, items []CharacterItem) error {
+	query := `
+	INSERT INTO characters(member_id, name, level, experience, strength, agility, intelligence, unspent_points, current_health, max_health)
+	VALUES(:member_id, :name, :level, :experience, :strength, :agility, :intelligence, :unspent_points, :current_health, :max_health)
+	ON CONFLICT (member_id) DO UPDATE SET
+		name = EXCLUDED.name,
+		level = EXCLUDED.level,
+		experience = EXCLUDED.experience,
+		strength = EXCLUDED.strength,
+		agility = EXCLUDED.agility,
+		intelligence = EXCLUDED.intelligence,
+		unspent_points = EXCLUDED.unspent_points,
+		current_health = EXCLUDED.current_health,
+		max_health = EXCLUDED.max_health,
+		updated_at = NOW()
+	RETURNING id
+	`
+
+	rows, err := sqlx.NamedQueryContext(ctx, tx, query, character)
+	if err != nil {
+		return commonhelpers.AnalyzeDBErr(err)
+	}
+
+	if rows.Next() {
+		if err := rows.Scan(&character.ID); err != nil {
+			rows.Close()
+			return err
+		}
+	}
+	rows.Close()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM character_items WHERE character_id = $1`, character.ID); err != nil {
+		return commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return insertItemsTx(ctx, tx, character.ID, items)
+}
+
+func insertItemsTx(ctx context.Context, tx *sqlx.Tx, characterID uuid.UUID, items []CharacterItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	for i := range items {
+		items[i].CharacterID = characterID
+	}
+
+	insertQuery := `
+	INSERT INTO character_items(id, character_id, item_type, name, quantity, lock_id, equipped_slot)
+	VALUES(:id, :character_id, :item_type, :name, :quantity, :lock_id, :equipped_slot)
+	`
+
+	if _, err := tx.NamedExecContext(ctx, insertQuery, items); err != nil {
+		return commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return nil
+}
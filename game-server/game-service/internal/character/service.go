@@ -0,0 +1,181 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	commonconstants "github.com/darkphotonKN/cosmic-void-server/common/constants"
+	commonhelpers "github.com/darkphotonKN/cosmic-void-server/common/utils"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+/**
+* Loads and stores persistent player characters.
+**/
+
+type Service struct {
+	Repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{
+		Repo: repo,
+	}
+}
+
+/**
+* loads a member's character, creating one with starting values the first
+* time they play.
+**/
+func (s *Service) LoadCharacter(ctx context.Context, memberID uuid.UUID, name string) (*types.CharacterState, error) {
+	character, err := s.Repo.GetByMemberID(ctx, memberID)
+
+	if errors.Is(err, commonconstants.ErrNotFound) {
+		character = newStartingCharacter(memberID, name)
+		starterItems := []CharacterItem{{
+			ID:       uuid.New(),
+			ItemType: "potion",
+			Name:     constants.DefaultStartingItem,
+			Quantity: constants.DefaultStartingQuantity,
+		}}
+
+		if err := s.Repo.Create(ctx, character, starterItems); err != nil {
+			return nil, fmt.Errorf("failed to create character for member %s: %w", memberID, err)
+		}
+
+		return toCharacterState(character, starterItems), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.Repo.GetItems(ctx, character.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCharacterState(character, items), nil
+}
+
+/**
+* writes every character back in a single transaction, either all of them
+* are saved or none are.
+**/
+func (s *Service) SaveCharacters(ctx context.Context, states []*types.CharacterState) error {
+	return commonhelpers.ExecTxContext(ctx, s.Repo.DB, func(tx *sqlx.Tx) error {
+		for _, state := range states {
+			character, items := fromCharacterState(state)
+
+			if err := s.Repo.SaveTx(ctx, tx, character, items); err != nil {
+				return fmt.Errorf("failed to save character for member %s: %w", state.MemberID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func newStartingCharacter(memberID uuid.UUID, name string) *Character {
+	return &Character{
+		MemberID:      memberID,
+		Name:          name,
+		Level:         1,
+		Experience:    0,
+		Strength:      10,
+		Agility:       10,
+		Intelligence:  10,
+		UnspentPoints: 0,
+		CurrentHealth: constants.DefaultPlayerHealth,
+		MaxHealth:     constants.DefaultPlayerHealth,
+	}
+}
+
+func toCharacterState(character *Character, items []CharacterItem) *types.CharacterState {
+	state := &types.CharacterState{
+		MemberID:      character.MemberID,
+		Name:          character.Name,
+		Level:         character.Level,
+		Experience:    character.Experience,
+		Strength:      character.Strength,
+		Agility:       character.Agility,
+		Intelligence:  character.Intelligence,
+		UnspentPoints: character.UnspentPoints,
+		CurrentHealth: character.CurrentHealth,
+		MaxHealth:     character.MaxHealth,
+		Inventory:     make([]types.CharacterItem, 0, len(items)),
+		Equipment:     make(map[string]types.CharacterItem),
+	}
+
+	// characters always start a match alive
+	if state.CurrentHealth <= 0 {
+		state.CurrentHealth = state.MaxHealth
+	}
+
+	for _, item := range items {
+		stateItem := types.CharacterItem{
+			ID:       item.ID,
+			Type:     item.ItemType,
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			LockID:   item.LockID,
+		}
+
+		if item.EquippedSlot != nil {
+			state.Equipment[*item.EquippedSlot] = stateItem
+			continue
+		}
+
+		state.Inventory = append(state.Inventory, stateItem)
+	}
+
+	return state
+}
+
+func fromCharacterState(state *types.CharacterState) (*Character, []CharacterItem) {
+	character := &Character{
+		MemberID:      state.MemberID,
+		Name:          state.Name,
+		Level:         state.Level,
+		Experience:    state.Experience,
+		Strength:      state.Strength,
+		Agility:       state.Agility,
+		Intelligence:  state.Intelligence,
+		UnspentPoints: state.UnspentPoints,
+		CurrentHealth: state.CurrentHealth,
+		MaxHealth:     state.MaxHealth,
+	}
+
+	items := make([]CharacterItem, 0, len(state.Inventory)+len(state.Equipment))
+
+	for _, item := range state.Inventory {
+		items = append(items, toCharacterItem(item, nil))
+	}
+
+	for slot, item := range state.Equipment {
+		equippedSlot := slot
+		items = append(items, toCharacterItem(item, &equippedSlot))
+	}
+
+	return character, items
+}
+
+func toCharacterItem(item types.CharacterItem, equippedSlot *string) CharacterItem {
+	id := item.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	return CharacterItem{
+		ID:           id,
+		ItemType:     item.Type,
+		Name:         item.Name,
+		Quantity:     item.Quantity,
+		LockID:       item.LockID,
+		EquippedSlot: equippedSlot,
+	}
+}
@@ -0,0 +1,16 @@
+package components
+
+import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+
+// levers, switches, shrines and anything else that can be turned on and off
+type ActivatableComponent struct {
+	IsActive bool
+}
+
+func (a *ActivatableComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeActivatable
+}
+
+func NewActivatableComponent(isActive bool) *ActivatableComponent {
+	return &ActivatableComponent{IsActive: isActive}
+}
@@ -0,0 +1,22 @@
+package components
+
+import (
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+)
+
+// marks a player entity as controlled by the server
+type BotComponent struct {
+	Difficulty string
+	// when the bot next decides what to do
+	NextDecision time.Time
+}
+
+func (b *BotComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeBot
+}
+
+func NewBotComponent(difficulty string) *BotComponent {
+	return &BotComponent{Difficulty: difficulty}
+}
@@ -0,0 +1,16 @@
+package components
+
+import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+
+// circular hitbox centered on the entity's transform
+type ColliderComponent struct {
+	Radius float64
+}
+
+func (c *ColliderComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeCollider
+}
+
+func NewColliderComponent(radius float64) *ColliderComponent {
+	return &ColliderComponent{Radius: radius}
+}
@@ -0,0 +1,15 @@
+package components
+
+import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+
+type ContainerComponent struct {
+	Items []*InventoryItem
+}
+
+func (c *ContainerComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeContainer
+}
+
+func NewContainerComponent(items ...*InventoryItem) *ContainerComponent {
+	return &ContainerComponent{Items: items}
+}
@@ -0,0 +1,16 @@
+package components
+
+import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+
+type DialogueComponent struct {
+	Speaker string
+	Lines   []string
+}
+
+func (d *DialogueComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeDialogue
+}
+
+func NewDialogueComponent(speaker string, lines ...string) *DialogueComponent {
+	return &DialogueComponent{Speaker: speaker, Lines: lines}
+}
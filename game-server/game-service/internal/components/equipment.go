@@ -0,0 +1,16 @@
+package components
+
+import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+
+type EquipmentComponent struct {
+	// [slot] equipped item, e.g. "weapon", "helmet"
+	Slots map[string]*InventoryItem
+}
+
+func (e *EquipmentComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeEquipment
+}
+
+func NewEquipmentComponent() *EquipmentComponent {
+	return &EquipmentComponent{Slots: make(map[string]*InventoryItem)}
+}
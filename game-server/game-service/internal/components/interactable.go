@@ -1,16 +1,40 @@
 package components
 
-import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+import (
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+)
+
+type InteractionVerb string
+
+const (
+	VerbOpen     InteractionVerb = "open"
+	VerbLoot     InteractionVerb = "loot"
+	VerbTalk     InteractionVerb = "talk"
+	VerbActivate InteractionVerb = "activate"
+)
 
 type InteractableComponent struct {
 	// determines how far away something is interactable
 	Range float64
+	// what players can do with this entity, the first verb is the default
+	Verbs []InteractionVerb
+	// how long before the entity can be interacted with again
+	Cooldown time.Duration
+	// walls between the player and the entity block the interaction
+	RequiresLineOfSight bool
 }
 
 func (i *InteractableComponent) Type() ecs.ComponentType {
-	return ecs.ComponentTypePlayer
+	return ecs.ComponentTypeInteractable
 }
 
-func NewInteractableComponent(interactableRange float64) *InteractableComponent {
-	return &InteractableComponent{Range: interactableRange}
+func NewInteractableComponent(interactableRange float64, cooldown time.Duration, requiresLineOfSight bool, verbs ...InteractionVerb) *InteractableComponent {
+	return &InteractableComponent{
+		Range:               interactableRange,
+		Verbs:               verbs,
+		Cooldown:            cooldown,
+		RequiresLineOfSight: requiresLineOfSight,
+	}
 }
@@ -0,0 +1,43 @@
+package components
+
+import (
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+)
+
+type ItemType string
+
+const (
+	ItemTypePotion ItemType = "potion"
+	ItemTypeWeapon ItemType = "weapon"
+	ItemTypeKey    ItemType = "key"
+)
+
+type InventoryItem struct {
+	ID       uuid.UUID
+	Type     ItemType
+	Name     string
+	Quantity int
+	// only set for keys, the lock this key opens
+	LockID string
+}
+
+type InventoryComponent struct {
+	Items []*InventoryItem
+}
+
+func (i *InventoryComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeInventory
+}
+
+func NewInventoryComponent(items ...*InventoryItem) *InventoryComponent {
+	return &InventoryComponent{Items: items}
+}
+
+func NewInventoryItem(itemType ItemType, name string, quantity int) *InventoryItem {
+	return &InventoryItem{ID: uuid.New(), Type: itemType, Name: name, Quantity: quantity}
+}
+
+func NewKeyItem(name string, lockID string) *InventoryItem {
+	return &InventoryItem{ID: uuid.New(), Type: ItemTypeKey, Name: name, Quantity: 1, LockID: lockID}
+}
@@ -0,0 +1,26 @@
+package components
+
+import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+
+type LockComponent struct {
+	// keys with the same lock ID open this lock
+	LockID   string
+	IsLocked bool
+	// the key is used up when it unlocks this lock
+	ConsumeKey bool
+	// locks again whenever it's closed, otherwise it stays unlocked for good
+	RelockOnClose bool
+}
+
+func (l *LockComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeLock
+}
+
+func NewLockComponent(lockID string, consumeKey, relockOnClose bool) *LockComponent {
+	return &LockComponent{
+		LockID:        lockID,
+		IsLocked:      true,
+		ConsumeKey:    consumeKey,
+		RelockOnClose: relockOnClose,
+	}
+}
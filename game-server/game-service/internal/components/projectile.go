@@ -0,0 +1,41 @@
+package components
+
+import (
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+)
+
+type ProjectileComponent struct {
+	// entity that fired the projectile
+	OwnerID uuid.UUID
+	Name    string
+	// normalized direction of travel
+	DirX, DirY float64
+	Speed      float64
+	// seconds left before the projectile expires
+	Lifetime float64
+	// how many more targets it can pass through after hitting one
+	PierceCount int
+	Damage      int
+
+	// entities already hit, a projectile never hits the same entity twice
+	HitEntities map[uuid.UUID]bool
+}
+
+func (p *ProjectileComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeProjectile
+}
+
+func NewProjectileComponent(ownerID uuid.UUID, name string, dirX, dirY, speed, lifetime float64, pierceCount, damage int) *ProjectileComponent {
+	return &ProjectileComponent{
+		OwnerID:     ownerID,
+		Name:        name,
+		DirX:        dirX,
+		DirY:        dirY,
+		Speed:       speed,
+		Lifetime:    lifetime,
+		PierceCount: pierceCount,
+		Damage:      damage,
+		HitEntities: make(map[uuid.UUID]bool),
+	}
+}
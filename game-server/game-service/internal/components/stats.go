@@ -8,6 +8,8 @@ type StatsComponent struct {
 	Strength     int
 	Agility      int
 	Intelligence int
+	// points earned from levelling up that haven't been allocated yet
+	UnspentPoints int
 }
 
 func (s *StatsComponent) Type() ecs.ComponentType {
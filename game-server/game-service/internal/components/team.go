@@ -0,0 +1,27 @@
+package components
+
+import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+
+type Faction string
+
+const (
+	FactionPlayers  Faction = "players"
+	FactionMonsters Faction = "monsters"
+	FactionNeutral  Faction = "neutral"
+)
+
+// team 0 means the entity is not on any team and only its faction is used
+const NoTeam = 0
+
+type TeamComponent struct {
+	TeamID  int
+	Faction Faction
+}
+
+func (t *TeamComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeTeam
+}
+
+func NewTeamComponent(teamID int, faction Faction) *TeamComponent {
+	return &TeamComponent{TeamID: teamID, Faction: faction}
+}
@@ -0,0 +1,17 @@
+package components
+
+import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+
+// axis aligned box starting at the entity's transform (top left corner)
+type WallComponent struct {
+	Width  float64
+	Height float64
+}
+
+func (w *WallComponent) Type() ecs.ComponentType {
+	return ecs.ComponentTypeWall
+}
+
+func NewWallComponent(width, height float64) *WallComponent {
+	return &WallComponent{Width: width, Height: height}
+}
@@ -17,15 +17,20 @@ type ComponentType string
 
 const (
 	ComponentTypePlayer ComponentType = "Player"
+	ComponentTypeBot    ComponentType = "Bot"
 	ComponentTypeNPC    ComponentType = "NPC"
 	ComponentTypeEnemy  ComponentType = "Enemy"
+	ComponentTypeTeam   ComponentType = "Team"
 
 	ComponentTypeItem      ComponentType = "Item"
 	ComponentTypeDoor      ComponentType = "Door"
 	ComponentTypeContainer ComponentType = "Container"
 
-	ComponentTypeTransform ComponentType = "Transform"
-	ComponentTypeVelocity  ComponentType = "Velocity"
+	ComponentTypeTransform  ComponentType = "Transform"
+	ComponentTypeVelocity   ComponentType = "Velocity"
+	ComponentTypeCollider   ComponentType = "Collider"
+	ComponentTypeProjectile ComponentType = "Projectile"
+	ComponentTypeWall       ComponentType = "Wall"
 
 	ComponentTypeHealth ComponentType = "Health"
 	ComponentTypeAttack ComponentType = "Attack"
@@ -41,7 +46,9 @@ const (
 
 	ComponentTypeInteractable ComponentType = "Interactable"
 	ComponentTypeOpenable     ComponentType = "Openable"
+	ComponentTypeLock         ComponentType = "Lock"
 	ComponentTypeDialogue     ComponentType = "Dialogue"
+	ComponentTypeActivatable  ComponentType = "Activatable"
 )
 
 type Entity struct {
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Backfill
+*
+* Modes that allow it take new players into games already being played when
+* someone leaves early. The mode's join hook decides where they spawn.
+**/
+
+/**
+* how many players each team has.
+**/
+func (s *Session) TeamCounts() map[int]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.teamCounts()
+}
+
+// NOTE: caller must hold the lock
+func (s *Session) teamCounts() map[int]int {
+	counts := make(map[int]int)
+	for _, entityID := range s.playerEntities {
+		entity, ok := s.EntityManager.GetEntity(entityID)
+		if !ok {
+			continue
+		}
+
+		if teamComp, hasTeam := entity.GetComponent(ecs.ComponentTypeTeam); hasTeam {
+			counts[teamComp.(*components.TeamComponent).TeamID]++
+		}
+	}
+	return counts
+}
+
+/**
+* slots left on each team when the session holds capacity players split
+* evenly between the mode's teams. Full teams are left out.
+**/
+func (s *Session) OpenSlots(capacity int) map[int]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	teams := s.mode.Teams
+	if teams < 1 {
+		teams = 1
+	}
+	perTeam := (capacity + teams - 1) / teams
+
+	counts := s.teamCounts()
+	open := make(map[int]int, teams)
+	for team := 1; team <= teams; team++ {
+		if room := perTeam - counts[team]; room > 0 {
+			open[team] = room
+		}
+	}
+
+	return open
+}
+
+/**
+* adds a player to a game already being played, spawning them where the
+* mode's join hook says and letting everyone know. NoTeam balances them
+* onto the team with the fewest players.
+**/
+func (s *Session) JoinInProgress(userID uuid.UUID, username string, team int, character *types.CharacterState) (uuid.UUID, error) {
+	s.mu.Lock()
+
+	if s.state == SessionEnding || s.state == SessionClosed {
+		s.mu.Unlock()
+		return uuid.Nil, ErrSessionClosed
+	}
+
+	if _, isPlayer := s.playerEntities[userID]; isPlayer {
+		s.mu.Unlock()
+		return uuid.Nil, ErrAlreadyJoined
+	}
+
+	if team == components.NoTeam {
+		team = s.smallestTeam()
+	}
+
+	at := SpawnPoint{}
+	if s.mode.OnJoin != nil {
+		at = s.mode.OnJoin(s, userID, team)
+	}
+
+	// watching the game turns into playing it
+	delete(s.spectators, userID)
+
+	entityID := s.spawnPlayer(userID, username, team, character, at)
+	s.mu.Unlock()
+
+	s.broadcast(types.Message{
+		Action: string(constants.ActionPlayerJoined),
+		Payload: map[string]interface{}{
+			"session_id": s.ID.String(),
+			"player_id":  userID.String(),
+			"username":   username,
+			"team":       team,
+		},
+	})
+
+	return entityID, nil
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing players joining games already being played.
+**/
+
+// TestJoinInProgress tests backfilled players spawn through the join hook
+func TestJoinInProgress(t *testing.T) {
+	session, recorder := newSpectatedSession(ModeTeamDeathmatch)
+	defer session.Shutdown()
+
+	first := uuid.New()
+	session.AddPlayerToTeam(first, "first", 1)
+	session.AddPlayerToTeam(uuid.New(), "second", 2)
+	session.AddPlayerToTeam(uuid.New(), "third", 2)
+
+	assert.Equal(t, map[int]int{1: 1}, session.OpenSlots(4))
+
+	joiner := uuid.New()
+	entityID, err := session.JoinInProgress(joiner, "joiner", components.NoTeam, nil)
+	require.NoError(t, err)
+
+	// balanced onto the short team, next to their teammate
+	assert.Equal(t, 1, session.GetPlayerTeam(joiner))
+	assert.Empty(t, session.OpenSlots(4))
+
+	entity, ok := session.EntityManager.GetEntity(entityID)
+	require.True(t, ok)
+	transformComp, _ := entity.GetComponent(ecs.ComponentTypeTransform)
+	assert.Equal(t, constants.DefaultColliderRadius*2, transformComp.(*components.TransformComponent).X)
+
+	joined := recorder.actions(first, constants.ActionPlayerJoined)
+	require.Len(t, joined, 1)
+	assert.Equal(t, joiner.String(), joined[0].Payload["player_id"])
+
+	_, err = session.JoinInProgress(joiner, "joiner", components.NoTeam, nil)
+	assert.ErrorIs(t, err, ErrAlreadyJoined)
+
+	session.End()
+	_, err = session.JoinInProgress(uuid.New(), "late", components.NoTeam, nil)
+	assert.ErrorIs(t, err, ErrSessionClosed)
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Bots
+*
+* Bots are ordinary player entities marked with a bot component. The AI
+* system decides their moves every tick and the session feeds them through
+* the message channel like a human's, so they play by the same rules.
+* Nothing is ever sent to them and they're left out of anything saved.
+**/
+
+/**
+* adds a server controlled player, NoTeam balances them like a human.
+**/
+func (s *Session) AddBot(userID uuid.UUID, username string, team int, difficulty string) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if team == components.NoTeam {
+		team = s.smallestTeam()
+	}
+
+	entityID := s.spawnPlayer(userID, username, team, nil, SpawnPoint{})
+
+	if entity, ok := s.EntityManager.GetEntity(entityID); ok {
+		entity.AddComponent(components.NewBotComponent(difficulty))
+	}
+	s.bots[userID] = true
+
+	return entityID
+}
+
+func (s *Session) IsBot(userID uuid.UUID) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.bots[userID]
+}
+
+/**
+* players in the session who aren't bots.
+**/
+func (s *Session) HumanIDs() []uuid.UUID {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	humans := make([]uuid.UUID, 0, len(s.playerEntities))
+	for playerID := range s.playerEntities {
+		if !s.bots[playerID] {
+			humans = append(humans, playerID)
+		}
+	}
+	return humans
+}
+
+/**
+* lets the AI system decide for every bot and hands the moves to the session
+* as if the bots had sent them.
+**/
+func (s *Session) driveBots(now time.Time) {
+	s.mu.RLock()
+	hasBots := len(s.bots) > 0
+	s.mu.RUnlock()
+
+	if !hasBots {
+		return
+	}
+
+	for _, input := range s.aiSystem.Update(now, s.EntityManager.GetAllEntities()) {
+		err := s.Deliver(types.ClientPackage{
+			Message: types.Message{
+				Action: string(constants.ActionMove),
+				Payload: map[string]interface{}{
+					"session_id": s.ID.String(),
+					"player_id":  input.PlayerID.String(),
+					"vx":         input.Vx,
+					"vy":         input.Vy,
+				},
+			},
+		})
+
+		if err != nil {
+			fmt.Printf("Failed to deliver bot %s input to session %s: %v\n", input.PlayerID, s.ID, err)
+		}
+	}
+}
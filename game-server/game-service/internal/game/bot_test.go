@@ -0,0 +1,60 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing server controlled players.
+**/
+
+// TestBotsPlayThroughInputPipeline tests bots are flagged and move through the session's messages
+func TestBotsPlayThroughInputPipeline(t *testing.T) {
+	session, recorder := newSpectatedSession(ModeTeamDeathmatch)
+	defer session.Shutdown()
+
+	humanID := uuid.New()
+	humanEntity, ok := session.EntityManager.GetEntity(session.AddPlayerToTeam(humanID, "human", 1))
+	require.True(t, ok)
+	transformComp, _ := humanEntity.GetComponent(ecs.ComponentTypeTransform)
+	transformComp.(*components.TransformComponent).X = 5
+
+	botID := uuid.New()
+	session.AddBot(botID, "Bot 1", components.NoTeam, "hard")
+
+	assert.True(t, session.IsBot(botID))
+	assert.Equal(t, 2, session.GetPlayerTeam(botID))
+	assert.Equal(t, []uuid.UUID{humanID}, session.HumanIDs())
+
+	state, err := session.Snapshot()
+	require.NoError(t, err)
+	flagged := make(map[uuid.UUID]bool)
+	for _, player := range state.Players {
+		flagged[player.ID] = player.Bot
+	}
+	assert.Equal(t, map[uuid.UUID]bool{humanID: false, botID: true}, flagged)
+
+	// bots are left out of saved characters and never sent anything
+	states := session.CharacterStates()
+	require.Len(t, states, 1)
+	assert.Equal(t, humanID, states[0].MemberID)
+
+	session.driveBots(time.Now())
+
+	// the session is still running, read under its motion lock
+	assert.Eventually(t, func() bool {
+		direction, _ := session.PlayerDirection(botID)
+		return direction.VX != 0
+	}, 2*time.Second, 10*time.Millisecond)
+
+	session.RemovePlayer(humanID)
+	assert.Empty(t, recorder.actions(botID, constants.ActionPlayerLeft))
+}
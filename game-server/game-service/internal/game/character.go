@@ -0,0 +1,108 @@
+package game
+
+import (
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* --- Character Persistence ---
+**/
+
+/**
+* captures the current character state of every human player in the session
+* so it can be written back at match end. Humans who left before the end are
+* saved as they were when they left.
+**/
+func (s *Session) CharacterStates() []*types.CharacterState {
+	s.mu.RLock()
+	entities := make([]*ecs.Entity, 0, len(s.playerEntities))
+	for playerID, entityID := range s.playerEntities {
+		if s.bots[playerID] {
+			continue
+		}
+
+		if entity, ok := s.EntityManager.GetEntity(entityID); ok {
+			entities = append(entities, entity)
+		}
+	}
+
+	states := make([]*types.CharacterState, 0, len(entities)+len(s.departedStates))
+	for playerID, state := range s.departedStates {
+		// players who came back are saved from their current entity
+		if _, playing := s.playerEntities[playerID]; !playing {
+			states = append(states, state)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, entity := range entities {
+		if state, ok := characterStateFromEntity(entity); ok {
+			states = append(states, state)
+		}
+	}
+
+	return states
+}
+
+// snapshots a leaving player's character before their entity is removed
+func (s *Session) keepDepartedState(playerID, entityID uuid.UUID) {
+	entity, ok := s.EntityManager.GetEntity(entityID)
+	if !ok {
+		return
+	}
+
+	state, ok := characterStateFromEntity(entity)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.departedStates[playerID] = state
+	s.mu.Unlock()
+}
+
+func characterStateFromEntity(entity *ecs.Entity) (*types.CharacterState, bool) {
+	playerComp, isPlayer := entity.GetComponent(ecs.ComponentTypePlayer)
+	statsComp, hasStats := entity.GetComponent(ecs.ComponentTypeStats)
+	healthComp, hasHealth := entity.GetComponent(ecs.ComponentTypeHealth)
+
+	if !isPlayer || !hasStats || !hasHealth {
+		return nil, false
+	}
+
+	player := playerComp.(*components.PlayerComponent)
+	stats := statsComp.(*components.StatsComponent)
+	health := healthComp.(*components.HealthComponent)
+
+	state := &types.CharacterState{
+		MemberID:      player.UserID,
+		Name:          player.Username,
+		Level:         stats.Level,
+		Experience:    stats.Experience,
+		Strength:      stats.Strength,
+		Agility:       stats.Agility,
+		Intelligence:  stats.Intelligence,
+		UnspentPoints: stats.UnspentPoints,
+		CurrentHealth: health.CurrentHealth,
+		MaxHealth:     health.MaxHealth,
+		Inventory:     make([]types.CharacterItem, 0),
+		Equipment:     make(map[string]types.CharacterItem),
+	}
+
+	if inventoryComp, hasInventory := entity.GetComponent(ecs.ComponentTypeInventory); hasInventory {
+		for _, item := range inventoryComp.(*components.InventoryComponent).Items {
+			state.Inventory = append(state.Inventory, inventoryToCharacterItem(item))
+		}
+	}
+
+	if equipmentComp, hasEquipment := entity.GetComponent(ecs.ComponentTypeEquipment); hasEquipment {
+		for slot, item := range equipmentComp.(*components.EquipmentComponent).Slots {
+			state.Equipment[slot] = inventoryToCharacterItem(item)
+		}
+	}
+
+	return state, true
+}
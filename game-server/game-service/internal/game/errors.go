@@ -1,7 +1,29 @@
 package game
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+)
 
 var (
-	ErrOutOfRange = errors.New("Error when attempting to interact with door entity as it was out of range.\n")
+	ErrOutOfRange        = systems.ErrOutOfRange
+	ErrNothingToLoot     = errors.New("There is nothing left to loot.")
+	ErrLocked            = errors.New("It's locked and requires a key.")
+	ErrWrongKey          = errors.New("None of the keys carried fit this lock.")
+	ErrUnknownProjectile = errors.New("Projectile definition does not exist.")
+	ErrInvalidDirection  = errors.New("Projectile direction must not be zero.")
+
+	ErrPlayerNotInSession = errors.New("Player is not in this session.")
+	ErrEntityNotFound     = errors.New("Entity does not exist in this session.")
+
+	ErrSessionClosed = errors.New("Game session has ended.")
+	ErrSessionBusy   = errors.New("Game session is not keeping up, try again.")
+	ErrAlreadyJoined = errors.New("Player is already in this session.")
+	ErrTeamFull      = errors.New("Team has no open slots.")
+
+	ErrSpectatorsFull      = errors.New("Session has no room for more spectators.")
+	ErrAlreadyInSession    = errors.New("Players can't spectate their own session.")
+	ErrNotSpectating       = errors.New("Not spectating this session.")
+	ErrFollowTargetMissing = errors.New("Player to follow is not in the session.")
 )
@@ -4,6 +4,7 @@ import (
 	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
 	"github.com/google/uuid"
 )
 
@@ -18,6 +19,9 @@ type PlayerConfig struct {
 	ItemName      string
 	ItemQuantity  int
 	Vx, Vy        float64
+	Team          int
+	// loaded from the character database, defaults are used when nil
+	Character *types.CharacterState
 }
 
 func CreatePlayerEntity(em *ecs.EntityManager, config PlayerConfig) *ecs.Entity {
@@ -27,17 +31,82 @@ func CreatePlayerEntity(em *ecs.EntityManager, config PlayerConfig) *ecs.Entity
 	entity.AddComponent(components.NewTransformComponent(config.X, config.Y))
 
 	entity.AddComponent(components.NewVelocityComponent(config.Vx, config.Vy, constants.DefaultSpeed))
+	entity.AddComponent(components.NewColliderComponent(constants.DefaultColliderRadius))
 
-	entity.AddComponent(components.NewHealthComponent(config.CurrentHealth, config.MaxHealth))
 	entity.AddComponent(components.NewSkillComponent(config.SkillName, config.SkillLevel))
+	entity.AddComponent(components.NewTeamComponent(config.Team, components.FactionPlayers))
+
+	// persisted character takes over stats, health and items
+	if config.Character != nil {
+		addCharacterComponents(entity, config.Character)
+		return entity
+	}
 
+	entity.AddComponent(components.NewHealthComponent(config.CurrentHealth, config.MaxHealth))
 	entity.AddComponent(components.NewStatsComponent())
+	entity.AddComponent(components.NewInventoryComponent(
+		components.NewInventoryItem(components.ItemTypePotion, config.ItemName, config.ItemQuantity),
+	))
+	entity.AddComponent(components.NewEquipmentComponent())
 
 	return entity
 }
 
+func addCharacterComponents(entity *ecs.Entity, character *types.CharacterState) {
+	entity.AddComponent(components.NewHealthComponent(character.CurrentHealth, character.MaxHealth))
+
+	stats := components.NewStatsComponent()
+	stats.Level = character.Level
+	stats.Experience = character.Experience
+	stats.Strength = character.Strength
+	stats.Agility = character.Agility
+	stats.Intelligence = character.Intelligence
+	stats.UnspentPoints = character.UnspentPoints
+	entity.AddComponent(stats)
+
+	inventory := components.NewInventoryComponent()
+	for _, item := range character.Inventory {
+		inventory.Items = append(inventory.Items, characterItemToInventory(item))
+	}
+	entity.AddComponent(inventory)
+
+	equipment := components.NewEquipmentComponent()
+	for slot, item := range character.Equipment {
+		equipment.Slots[slot] = characterItemToInventory(item)
+	}
+	entity.AddComponent(equipment)
+}
+
+func characterItemToInventory(item types.CharacterItem) *components.InventoryItem {
+	return &components.InventoryItem{
+		ID:       item.ID,
+		Type:     components.ItemType(item.Type),
+		Name:     item.Name,
+		Quantity: item.Quantity,
+		LockID:   item.LockID,
+	}
+}
+
+func inventoryToCharacterItem(item *components.InventoryItem) types.CharacterItem {
+	return types.CharacterItem{
+		ID:       item.ID,
+		Type:     string(item.Type),
+		Name:     item.Name,
+		Quantity: item.Quantity,
+		LockID:   item.LockID,
+	}
+}
+
+// optional lock, entities without a lock ID are never locked
+type LockConfig struct {
+	LockID        string
+	ConsumeKey    bool
+	RelockOnClose bool
+}
+
 type DoorConfig struct {
 	X, Y float64
+	Lock LockConfig
 }
 
 func CreateDoorEntity(em *ecs.EntityManager, config DoorConfig) *ecs.Entity {
@@ -45,6 +114,87 @@ func CreateDoorEntity(em *ecs.EntityManager, config DoorConfig) *ecs.Entity {
 	entity.AddComponent(components.NewDoorComponent())
 	entity.AddComponent(components.NewTransformComponent(config.X, config.Y))
 	entity.AddComponent(components.NewOpenableComponent(false)) // default false
+	entity.AddComponent(components.NewInteractableComponent(
+		constants.DefaultInteractableRange,
+		constants.DefaultInteractCooldown,
+		true,
+		components.VerbOpen,
+	))
+	addLock(entity, config.Lock)
+
+	return entity
+}
+
+type ContainerConfig struct {
+	X, Y  float64
+	Items []*components.InventoryItem
+	Lock  LockConfig
+}
+
+func CreateContainerEntity(em *ecs.EntityManager, config ContainerConfig) *ecs.Entity {
+	entity := em.CreateEntity()
+	entity.AddComponent(components.NewContainerComponent(config.Items...))
+	entity.AddComponent(components.NewTransformComponent(config.X, config.Y))
+	entity.AddComponent(components.NewOpenableComponent(false))
+	entity.AddComponent(components.NewInteractableComponent(
+		constants.DefaultInteractableRange,
+		constants.DefaultInteractCooldown,
+		true,
+		components.VerbLoot,
+		components.VerbOpen,
+	))
+	addLock(entity, config.Lock)
+
+	return entity
+}
+
+func addLock(entity *ecs.Entity, config LockConfig) {
+	if config.LockID == "" {
+		return
+	}
+
+	entity.AddComponent(components.NewLockComponent(config.LockID, config.ConsumeKey, config.RelockOnClose))
+}
+
+type WallConfig struct {
+	X, Y          float64
+	Width, Height float64
+}
+
+func CreateWallEntity(em *ecs.EntityManager, config WallConfig) *ecs.Entity {
+	entity := em.CreateEntity()
+	entity.AddComponent(components.NewTransformComponent(config.X, config.Y))
+	entity.AddComponent(components.NewWallComponent(config.Width, config.Height))
+
+	return entity
+}
+
+type ProjectileConfig struct {
+	OwnerID    uuid.UUID
+	X, Y       float64
+	DirX, DirY float64
+	Definition ProjectileDefinition
+	// team of the owner, so faction rules still apply if the owner is gone
+	Team    int
+	Faction components.Faction
+}
+
+func CreateProjectileEntity(em *ecs.EntityManager, config ProjectileConfig) *ecs.Entity {
+	definition := config.Definition
+
+	entity := em.CreateEntity()
+	entity.AddComponent(components.NewTransformComponent(config.X, config.Y))
+	entity.AddComponent(components.NewProjectileComponent(
+		config.OwnerID,
+		definition.Name,
+		config.DirX,
+		config.DirY,
+		definition.Speed,
+		definition.Lifetime,
+		definition.PierceCount,
+		definition.Damage,
+	))
+	entity.AddComponent(components.NewTeamComponent(config.Team, config.Faction))
 
 	return entity
 }
@@ -60,7 +60,9 @@ func TestHandleMoveUpdatesPositionIntegration(t *testing.T) {
 	// account for system game loop refresh rate, but only time for 1 move
 	time.Sleep(time.Millisecond * 1200)
 
-	fmt.Printf("\nplayerTransformCoords after update: %+v\n\n", component)
-	assert.Equal(t, float64(0.81), component.X)
-	assert.Equal(t, float64(0.81), component.Y)
+	// read under the game loop's lock
+	position, _ := session.PlayerPosition(player1ID)
+	fmt.Printf("\nplayerTransformCoords after update: %+v\n\n", position)
+	assert.Equal(t, float64(0.81), position.X)
+	assert.Equal(t, float64(0.81), position.Y)
 }
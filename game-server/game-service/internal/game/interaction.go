@@ -0,0 +1,201 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+)
+
+/**
+* --- Interaction Handlers ---
+*
+* One handler per verb. The interaction system has already checked range,
+* cooldowns and line of sight by the time these run.
+**/
+
+func (s *Session) registerInteractionHandlers() {
+	s.interactionSystem.RegisterHandler(components.VerbOpen, s.handleOpen)
+	s.interactionSystem.RegisterHandler(components.VerbLoot, s.handleLoot)
+	s.interactionSystem.RegisterHandler(components.VerbTalk, s.handleTalk)
+	s.interactionSystem.RegisterHandler(components.VerbActivate, s.handleActivate)
+}
+
+/**
+* allows game modes and maps to add their own interactions, or replace the
+* default handler for a verb.
+**/
+func (s *Session) RegisterInteractionHandler(verb components.InteractionVerb, handler systems.InteractionHandler) {
+	s.interactionSystem.RegisterHandler(verb, handler)
+}
+
+/**
+* toggles doors and containers open or closed, unlocking them first when
+* they're locked.
+**/
+func (s *Session) handleOpen(ctx systems.InteractionContext) (map[string]interface{}, error) {
+	openableComp, hasOpenable := ctx.Target.GetComponent(ecs.ComponentTypeOpenable)
+	if !hasOpenable {
+		return nil, systems.ErrMissingComponents
+	}
+
+	openable := openableComp.(*components.OpenableComponent)
+
+	// locked entities need the matching key before they can be opened
+	if !openable.IsOpen {
+		if err := s.tryUnlock(ctx.Player, ctx.Target); err != nil {
+			return nil, err
+		}
+	}
+
+	openable.IsOpen = !openable.IsOpen
+
+	if !openable.IsOpen {
+		s.relockOnClose(ctx.Target)
+	}
+
+	return map[string]interface{}{
+		"verb":    string(ctx.Verb),
+		"is_open": openable.IsOpen,
+	}, nil
+}
+
+/**
+* opens the container if needed and moves everything inside into the
+* player's inventory.
+**/
+func (s *Session) handleLoot(ctx systems.InteractionContext) (map[string]interface{}, error) {
+	containerComp, isContainer := ctx.Target.GetComponent(ecs.ComponentTypeContainer)
+	if !isContainer {
+		return nil, systems.ErrMissingComponents
+	}
+
+	container := containerComp.(*components.ContainerComponent)
+
+	if openableComp, hasOpenable := ctx.Target.GetComponent(ecs.ComponentTypeOpenable); hasOpenable {
+		openable := openableComp.(*components.OpenableComponent)
+
+		if !openable.IsOpen {
+			if err := s.tryUnlock(ctx.Player, ctx.Target); err != nil {
+				return nil, err
+			}
+			openable.IsOpen = true
+		}
+	}
+
+	if len(container.Items) == 0 {
+		return nil, ErrNothingToLoot
+	}
+
+	inventoryComp, hasInventory := ctx.Player.GetComponent(ecs.ComponentTypeInventory)
+	if !hasInventory {
+		inventoryComp = components.NewInventoryComponent()
+		ctx.Player.AddComponent(inventoryComp)
+	}
+
+	inventory := inventoryComp.(*components.InventoryComponent)
+
+	looted := make([]map[string]interface{}, 0, len(container.Items))
+	for _, item := range container.Items {
+		inventory.Items = append(inventory.Items, item)
+		looted = append(looted, map[string]interface{}{
+			"id":       item.ID.String(),
+			"type":     string(item.Type),
+			"name":     item.Name,
+			"quantity": item.Quantity,
+		})
+	}
+	container.Items = nil
+
+	source := systems.ExperienceSourceTreasure
+	if err := s.awardEntityExperience(ctx.Player.ID, s.progressionSystem.ExperienceFor(source), source); err != nil {
+		fmt.Printf("Failed to award treasure experience to entity %s: %s\n", ctx.Player.ID, err)
+	}
+
+	return map[string]interface{}{
+		"verb":  string(ctx.Verb),
+		"items": looted,
+	}, nil
+}
+
+func (s *Session) handleTalk(ctx systems.InteractionContext) (map[string]interface{}, error) {
+	dialogueComp, hasDialogue := ctx.Target.GetComponent(ecs.ComponentTypeDialogue)
+	if !hasDialogue {
+		return nil, systems.ErrMissingComponents
+	}
+
+	dialogue := dialogueComp.(*components.DialogueComponent)
+
+	return map[string]interface{}{
+		"verb":    string(ctx.Verb),
+		"speaker": dialogue.Speaker,
+		"lines":   dialogue.Lines,
+	}, nil
+}
+
+func (s *Session) handleActivate(ctx systems.InteractionContext) (map[string]interface{}, error) {
+	activatableComp, isActivatable := ctx.Target.GetComponent(ecs.ComponentTypeActivatable)
+	if !isActivatable {
+		return nil, systems.ErrMissingComponents
+	}
+
+	activatable := activatableComp.(*components.ActivatableComponent)
+	activatable.IsActive = !activatable.IsActive
+
+	return map[string]interface{}{
+		"verb":      string(ctx.Verb),
+		"is_active": activatable.IsActive,
+	}, nil
+}
+
+/**
+* maps an interaction error to the reason sent back to the player.
+**/
+func interactFailureReason(err error) constants.ErrorCode {
+	switch {
+	case errors.Is(err, ErrOutOfRange):
+		return constants.ErrorOutOfRange
+	case errors.Is(err, ErrLocked):
+		return constants.ErrorLocked
+	case errors.Is(err, ErrWrongKey):
+		return constants.ErrorWrongKey
+	case errors.Is(err, ErrNothingToLoot):
+		return constants.ErrorNothingToLoot
+	case errors.Is(err, systems.ErrNoLineOfSight):
+		return constants.ErrorNoLineOfSight
+	case errors.Is(err, systems.ErrInteractCooldown):
+		return constants.ErrorInteractCooldown
+	case errors.Is(err, systems.ErrUnsupportedVerb):
+		return constants.ErrorUnsupportedVerb
+	case errors.Is(err, systems.ErrNotInteractable):
+		return constants.ErrorNotInteractable
+	case errors.Is(err, ErrEntityNotFound):
+		return constants.ErrorEntityNotFound
+	case errors.Is(err, ErrPlayerNotInSession):
+		return constants.ErrorPlayerNotFound
+	default:
+		return constants.ErrorInteractionFailed
+	}
+}
+
+/**
+* maps the error from any other game action to the reason sent back.
+**/
+func actionFailureReason(err error) constants.ErrorCode {
+	switch {
+	case errors.Is(err, ErrPlayerNotInSession):
+		return constants.ErrorPlayerNotFound
+	case errors.Is(err, ErrUnknownProjectile),
+		errors.Is(err, ErrInvalidDirection),
+		errors.Is(err, systems.ErrUnknownStat),
+		errors.Is(err, systems.ErrInvalidStatPoints):
+		return constants.ErrorInvalidPayload
+	case errors.Is(err, systems.ErrNotEnoughPoints):
+		return constants.ErrorNotEnoughPoints
+	default:
+		return constants.ErrorInternalServerError
+	}
+}
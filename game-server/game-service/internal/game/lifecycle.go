@@ -0,0 +1,157 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+)
+
+/**
+* Session lifecycle
+*
+* A session moves through starting -> running -> ending -> closed and never
+* goes back. It ends itself once everyone leaves or its mode's win
+* condition is met, or the server ends it. Its goroutines all stop when its
+* context is cancelled, and the message channel is never closed so the hub
+* can't send on a closed channel, it hands messages over through Deliver
+* instead.
+**/
+
+type SessionState string
+
+const (
+	// created, game loop not started yet
+	SessionStarting SessionState = "starting"
+	// accepting player actions
+	SessionRunning SessionState = "running"
+	// the game is over and the server is wrapping it up, player actions are
+	// no longer accepted
+	SessionEnding SessionState = "ending"
+	// every goroutine has stopped
+	SessionClosed SessionState = "closed"
+)
+
+func (s *Session) State() SessionState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.state
+}
+
+/**
+* closed once the session starts shutting down.
+**/
+func (s *Session) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+/**
+* hands a client message to the session without blocking the caller. Fails
+* once the session is ending, or when it is too far behind to take more.
+**/
+func (s *Session) Deliver(clientPackage types.ClientPackage) error {
+	state := s.State()
+	if state == SessionEnding || state == SessionClosed {
+		return ErrSessionClosed
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return ErrSessionClosed
+	case s.MessageCh <- clientPackage:
+		return nil
+	default:
+		return ErrSessionBusy
+	}
+}
+
+/**
+* stops accepting player actions while the server wraps the game up. The game
+* loop keeps running until Shutdown.
+**/
+func (s *Session) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == SessionStarting || s.state == SessionRunning {
+		s.state = SessionEnding
+	}
+}
+
+/**
+* the team that won once the game ended itself, 0 for a draw or while it's
+* still being played.
+**/
+func (s *Session) WinningTeam() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.winningTeam
+}
+
+/**
+* ends the game once every player has left or the mode's win condition is
+* met, letting everyone still in it know who won. The server wraps it up
+* from there.
+**/
+func (s *Session) checkGameOver() {
+	s.mu.Lock()
+	if s.state != SessionStarting && s.state != SessionRunning {
+		s.mu.Unlock()
+		return
+	}
+
+	winningTeam, over := 0, len(s.playerEntities) == len(s.bots)
+	if !over && s.mode.WinCondition != nil {
+		winningTeam, over = s.mode.WinCondition(s)
+	}
+
+	if !over {
+		s.mu.Unlock()
+		return
+	}
+
+	s.state = SessionEnding
+	s.winningTeam = winningTeam
+	s.mu.Unlock()
+
+	fmt.Printf("Game session %s is over, winning team: %d\n", s.ID, winningTeam)
+
+	if winningTeam != 0 {
+		s.awardObjectiveExperience(winningTeam)
+	}
+
+	s.broadcast(types.Message{
+		Action: string(constants.ActionGameOver),
+		Payload: map[string]interface{}{
+			"session_id":   s.ID.String(),
+			"winning_team": winningTeam,
+		},
+	})
+}
+
+/**
+* stops every goroutine the session runs and waits for them to exit.
+**/
+// NOTE: must not be called from the session's own goroutines
+func (s *Session) Shutdown() {
+	s.mu.Lock()
+	if s.state == SessionClosed {
+		s.mu.Unlock()
+		return
+	}
+	s.state = SessionClosed
+	s.mu.Unlock()
+
+	fmt.Printf("Shutting down game session id %s\n", s.ID)
+	s.cancel()
+	s.running.Wait()
+}
+
+func (s *Session) PlayerCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.playerEntities)
+}
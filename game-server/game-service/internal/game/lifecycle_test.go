@@ -0,0 +1,120 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/serializer"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing sessions moving through their lifecycle and stopping cleanly.
+**/
+
+// TestSessionLifecycle tests a session goes from running through ending to closed
+func TestSessionLifecycle(t *testing.T) {
+	session := NewSession(createMockSender(), serializer.NewStateSerializer())
+	session.AddPlayer(uuid.New(), "player")
+
+	require.Eventually(t, func() bool {
+		return session.State() == SessionRunning
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, session.Deliver(types.ClientPackage{}))
+
+	session.End()
+	assert.Equal(t, SessionEnding, session.State())
+	assert.ErrorIs(t, session.Deliver(types.ClientPackage{}), ErrSessionClosed)
+
+	session.Shutdown()
+	assert.Equal(t, SessionClosed, session.State())
+
+	select {
+	case <-session.Done():
+	default:
+		t.Fatal("session context should be cancelled")
+	}
+
+	// shutting down again is harmless
+	session.Shutdown()
+	assert.ErrorIs(t, session.Deliver(types.ClientPackage{}), ErrSessionClosed)
+}
+
+// TestSessionDeliverNeverBlocks tests a session that's behind turns messages away
+// white box test, the session is built without its goroutines reading messages
+func TestSessionDeliverNeverBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	session := &Session{
+		ID:        uuid.New(),
+		MessageCh: make(chan types.ClientPackage, 1),
+		state:     SessionRunning,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	assert.NoError(t, session.Deliver(types.ClientPackage{}))
+	assert.ErrorIs(t, session.Deliver(types.ClientPackage{}), ErrSessionBusy)
+}
+
+// TestSessionEndsWhenEmpty tests a session ends itself as a draw once every player has left
+func TestSessionEndsWhenEmpty(t *testing.T) {
+	session, _ := newSpectatedSession(ModeCoop)
+	defer session.Shutdown()
+
+	playerID, otherID := uuid.New(), uuid.New()
+	session.AddPlayer(playerID, "player")
+	session.AddPlayer(otherID, "other")
+	session.AddBot(uuid.New(), "bot", components.NoTeam, systems.DefaultBotDifficulty)
+
+	session.RemovePlayer(playerID)
+	assert.NotEqual(t, SessionEnding, session.State())
+
+	// bots don't keep a game going
+	session.RemovePlayer(otherID)
+	assert.Equal(t, SessionEnding, session.State())
+	assert.Equal(t, 0, session.WinningTeam())
+}
+
+// TestLastTeamStanding tests team deathmatch is won by the last team with anyone alive
+func TestLastTeamStanding(t *testing.T) {
+	session, recorder := newSpectatedSession(ModeTeamDeathmatch)
+	defer session.Shutdown()
+
+	winnerID, loserID := uuid.New(), uuid.New()
+	session.AddPlayerToTeam(winnerID, "winner", 1)
+	loserEntityID := session.AddPlayerToTeam(loserID, "loser", 2)
+
+	session.checkGameOver()
+	assert.NotEqual(t, SessionEnding, session.State())
+
+	loser, ok := session.EntityManager.GetEntity(loserEntityID)
+	require.True(t, ok)
+	healthComp, _ := loser.GetComponent(ecs.ComponentTypeHealth)
+	healthComp.(*components.HealthComponent).CurrentHealth = 0
+
+	session.checkGameOver()
+	assert.Equal(t, SessionEnding, session.State())
+	assert.Equal(t, 1, session.WinningTeam())
+
+	over := recorder.actions(winnerID, constants.ActionGameOver)
+	require.Len(t, over, 1)
+	assert.Equal(t, 1, over[0].Payload["winning_team"])
+
+	// winning is the objective, only the winners earn it
+	gained := recorder.actions(winnerID, constants.ActionExperienceGained)
+	require.Len(t, gained, 1)
+	assert.Equal(t, string(systems.ExperienceSourceObjective), gained[0].Payload["source"])
+	assert.Equal(t, systems.DefaultProgressionConfig().ObjectiveExperience, gained[0].Payload["gained"])
+	assert.Empty(t, recorder.actions(loserID, constants.ActionExperienceGained))
+}
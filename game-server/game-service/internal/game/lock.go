@@ -0,0 +1,113 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+)
+
+/**
+* --- Locks and Keys ---
+*
+* Lockable doors and containers carry a LockComponent. Keys are inventory
+* items with a matching lock ID.
+**/
+
+/**
+* unlocks the target with a key from the player's inventory when it's locked.
+* Returns the reason the player couldn't open it otherwise.
+**/
+func (s *Session) tryUnlock(playerEntity, targetEntity *ecs.Entity) error {
+	lockComp, hasLock := targetEntity.GetComponent(ecs.ComponentTypeLock)
+	if !hasLock {
+		return nil
+	}
+
+	lock := lockComp.(*components.LockComponent)
+	if !lock.IsLocked {
+		return nil
+	}
+
+	inventoryComp, hasInventory := playerEntity.GetComponent(ecs.ComponentTypeInventory)
+	if !hasInventory {
+		return ErrLocked
+	}
+
+	inventory := inventoryComp.(*components.InventoryComponent)
+
+	hasAnyKey := false
+	for i, item := range inventory.Items {
+		if item.Type != components.ItemTypeKey {
+			continue
+		}
+		hasAnyKey = true
+
+		if item.LockID != lock.LockID {
+			continue
+		}
+
+		lock.IsLocked = false
+
+		if lock.ConsumeKey {
+			item.Quantity--
+			if item.Quantity <= 0 {
+				inventory.Items = append(inventory.Items[:i], inventory.Items[i+1:]...)
+			}
+		}
+
+		fmt.Printf("Entity %s unlocked with key %s\n", targetEntity.ID, item.Name)
+		return nil
+	}
+
+	if hasAnyKey {
+		return ErrWrongKey
+	}
+
+	return ErrLocked
+}
+
+/**
+* locks the target again after it was closed, if its lock is set up to.
+**/
+func (s *Session) relockOnClose(targetEntity *ecs.Entity) {
+	lockComp, hasLock := targetEntity.GetComponent(ecs.ComponentTypeLock)
+	if !hasLock {
+		return
+	}
+
+	lock := lockComp.(*components.LockComponent)
+	if lock.RelockOnClose {
+		lock.IsLocked = true
+	}
+}
+
+/**
+* adds an item, like a key, to a player's inventory.
+**/
+func (s *Session) GiveItem(playerID uuid.UUID, item *components.InventoryItem) error {
+	s.mu.RLock()
+	playerEntityID, ok := s.playerEntities[playerID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return ErrPlayerNotInSession
+	}
+
+	playerEntity, ok := s.EntityManager.GetEntity(playerEntityID)
+	if !ok {
+		return fmt.Errorf("player entity %s does not exist", playerEntityID)
+	}
+
+	inventoryComp, hasInventory := playerEntity.GetComponent(ecs.ComponentTypeInventory)
+	if !hasInventory {
+		inventoryComp = components.NewInventoryComponent()
+		playerEntity.AddComponent(inventoryComp)
+	}
+
+	inventory := inventoryComp.(*components.InventoryComponent)
+	inventory.Items = append(inventory.Items, item)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package game
+
+import (
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/google/uuid"
+)
+
+/**
+* Game modes decide the rules a session is played with.
+**/
+
+type GameMode struct {
+	Name string
+	// number of teams players are split into, 1 means everyone is on the same
+	// side (co-op against NPCs)
+	Teams int
+	// allows players on the same team to damage each other
+	FriendlyFire bool
+
+	// experience, level curve and stat growth
+	Progression systems.ProgressionConfig
+	// keeps levels and experience earned in a match for the next one
+	PersistProgression bool
+	// match results move players' skill ratings
+	Rated bool
+
+	// how far behind the game spectators see it, so they can't feed live
+	// positions to a player (ghosting). 0 streams the game as it happens
+	SpectatorDelay time.Duration
+	MaxSpectators  int
+
+	// sessions with open slots take players from the queue while the game
+	// is being played, spawning them through OnJoin
+	Backfill bool
+	OnJoin   JoinHook
+
+	// decides whether the game is over after a kill or a player leaving,
+	// nil when it only ends once everyone has left
+	WinCondition WinCondition
+}
+
+/**
+* decides where a player joining a game already in progress spawns.
+**/
+// NOTE: runs with the session lock held
+type JoinHook func(s *Session, userID uuid.UUID, team int) SpawnPoint
+
+/**
+* reports whether the game is over and which team won it, 0 for a draw.
+**/
+// NOTE: runs with the session lock held
+type WinCondition func(s *Session) (winningTeam int, over bool)
+
+type SpawnPoint struct {
+	X float64
+	Y float64
+}
+
+var (
+	ModeCoop = GameMode{
+		Name:         "coop",
+		Teams:        1,
+		FriendlyFire: false,
+
+		Progression:        systems.DefaultProgressionConfig(),
+		PersistProgression: true,
+
+		SpectatorDelay: 0,
+		MaxSpectators:  8,
+
+		Backfill: true,
+		OnJoin:   spawnNearTeammate,
+	}
+
+	ModeTeamDeathmatch = GameMode{
+		Name:         "team_deathmatch",
+		Teams:        2,
+		FriendlyFire: false,
+
+		Progression:        systems.DefaultProgressionConfig(),
+		PersistProgression: false,
+		Rated:              true,
+
+		SpectatorDelay: 10 * time.Second,
+		MaxSpectators:  16,
+
+		Backfill: true,
+		OnJoin:   spawnNearTeammate,
+
+		WinCondition: lastTeamStanding,
+	}
+)
+
+func DefaultGameMode() GameMode {
+	return ModeCoop
+}
+
+var modes = map[string]GameMode{
+	ModeCoop.Name:           ModeCoop,
+	ModeTeamDeathmatch.Name: ModeTeamDeathmatch,
+}
+
+/**
+* finds a game mode by name, an empty name gives the default mode.
+**/
+func ModeByName(name string) (GameMode, bool) {
+	if name == "" {
+		return DefaultGameMode(), true
+	}
+
+	mode, ok := modes[name]
+	return mode, ok
+}
+
+/**
+* puts the player next to someone on their team so they don't join the
+* game alone, the start spawn when nobody is.
+**/
+// NOTE: caller must hold the lock
+func spawnNearTeammate(s *Session, userID uuid.UUID, team int) SpawnPoint {
+	for playerID, entityID := range s.playerEntities {
+		if playerID == userID {
+			continue
+		}
+
+		entity, ok := s.EntityManager.GetEntity(entityID)
+		if !ok {
+			continue
+		}
+
+		teamComp, hasTeam := entity.GetComponent(ecs.ComponentTypeTeam)
+		if !hasTeam || teamComp.(*components.TeamComponent).TeamID != team {
+			continue
+		}
+
+		transformComp, hasTransform := entity.GetComponent(ecs.ComponentTypeTransform)
+		if !hasTransform {
+			continue
+		}
+
+		transform := transformComp.(*components.TransformComponent)
+		return SpawnPoint{X: transform.X + constants.DefaultColliderRadius*2, Y: transform.Y}
+	}
+
+	return SpawnPoint{}
+}
+
+/**
+* the game is over once only one team has anyone left alive, that team wins.
+* Everyone dying at once is a draw.
+**/
+// NOTE: caller must hold the lock
+func lastTeamStanding(s *Session) (int, bool) {
+	alive := make(map[int]bool)
+
+	for _, entityID := range s.playerEntities {
+		entity, ok := s.EntityManager.GetEntity(entityID)
+		if !ok {
+			continue
+		}
+
+		if healthComp, hasHealth := entity.GetComponent(ecs.ComponentTypeHealth); hasHealth && healthComp.(*components.HealthComponent).CurrentHealth <= 0 {
+			continue
+		}
+
+		if teamComp, hasTeam := entity.GetComponent(ecs.ComponentTypeTeam); hasTeam {
+			alive[teamComp.(*components.TeamComponent).TeamID] = true
+		}
+	}
+
+	if len(alive) > 1 {
+		return 0, false
+	}
+
+	for team := range alive {
+		return team, true
+	}
+	return 0, true
+}
+
+/**
+* Maps players can pick for their rooms.
+**/
+
+const DefaultMap = "derelict_station"
+
+var maps = map[string]bool{
+	DefaultMap:       true,
+	"asteroid_field": true,
+	"reactor_core":   true,
+}
+
+func IsKnownMap(name string) bool {
+	return maps[name]
+}
@@ -0,0 +1,151 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* --- Progression ---
+*
+* Experience is awarded for kills, objectives and treasure. Players are told
+* about every gain and every level up.
+**/
+
+/**
+* awards a player the experience a source is worth in this session's mode.
+**/
+func (s *Session) AwardExperience(playerID uuid.UUID, source systems.ExperienceSource) error {
+	s.mu.RLock()
+	playerEntityID, ok := s.playerEntities[playerID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return ErrPlayerNotInSession
+	}
+
+	return s.awardEntityExperience(playerEntityID, s.progressionSystem.ExperienceFor(source), source)
+}
+
+/**
+* kills are credited to the entity that landed the final hit, only players
+* earn experience.
+**/
+func (s *Session) awardKillExperience(killerEntityID uuid.UUID) {
+	killer, ok := s.EntityManager.GetEntity(killerEntityID)
+	if !ok || !killer.HasComponent(ecs.ComponentTypePlayer) {
+		return
+	}
+
+	source := systems.ExperienceSourceKill
+	if err := s.awardEntityExperience(killerEntityID, s.progressionSystem.ExperienceFor(source), source); err != nil {
+		fmt.Printf("Failed to award kill experience to entity %s: %s\n", killerEntityID, err)
+	}
+}
+
+/**
+* winning the game is every mode's objective, everyone on the winning team
+* still in the session earns it.
+**/
+func (s *Session) awardObjectiveExperience(winningTeam int) {
+	source := systems.ExperienceSourceObjective
+
+	for _, playerID := range s.GetPlayerIDs() {
+		if s.GetPlayerTeam(playerID) != winningTeam {
+			continue
+		}
+
+		if err := s.AwardExperience(playerID, source); err != nil {
+			fmt.Printf("Failed to award objective experience to player %s: %s\n", playerID, err)
+		}
+	}
+}
+
+func (s *Session) awardEntityExperience(entityID uuid.UUID, amount int, source systems.ExperienceSource) error {
+	entity, ok := s.EntityManager.GetEntity(entityID)
+	if !ok {
+		return fmt.Errorf("entity %s does not exist", entityID)
+	}
+
+	result, err := s.progressionSystem.AwardExperience(entity, amount)
+	if err != nil {
+		return err
+	}
+
+	playerComp, isPlayer := entity.GetComponent(ecs.ComponentTypePlayer)
+	if !isPlayer {
+		return nil
+	}
+
+	playerID := playerComp.(*components.PlayerComponent).UserID
+
+	s.sender.SendToPlayer(playerID, types.Message{
+		Action: string(constants.ActionExperienceGained),
+		Payload: map[string]interface{}{
+			"source":     string(source),
+			"gained":     result.Gained,
+			"experience": result.Total,
+			"next_level": result.NextLevel,
+			"level":      result.Level,
+		},
+	})
+
+	if result.Levels > 0 {
+		s.sender.SendToPlayer(playerID, levelUpMessage(entity, result))
+	}
+
+	return nil
+}
+
+/**
+* spends the player's unspent points on a stat, returning their stats after.
+**/
+func (s *Session) handleAllocateStat(playerID uuid.UUID, stat systems.Stat, points int) (map[string]interface{}, error) {
+	s.mu.RLock()
+	playerEntityID, ok := s.playerEntities[playerID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrPlayerNotInSession
+	}
+
+	playerEntity, ok := s.EntityManager.GetEntity(playerEntityID)
+	if !ok {
+		return nil, fmt.Errorf("player entity %s does not exist", playerEntityID)
+	}
+
+	if err := s.progressionSystem.AllocatePoints(playerEntity, stat, points); err != nil {
+		return nil, err
+	}
+
+	statsComp, _ := playerEntity.GetComponent(ecs.ComponentTypeStats)
+	return statsPayload(statsComp.(*components.StatsComponent)), nil
+}
+
+func levelUpMessage(entity *ecs.Entity, result systems.ExperienceResult) types.Message {
+	statsComp, _ := entity.GetComponent(ecs.ComponentTypeStats)
+	payload := statsPayload(statsComp.(*components.StatsComponent))
+	payload["levels_gained"] = result.Levels
+
+	return types.Message{
+		Action:  string(constants.ActionLevelUp),
+		Payload: payload,
+	}
+}
+
+func statsPayload(stats *components.StatsComponent) map[string]interface{} {
+	return map[string]interface{}{
+		"level":          stats.Level,
+		"experience":     stats.Experience,
+		"strength":       stats.Strength,
+		"agility":        stats.Agility,
+		"intelligence":   stats.Intelligence,
+		"unspent_points": stats.UnspentPoints,
+	}
+}
@@ -0,0 +1,38 @@
+package game
+
+/**
+* Definitions for every projectile a skill can fire.
+**/
+
+type ProjectileDefinition struct {
+	Name string
+	// units per second
+	Speed float64
+	// seconds before it expires on its own
+	Lifetime float64
+	// number of extra targets it passes through after the first hit
+	PierceCount int
+	Damage      int
+}
+
+var projectileDefinitions = map[string]ProjectileDefinition{
+	"Fireball": {
+		Name:        "Fireball",
+		Speed:       8,
+		Lifetime:    2,
+		PierceCount: 0,
+		Damage:      20,
+	},
+	"Piercing Arrow": {
+		Name:        "Piercing Arrow",
+		Speed:       12,
+		Lifetime:    1.5,
+		PierceCount: 2,
+		Damage:      12,
+	},
+}
+
+func GetProjectileDefinition(name string) (ProjectileDefinition, bool) {
+	definition, ok := projectileDefinitions[name]
+	return definition, ok
+}
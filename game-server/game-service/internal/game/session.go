@@ -1,9 +1,12 @@
 package game
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
@@ -23,21 +26,56 @@ type Session struct {
 	MessageCh     chan types.ClientPackage
 	// [playerID] playerEntityID
 	playerEntities map[uuid.UUID]uuid.UUID
-	mu             sync.RWMutex
+	// players whose connection dropped, kept in the session until they
+	// reconnect or the server removes them
+	// [playerID] to when they disconnected
+	disconnected map[uuid.UUID]time.Time
+	// users watching without an entity
+	// [spectatorID] to the player their camera follows, nil for none
+	spectators map[uuid.UUID]uuid.UUID
+	// states waiting out the mode's spectator delay, oldest first
+	spectatorFrames []spectatorFrame
+	// players controlled by the server
+	// [playerID] to whether they're a bot
+	bots map[uuid.UUID]bool
+	// humans who left before the game ended, they still count towards its
+	// result
+	// [playerID] to the team they played on
+	departed map[uuid.UUID]int
+	// what those humans' characters looked like when they left, saved with
+	// everyone else's at the end
+	// [playerID] to their character
+	departedStates map[uuid.UUID]*types.CharacterState
+	// round trips measured by the server, for lag compensation
+	// [playerID] to their latency
+	latencies map[uuid.UUID]time.Duration
+	mu        sync.RWMutex
+	// positions and velocities, the game loop moves players while their
+	// input steers them from the message goroutine
+	motionMu sync.Mutex
+
+	// rules this session is played with
+	mode     GameMode
+	factions *systems.FactionTable
 
 	movementSystem *systems.MovementSystem
 	combatSystem   *systems.CombatSystem
 	skillSystem    *systems.SkillSystem
 
-	stopChan  chan struct{}
-	isRunning bool
-
-	// caching
-
-	// [playerID] - interacted
-	playerInteractedCache map[uuid.UUID]bool
-	// [entityID] - interacted
-	containerInteractedCache map[uuid.UUID]bool
+	projectileSystem  *systems.ProjectileSystem
+	interactionSystem *systems.InteractionSystem
+	progressionSystem *systems.ProgressionSystem
+	aiSystem          *systems.AISystem
+
+	// lifecycle, cancelling ctx stops every goroutine the session runs
+	state   SessionState
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+	// the team that won once the game ended itself, 0 for a draw
+	winningTeam int
+	// ticks whose updates took longer than the tick itself
+	tickOverruns atomic.Int64
 
 	// TEST: testing only
 	TestMessageSpy chan types.Message
@@ -48,27 +86,52 @@ type Session struct {
 }
 
 func NewSession(sender *messaging.MessageSender, serializer *serializer.StateSerializer) *Session {
+	return NewSessionWithMode(sender, serializer, DefaultGameMode())
+}
+
+func NewSessionWithMode(sender *messaging.MessageSender, serializer *serializer.StateSerializer, mode GameMode) *Session {
 	sessionId := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	factions := systems.NewFactionTable()
+	combatSystem := systems.NewCombatSystem(factions, mode.FriendlyFire)
+	skillSystem := systems.NewSkillSystem(factions, mode.FriendlyFire)
 
 	s := &Session{
 		ID:            sessionId,
 		EntityManager: ecs.NewEntityManager(),
 		// map [playerID] to entityID
 		playerEntities: make(map[uuid.UUID]uuid.UUID),
+		disconnected:   make(map[uuid.UUID]time.Time),
+		spectators:     make(map[uuid.UUID]uuid.UUID),
+		bots:           make(map[uuid.UUID]bool),
+		departed:       make(map[uuid.UUID]int),
+		departedStates: make(map[uuid.UUID]*types.CharacterState),
+		latencies:      make(map[uuid.UUID]time.Duration),
 		MessageCh:      make(chan types.ClientPackage, 100),
 
+		mode:     mode,
+		factions: factions,
+
 		movementSystem: systems.NewMovementSystem(),
-		combatSystem:   systems.NewCombatSystem(),
-		skillSystem:    systems.NewSkillSystem(),
-		stopChan:       make(chan struct{}),
-		isRunning:      false,
+		combatSystem:   combatSystem,
+		skillSystem:    skillSystem,
+
+		projectileSystem: systems.NewProjectileSystem(combatSystem),
 
-		playerInteractedCache:    make(map[uuid.UUID]bool, constants.DefautMaxSessionPlayers),
-		containerInteractedCache: make(map[uuid.UUID]bool),
-		sender:                   sender,
-		stateSerializer:          serializer,
+		state:  SessionStarting,
+		ctx:    ctx,
+		cancel: cancel,
+
+		interactionSystem: systems.NewInterationSystem(),
+		progressionSystem: systems.NewProgressionSystem(mode.Progression),
+		aiSystem:          systems.NewAISystem(combatSystem, skillSystem),
+
+		sender:          sender,
+		stateSerializer: serializer,
 	}
 
+	s.registerInteractionHandlers()
+
 	go s.Start()
 
 	return s
@@ -82,17 +145,25 @@ func (s *Session) Start() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if s.isRunning {
+	// already started, or shut down before it got the chance
+	if s.state != SessionStarting {
 		return
 	}
 
-	s.isRunning = true
+	s.state = SessionRunning
+	s.running.Add(2)
 
 	// managing incoming client messages
-	go s.manageClientMessages()
+	go func() {
+		defer s.running.Done()
+		s.manageClientMessages()
+	}()
 
 	// start update game loop
-	go s.manageGameLoop()
+	go func() {
+		defer s.running.Done()
+		s.manageGameLoop()
+	}()
 }
 
 /**
@@ -104,12 +175,17 @@ func (s *Session) manageClientMessages() {
 	if s.TestMessageSpy != nil {
 		for {
 			select {
+			case <-s.ctx.Done():
+				return
 			case message := <-s.MessageCh:
 				fmt.Printf("\nTest message received, %+v\n\n", message)
 
 				// propogate to test
-				s.TestMessageSpy <- message.Message
-			default:
+				select {
+				case s.TestMessageSpy <- message.Message:
+				case <-s.ctx.Done():
+					return
+				}
 			}
 		}
 	}
@@ -117,114 +193,267 @@ func (s *Session) manageClientMessages() {
 
 	for {
 		select {
+		case <-s.ctx.Done():
+			return
+
 		case msg := <-s.MessageCh:
 			fmt.Printf("\nincoming message to game session %s:\n%v\n\n", s.ID, msg)
 
-			switch constants.Action(msg.Message.Action) {
-			case constants.ActionMove:
+			s.handleClientMessage(msg)
+		}
+	}
+}
 
-				fmt.Printf("Action from client was move\n")
-				// parse payload based on message action
-				parsedPayload, err := msg.Message.ParsePayload()
+/**
+* applies one client message to the game. Payloads are decoded and validated
+* before anything acts on them, and a message that still manages to panic is
+* dropped without taking the session down with it.
+**/
+func (s *Session) handleClientMessage(msg types.ClientPackage) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fmt.Printf("\nSession %s recovered from a panic handling %s: %v\n\n", s.ID, msg.Message.Action, recovered)
+		}
+	}()
 
-				if err != nil {
-					// TODO: respond to client error
-					fmt.Printf("\n attempting to parse payload from %+v from unsuccesfull as types don't match.\n\n", parsedPayload)
-				}
+	action := constants.Action(msg.Message.Action)
 
-				movePayload := parsedPayload.(types.PlayerSessionMovePayload)
+	parsedPayload, err := msg.Message.ParsePayload()
+	if err != nil {
+		s.rejectPayload(msg.Message, err)
+		return
+	}
 
-				fmt.Printf("\nParsed move payload:\n%+v\n\n", movePayload)
+	switch payload := parsedPayload.(type) {
+	case *types.PlayerSessionMovePayload:
+		playerID := uuid.MustParse(payload.PlayerID)
 
-				// update based on action payload
-				playerID, err := uuid.Parse(movePayload.PlayerID)
-				if err != nil {
-					fmt.Printf("\nPlayerID %s from session payload was invalid.\n\n", movePayload.PlayerID)
-					// TODO: respond to client error
-				}
-				s.handleMove(playerID, movePayload.Vx, movePayload.Vy)
+		if err := s.handleMove(playerID, payload.Vx, payload.Vy); err != nil {
+			s.rejectAction(playerID, msg.Message, actionFailureReason(err), err, nil)
+			return
+		}
+		s.acknowledge(playerID, msg.Message, nil)
 
-			case constants.ActionInteract:
-				fmt.Printf("Action from client was interact")
+	case *types.PlayerSessionInteractPayload:
+		playerID := uuid.MustParse(payload.PlayerID)
+		entityID := uuid.MustParse(payload.EntityID)
+		details := map[string]interface{}{"entity_id": entityID.String()}
 
-				parsedPayload, err := msg.Message.ParsePayload()
+		result, err := s.handleInteractVerb(playerID, entityID, components.InteractionVerb(payload.Verb))
+		if err != nil {
+			s.rejectAction(playerID, msg.Message, interactFailureReason(err), err, details)
+			return
+		}
 
-				if err != nil {
-					// TODO respond to client error
-				}
+		for key, value := range result {
+			details[key] = value
+		}
 
-				interactPayload := parsedPayload.(types.PlayerSessionInteractPayload)
-				fmt.Printf("\nParsed interact payload:\n%+v\n\n", interactPayload)
+		// interact results always go out, the outcome is the point
+		s.sendResult(playerID, msg.Message, true, details)
 
-				playerID, err := uuid.Parse(interactPayload.PlayerID)
+	case *types.PlayerSessionChatPayload:
+		playerID := uuid.MustParse(payload.PlayerID)
 
-				if err != nil {
-					fmt.Printf("\nPlayerID %s from session payload was invalid.\n\n", interactPayload.PlayerID)
-					// TODO: respond to client error
-				}
+		if err := s.handleChat(playerID, payload.Channel, payload.Message); err != nil {
+			s.rejectAction(playerID, msg.Message, actionFailureReason(err), err, nil)
+			return
+		}
+		s.acknowledge(playerID, msg.Message, nil)
 
-				entityIDUUID, err := uuid.Parse(interactPayload.EntityID)
+	case *types.PlayerSessionAttackPayload:
+		playerID := uuid.MustParse(payload.PlayerID)
 
-				if err != nil {
-					fmt.Printf("\nEntityID %s from session payload was invalid.\n\n", interactPayload.EntityID)
-					// TODO: respond to client error
-				}
+		projectileID, err := s.SpawnProjectile(playerID, payload.Projectile, payload.Dx, payload.Dy)
+		if err != nil {
+			s.rejectAction(playerID, msg.Message, actionFailureReason(err), err, nil)
+			return
+		}
+		s.acknowledge(playerID, msg.Message, map[string]interface{}{"projectile_id": projectileID.String()})
 
-				err = s.handleInteract(playerID, entityIDUUID)
+	case *types.PlayerSessionAllocateStatPayload:
+		playerID := uuid.MustParse(payload.PlayerID)
 
-				if err != nil {
-					s.sender.SendToPlayer(playerID, types.Message{})
-				}
-			}
+		stats, err := s.handleAllocateStat(playerID, systems.Stat(payload.Stat), payload.Points)
+		if err != nil {
+			s.rejectAction(playerID, msg.Message, actionFailureReason(err), err, nil)
+			return
 		}
+
+		// the new stats always go out so the client can show them
+		s.sendResult(playerID, msg.Message, true, stats)
+
+	default:
+		fmt.Printf("\nSession %s has no handler for action %s\n\n", s.ID, action)
+	}
+}
+
+/**
+* --- Acknowledgements ---
+* Every game action gets a reply with the request id it came with. Failures
+* are always sent, successes only when the client gave a request id to match
+* them with, so moves don't double the traffic for clients not listening.
+**/
+
+func (s *Session) acknowledge(playerID uuid.UUID, message types.Message, details map[string]interface{}) {
+	if message.RequestID == "" {
+		return
+	}
+
+	s.sendResult(playerID, message, true, details)
+}
+
+func (s *Session) rejectAction(playerID uuid.UUID, message types.Message, reason constants.ErrorCode, err error, details map[string]interface{}) {
+	payload := map[string]interface{}{
+		"reason":  string(reason),
+		"message": err.Error(),
 	}
+	for key, value := range details {
+		payload[key] = value
+	}
+
+	s.sendResult(playerID, message, false, payload)
+}
+
+func (s *Session) sendResult(playerID uuid.UUID, message types.Message, success bool, payload map[string]interface{}) {
+	if payload == nil {
+		payload = make(map[string]interface{}, 1)
+	}
+	payload["success"] = success
+
+	s.sender.SendToPlayer(playerID, types.Message{
+		Action:    message.Action,
+		RequestID: message.RequestID,
+		Payload:   payload,
+	})
+}
+
+/**
+* tells the player their message was rejected and which fields were wrong.
+* Messages without a valid player id have nobody to answer and are dropped.
+**/
+func (s *Session) rejectPayload(message types.Message, err error) {
+	fmt.Printf("\nSession %s rejected %s payload: %s\n\n", s.ID, message.Action, err)
+
+	playerIDStr, _ := message.Payload["player_id"].(string)
+	playerID, parseErr := uuid.Parse(playerIDStr)
+	if parseErr != nil {
+		return
+	}
+
+	var details map[string]interface{}
+
+	var payloadErr *types.PayloadError
+	if errors.As(err, &payloadErr) {
+		details = map[string]interface{}{"fields": payloadErr.Fields}
+	}
+
+	s.rejectAction(playerID, message, constants.ErrorInvalidPayload, err, details)
 }
 
 const framerate = 1
 
+const tickInterval = (1 * time.Second) / framerate
+
 /**
 * manages all the game update loops.
 * runs system code to update state of game x times every second.
 **/
 func (s *Session) manageGameLoop() {
 	// TODO: update from once per second to 30 / 60 times a second
-	ticker := time.NewTicker((1 * time.Second) / framerate)
+	ticker := time.NewTicker(tickInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
+		case <-s.ctx.Done():
+			return
+
 		case <-ticker.C:
+			tickStart := time.Now()
+
+			// bots decide before anything moves, their inputs go through
+			// the message channel like everyone else's
+			s.driveBots(time.Now())
+
 			entities := s.EntityManager.GetAllEntities()
 
 			// movement
 			movementSys := systems.MovementSystem{}
+			s.motionMu.Lock()
 			movementSys.Update(float64(1), entities)
+			s.motionMu.Unlock()
+
+			// projectiles
+			projectileUpdate := s.projectileSystem.Update(float64(1), entities)
+			s.applyProjectileUpdate(projectileUpdate)
 
 			// interaction
-			interactionSys := systems.InteractionSystem{}
-			interactionSys.Update(entities)
+			s.interactionSystem.Update(entities)
+
+			s.streamToSpectators(time.Now())
+
+			// the ticker drops the ticks a slow update runs into
+			if time.Since(tickStart) > tickInterval {
+				s.tickOverruns.Add(1)
+			}
 		}
 	}
 }
 
+/**
+* how many ticks took longer to update than the tick interval.
+**/
+func (s *Session) TickOverruns() int64 {
+	return s.tickOverruns.Load()
+}
+
 func (s *Session) AddPlayer(userID uuid.UUID, username string) uuid.UUID {
+	return s.AddPlayerToTeam(userID, username, components.NoTeam)
+}
+
+/**
+* adds a player on a specific team. Passing NoTeam lets the session balance
+* the player onto the team with the fewest members.
+**/
+func (s *Session) AddPlayerToTeam(userID uuid.UUID, username string, team int) uuid.UUID {
+	return s.AddPlayerWithCharacter(userID, username, team, nil)
+}
+
+/**
+* adds a player using their persisted character. A nil character spawns the
+* player with default stats and items.
+**/
+func (s *Session) AddPlayerWithCharacter(userID uuid.UUID, username string, team int, character *types.CharacterState) uuid.UUID {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if team == components.NoTeam {
+		team = s.smallestTeam()
+	}
+
+	return s.spawnPlayer(userID, username, team, character, SpawnPoint{})
+}
+
+// NOTE: caller must hold the lock
+func (s *Session) spawnPlayer(userID uuid.UUID, username string, team int, character *types.CharacterState, at SpawnPoint) uuid.UUID {
 	PlayerConfig := PlayerConfig{
 		UserID:        userID,
 		Username:      username,
-		X:             0,
-		Y:             0,
+		X:             at.X,
+		Y:             at.Y,
 		SkillName:     "Basic Attack",
 		SkillLevel:    1,
-		CurrentHealth: 100,
-		MaxHealth:     100,
-		ItemName:      "Health Potion",
-		ItemQuantity:  3,
+		CurrentHealth: constants.DefaultPlayerHealth,
+		MaxHealth:     constants.DefaultPlayerHealth,
+		ItemName:      constants.DefaultStartingItem,
+		ItemQuantity:  constants.DefaultStartingQuantity,
 
 		Vx: 0,
 		Vy: 0,
+
+		Team:      team,
+		Character: character,
 	}
 
 	entity := CreatePlayerEntity(s.EntityManager, PlayerConfig)
@@ -234,24 +463,417 @@ func (s *Session) AddPlayer(userID uuid.UUID, username string) uuid.UUID {
 	return entity.ID
 }
 
-func (s *Session) RemovePlayer(userID string) {
-	// 選項 1: 直接移除
-	// 選項 2: 標記為死亡，等待復活
+/**
+* finds the team with the least players for auto balancing.
+* NOTE: caller must hold the lock.
+**/
+func (s *Session) smallestTeam() int {
+	if s.mode.Teams <= 0 {
+		return components.NoTeam
+	}
+
+	counts := s.teamCounts()
+
+	smallest := 1
+	for team := 2; team <= s.mode.Teams; team++ {
+		if counts[team] < counts[smallest] {
+			smallest = team
+		}
+	}
+
+	return smallest
 }
 
-func (s *Session) AddDoor(x, y float64) uuid.UUID {
+/**
+* returns the team a player is on, NoTeam if they're not in the session.
+**/
+func (s *Session) GetPlayerTeam(userID uuid.UUID) int {
+	s.mu.RLock()
+	entityID, ok := s.playerEntities[userID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return components.NoTeam
+	}
+
+	entity, ok := s.EntityManager.GetEntity(entityID)
+	if !ok {
+		return components.NoTeam
+	}
+
+	teamComp, hasTeam := entity.GetComponent(ecs.ComponentTypeTeam)
+	if !hasTeam {
+		return components.NoTeam
+	}
+
+	return teamComp.(*components.TeamComponent).TeamID
+}
+
+/**
+* where the player is, read under the lock the game loop moves them with.
+**/
+func (s *Session) PlayerPosition(userID uuid.UUID) (types.Position, bool) {
+	transformComp, ok := s.playerComponent(userID, ecs.ComponentTypeTransform)
+	if !ok {
+		return types.Position{}, false
+	}
+
+	transform := transformComp.(*components.TransformComponent)
+
+	s.motionMu.Lock()
+	defer s.motionMu.Unlock()
+
+	return types.Position{X: transform.X, Y: transform.Y}, true
+}
+
+/**
+* which way and how fast the player is moving, read under the same lock.
+**/
+func (s *Session) PlayerDirection(userID uuid.UUID) (types.PlayerDirection, bool) {
+	velocityComp, ok := s.playerComponent(userID, ecs.ComponentTypeVelocity)
+	if !ok {
+		return types.PlayerDirection{}, false
+	}
+
+	velocity := velocityComp.(*components.VelocityComponent)
+
+	s.motionMu.Lock()
+	defer s.motionMu.Unlock()
+
+	return types.PlayerDirection{VX: velocity.VX, VY: velocity.VY, Speed: velocity.Speed}, true
+}
+
+func (s *Session) playerComponent(userID uuid.UUID, componentType ecs.ComponentType) (ecs.Component, bool) {
+	s.mu.RLock()
+	entityID, ok := s.playerEntities[userID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	entity, ok := s.EntityManager.GetEntity(entityID)
+	if !ok {
+		return nil, false
+	}
+
+	return entity.GetComponent(componentType)
+}
+
+/**
+* the team of every human who played in the session, including the ones who
+* left before it ended.
+**/
+func (s *Session) Participants() map[uuid.UUID]int {
+	teams := make(map[uuid.UUID]int)
+
+	s.mu.RLock()
+	for playerID, team := range s.departed {
+		teams[playerID] = team
+	}
+	s.mu.RUnlock()
+
+	for _, playerID := range s.HumanIDs() {
+		teams[playerID] = s.GetPlayerTeam(playerID)
+	}
+	return teams
+}
+
+func (s *Session) Mode() GameMode {
+	return s.mode
+}
+
+func (s *Session) HasPlayer(userID uuid.UUID) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.playerEntities[userID]
+	return ok
+}
+
+/**
+* removes the player's entity from the session for good and lets everyone
+* else know they left.
+**/
+func (s *Session) RemovePlayer(userID uuid.UUID) {
+	team := s.GetPlayerTeam(userID)
+
+	s.mu.Lock()
+	entityID, ok := s.playerEntities[userID]
+	human := ok && !s.bots[userID]
+	if ok {
+		if human {
+			s.departed[userID] = team
+		}
+		delete(s.playerEntities, userID)
+		delete(s.disconnected, userID)
+		delete(s.bots, userID)
+		delete(s.latencies, userID)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if human {
+		s.keepDepartedState(userID, entityID)
+	}
+
+	s.EntityManager.RemoveEntity(entityID)
+
+	s.broadcast(types.Message{
+		Action: string(constants.ActionPlayerLeft),
+		Payload: map[string]interface{}{
+			"session_id": s.ID.String(),
+			"player_id":  userID.String(),
+		},
+	})
+
+	s.checkGameOver()
+}
+
+/**
+* keeps the player's entity in place while their connection is down. They
+* stop moving so they don't drift off while nobody controls them.
+**/
+func (s *Session) MarkDisconnected(userID uuid.UUID) bool {
+	s.mu.Lock()
+	entityID, ok := s.playerEntities[userID]
+	if ok {
+		s.disconnected[userID] = time.Now()
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	if entity, exists := s.EntityManager.GetEntity(entityID); exists {
+		if vc, hasVelocity := entity.GetComponent(ecs.ComponentTypeVelocity); hasVelocity {
+			velocity := vc.(*components.VelocityComponent)
+			s.motionMu.Lock()
+			velocity.VX = 0
+			velocity.VY = 0
+			s.motionMu.Unlock()
+		}
+	}
+
+	s.broadcast(types.Message{
+		Action: string(constants.ActionPlayerDisconnected),
+		Payload: map[string]interface{}{
+			"session_id": s.ID.String(),
+			"player_id":  userID.String(),
+		},
+	})
+
+	return true
+}
+
+/**
+* clears a disconnect, reporting whether the player was disconnected.
+**/
+func (s *Session) MarkReconnected(userID uuid.UUID) bool {
+	s.mu.Lock()
+	_, wasDisconnected := s.disconnected[userID]
+	delete(s.disconnected, userID)
+	s.mu.Unlock()
+
+	if wasDisconnected {
+		s.broadcast(types.Message{
+			Action: string(constants.ActionPlayerReconnected),
+			Payload: map[string]interface{}{
+				"session_id": s.ID.String(),
+				"player_id":  userID.String(),
+			},
+		})
+	}
+
+	return wasDisconnected
+}
+
+func (s *Session) IsDisconnected(userID uuid.UUID) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, disconnected := s.disconnected[userID]
+	return disconnected
+}
+
+func (s *Session) SetPlayerLatency(userID uuid.UUID, latency time.Duration) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	doorConfig := DoorConfig{
+	if _, ok := s.playerEntities[userID]; ok {
+		s.latencies[userID] = latency
+	}
+}
+
+/**
+* the player's round trip to the server, zero until it's been measured and
+* for bots.
+**/
+func (s *Session) PlayerLatency(userID uuid.UUID) time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.latencies[userID]
+}
+
+/**
+* the full client facing state of the session, e.g. for players catching up
+* after a reconnect.
+**/
+func (s *Session) Snapshot() (*types.ClientGameState, error) {
+	entities := make(map[uuid.UUID]*ecs.Entity)
+	for _, entity := range s.EntityManager.GetAllEntities() {
+		entities[entity.ID] = entity
+	}
+
+	s.motionMu.Lock()
+	defer s.motionMu.Unlock()
+
+	return s.stateSerializer.Serialize(s.ID, entities)
+}
+
+func (s *Session) AddDoor(x, y float64) uuid.UUID {
+	return s.AddDoorWithConfig(DoorConfig{
 		X: x,
 		Y: y,
-	}
+	})
+}
+
+/**
+* adds a door with full configuration, e.g. a lock.
+**/
+func (s *Session) AddDoorWithConfig(doorConfig DoorConfig) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	entity := CreateDoorEntity(s.EntityManager, doorConfig)
 	return entity.ID
 }
 
+func (s *Session) AddContainer(containerConfig ContainerConfig) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity := CreateContainerEntity(s.EntityManager, containerConfig)
+	return entity.ID
+}
+
+func (s *Session) AddWall(x, y, width, height float64) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wallConfig := WallConfig{
+		X:      x,
+		Y:      y,
+		Width:  width,
+		Height: height,
+	}
+
+	entity := CreateWallEntity(s.EntityManager, wallConfig)
+	return entity.ID
+}
+
+/**
+* fires a projectile from a player's position in the direction given.
+**/
+func (s *Session) SpawnProjectile(playerID uuid.UUID, projectileName string, dirX, dirY float64) (uuid.UUID, error) {
+	definition, ok := GetProjectileDefinition(projectileName)
+	if !ok {
+		return uuid.Nil, ErrUnknownProjectile
+	}
+
+	length := math.Sqrt(dirX*dirX + dirY*dirY)
+	if length == 0 {
+		return uuid.Nil, ErrInvalidDirection
+	}
+
+	s.mu.RLock()
+	playerEntityID, ok := s.playerEntities[playerID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return uuid.Nil, ErrPlayerNotInSession
+	}
+
+	playerEntity, ok := s.EntityManager.GetEntity(playerEntityID)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("player entity %s does not exist", playerEntityID)
+	}
+
+	transformComp, hasTransform := playerEntity.GetComponent(ecs.ComponentTypeTransform)
+	if !hasTransform {
+		return uuid.Nil, fmt.Errorf("player entity %s has no transform", playerEntityID)
+	}
+	transform := transformComp.(*components.TransformComponent)
+
+	config := ProjectileConfig{
+		OwnerID:    playerEntityID,
+		X:          transform.X,
+		Y:          transform.Y,
+		DirX:       dirX / length,
+		DirY:       dirY / length,
+		Definition: definition,
+		Team:       components.NoTeam,
+		Faction:    components.FactionPlayers,
+	}
+
+	if teamComp, hasTeam := playerEntity.GetComponent(ecs.ComponentTypeTeam); hasTeam {
+		team := teamComp.(*components.TeamComponent)
+		config.Team = team.TeamID
+		config.Faction = team.Faction
+	}
+
+	entity := CreateProjectileEntity(s.EntityManager, config)
+
+	return entity.ID, nil
+}
+
+/**
+* removes despawned projectiles and lets players know what got hit.
+**/
+func (s *Session) applyProjectileUpdate(update systems.ProjectileUpdate) {
+	killed := false
+
+	for _, hit := range update.Hits {
+		s.broadcast(types.Message{
+			Action: string(constants.ActionProjectileHit),
+			Payload: map[string]interface{}{
+				"projectile_id": hit.ProjectileID.String(),
+				"attacker_id":   hit.AttackerID.String(),
+				"target_id":     hit.TargetID.String(),
+				"damage":        hit.Damage,
+				"killed":        hit.Killed,
+			},
+		})
+
+		if hit.Killed {
+			killed = true
+			s.awardKillExperience(hit.AttackerID)
+		}
+	}
+
+	for _, entityID := range update.Despawned {
+		s.EntityManager.RemoveEntity(entityID)
+	}
+
+	if killed {
+		s.checkGameOver()
+	}
+}
+
+/**
+* sends a message to every human player in the session.
+**/
+func (s *Session) broadcast(message types.Message) {
+	for _, playerID := range s.HumanIDs() {
+		s.sender.SendToPlayer(playerID, message)
+	}
+}
+
 func (s *Session) Update(deltaTime float64) {
 	// fmt.Printf("Session %s updating...\n", s.ID)
 	// entities := s.EntityManager.GetAllEntities()
@@ -261,18 +883,6 @@ func (s *Session) Update(deltaTime float64) {
 	// s.skillSystem.Update(deltaTime, entities)
 }
 
-func (s *Session) Shutdown() {
-	s.mu.Lock()
-	if !s.isRunning {
-		s.mu.Unlock()
-		return
-	}
-	s.mu.Unlock()
-	fmt.Printf("Shutting down game session id %s\n", s.ID)
-	close(s.stopChan)
-	close(s.MessageCh)
-}
-
 /**
 * GetPlayerIDs returns all player IDs in this session
 **/
@@ -310,7 +920,7 @@ func (s *Session) handleMove(playerID uuid.UUID, vx, vy float64) error {
 
 	if !ok {
 		fmt.Printf("\nPlayerEntityID doesn't exist for playerID: %s\n\n", playerID)
-		return fmt.Errorf("\nPlayerEntityID doesn't exist for playerID: %s\n\n", playerID)
+		return ErrPlayerNotInSession
 	}
 
 	playerEntity, ok := s.EntityManager.GetEntity(playerEntityID)
@@ -330,134 +940,112 @@ func (s *Session) handleMove(playerID uuid.UUID, vx, vy float64) error {
 	component := playerVelocityComponent.(*components.VelocityComponent)
 
 	// update velocity values
+	s.motionMu.Lock()
 	component.VX = vx
 	component.VY = vy
+	s.motionMu.Unlock()
 
 	return nil
 }
 
 /**
-* handles player interacting with x object with target entity id.
+* handles player interacting with x object with target entity id, using the
+* target's default verb.
 **/
 func (s *Session) handleInteract(playerID uuid.UUID, targetEntityID uuid.UUID) error {
+	_, err := s.handleInteractVerb(playerID, targetEntityID, "")
+	return err
+}
+
+/**
+* handles player interacting with the target using a specific verb. Rules
+* and effects live in the interaction system and its registered handlers.
+**/
+func (s *Session) handleInteractVerb(playerID uuid.UUID, targetEntityID uuid.UUID, verb components.InteractionVerb) (map[string]interface{}, error) {
 	targetEntity, hasEntity := s.EntityManager.GetEntity(targetEntityID)
 
 	if !hasEntity {
 		fmt.Printf("Error when attempting to retrieve target entity with entityID %s\n", targetEntityID)
-		return fmt.Errorf("Error when attempting to retrieve target entity with entityID %s", targetEntityID)
+		return nil, ErrEntityNotFound
 	}
 
-	// check container cache first before wasting resources on execution
 	s.mu.RLock()
-	_, exists := s.containerInteractedCache[targetEntityID]
-	if exists {
-		fmt.Printf("container targeted entityID %s was still cached and not available to be interacted.\n", targetEntityID)
-		return fmt.Errorf("container targeted entityID %s was still cached and not available to be interacted.\n", targetEntityID)
-	}
-	s.mu.RUnlock()
-
-	// get that entity's type and decide on the effect
-	_, isDoorEntity := targetEntity.GetComponent(ecs.ComponentTypeDoor)
-	_, isContainerEntity := targetEntity.GetComponent(ecs.ComponentTypeContainer)
-
-	if !isDoorEntity && !isContainerEntity {
-		fmt.Printf("entity type did not match any interactable entity.\n")
-		return fmt.Errorf("entity type did not match any interactable entity.\n")
-	}
-
-	// --- player entity ---
-
-	// establish player's position
-	s.mu.RLock()
-	playerEntityID := s.playerEntities[playerID]
-	s.mu.RUnlock()
-
-	s.mu.RLock()
-	// exit early if cached
-	_, exists = s.playerInteractedCache[playerEntityID]
+	playerEntityID, ok := s.playerEntities[playerID]
 	s.mu.RUnlock()
 
-	if exists {
-		fmt.Printf("player interacted too soon with with playerEntityID %s\n", playerEntityID)
-		return fmt.Errorf("player interacted too soon with with playerEntityID %s\n", playerEntityID)
+	if !ok {
+		return nil, ErrPlayerNotInSession
 	}
 
 	playerEntity, hasPlayerEntity := s.EntityManager.GetEntity(playerEntityID)
 
 	if !hasPlayerEntity {
 		fmt.Printf("Error when attempting to retrieve target player entity with entityID %s\n", playerEntityID)
-
-		return fmt.Errorf("Error when attempting to retrieve target player entity with entityID %s\n", targetEntityID)
+		return nil, fmt.Errorf("Error when attempting to retrieve target player entity with entityID %s\n", playerEntityID)
 	}
 
-	playerTransformComponent, hasTransform := playerEntity.GetComponent(ecs.ComponentTypeTransform)
+	result, err := s.interactionSystem.Interact(playerEntity, targetEntity, verb, s.EntityManager.GetAllEntities())
 
-	if !hasTransform {
-		fmt.Printf("Error when attempting to retrieve player entity transform component with entityID %s\n", playerEntityID)
-		return fmt.Errorf("Error when attempting to retrieve player entity transform component with entityID %s", playerEntityID)
+	if err != nil {
+		fmt.Printf("Interaction from player %s with entity %s failed: %s\n", playerID, targetEntityID, err)
+		return nil, err
 	}
 
-	playerTransform := playerTransformComponent.(*components.TransformComponent)
+	return result, nil
+}
 
-	// --- door entity ---
+/**
+* relays a chat message from a player. Team chat only reaches players the
+* sender is allied with.
+**/
+func (s *Session) handleChat(playerID uuid.UUID, channel string, message string) error {
+	s.mu.RLock()
+	senderEntityID, ok := s.playerEntities[playerID]
+	recipients := make(map[uuid.UUID]uuid.UUID, len(s.playerEntities))
+	for id, entityID := range s.playerEntities {
+		if !s.bots[id] {
+			recipients[id] = entityID
+		}
+	}
+	s.mu.RUnlock()
 
-	if isDoorEntity {
-		// get location
-		doorTransformComponent, hasTransform := targetEntity.GetComponent(ecs.ComponentTypeTransform)
+	if !ok {
+		return ErrPlayerNotInSession
+	}
 
-		if !hasTransform {
-			fmt.Printf("Error when attempting to retrieve door entity transform component with entityID %s\n", targetEntityID)
-			return fmt.Errorf("Error when attempting to retrieve door entity transform component with entityID %s", targetEntityID)
-		}
+	senderEntity, ok := s.EntityManager.GetEntity(senderEntityID)
+	if !ok {
+		return fmt.Errorf("player entity %s does not exist", senderEntityID)
+	}
 
-		doorTransform := doorTransformComponent.(*components.TransformComponent)
-		// validate is within distance from player
-		isWithinDistance := s.calcWithinDistance(playerTransform.X, playerTransform.Y, doorTransform.X, doorTransform.Y)
+	username := ""
+	if playerComp, hasPlayer := senderEntity.GetComponent(ecs.ComponentTypePlayer); hasPlayer {
+		username = playerComp.(*components.PlayerComponent).Username
+	}
 
-		if !isWithinDistance {
-			// TODO: add return message to client
-			fmt.Printf("Error when attempting to interact with door entity as it was out of range. targetID: %s, playerID: %s. \n", targetEntityID, playerID)
-			return ErrOutOfRange
-		}
+	if channel != constants.ChatChannelTeam {
+		channel = constants.ChatChannelAll
+	}
 
-		// trigger doors swap in openable state via its OpenableComponent
-		doorOpenableComponent, hasOpenable := targetEntity.GetComponent(ecs.ComponentTypeOpenable)
+	chatMessage := types.Message{
+		Action: string(constants.ActionChat),
+		Payload: map[string]interface{}{
+			"player_id": playerID.String(),
+			"username":  username,
+			"channel":   channel,
+			"message":   message,
+		},
+	}
 
-		if !hasOpenable {
-			fmt.Printf("Error when attempting to retrieve door entity openable component with entityID %s\n", targetEntityID)
-			return fmt.Errorf("Error when attempting to retrieve door entity openable component with entityID %s", targetEntityID)
+	for recipientID, recipientEntityID := range recipients {
+		if channel == constants.ChatChannelTeam {
+			recipientEntity, ok := s.EntityManager.GetEntity(recipientEntityID)
+			if !ok || s.factions.Relation(senderEntity, recipientEntity) != systems.RelationAlly {
+				continue
+			}
 		}
 
-		doorOpenable := doorOpenableComponent.(*components.OpenableComponent)
-
-		// update state
-		doorOpenable.IsOpen = !doorOpenable.IsOpen
-
-		// add door to interacted to cache
-		s.mu.Lock()
-		s.containerInteractedCache[targetEntityID] = true
-		s.mu.Unlock()
-
-		// release cache in 100 milliseconds
-		go func() {
-			time.Sleep(time.Millisecond * 100)
-			s.mu.Lock()
-			delete(s.containerInteractedCache, targetEntityID)
-			s.mu.Unlock()
-		}()
-
-		// add player to interacted cache
-		s.mu.Lock()
-		s.playerInteractedCache[playerEntityID] = true
-		s.mu.Unlock()
-
-		// remove them from cache after a short while
-		go func() {
-			time.Sleep(time.Millisecond * 100)
-			s.mu.Lock()
-			delete(s.playerInteractedCache, playerEntityID)
-			s.mu.Unlock()
-		}()
+		s.sender.SendToPlayer(recipientID, chatMessage)
 	}
 
 	return nil
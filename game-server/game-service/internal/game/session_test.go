@@ -6,10 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/serializer"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -207,3 +209,373 @@ func TestHandleInteract(t *testing.T) {
 		// check its opposite
 	}
 }
+
+// test players are balanced across teams when the mode has more than one
+func TestAddPlayerBalancesTeams(t *testing.T) {
+	sender := createMockSender()
+	stateSerializer := serializer.NewStateSerializer()
+	session := NewSessionWithMode(sender, stateSerializer, ModeTeamDeathmatch)
+	defer session.Shutdown()
+
+	player1ID := uuid.New()
+	player2ID := uuid.New()
+	player3ID := uuid.New()
+
+	session.AddPlayer(player1ID, "Player1")
+	session.AddPlayer(player2ID, "Player2")
+	session.AddPlayerToTeam(player3ID, "Player3", 2)
+
+	assert.Equal(t, 1, session.GetPlayerTeam(player1ID))
+	assert.Equal(t, 2, session.GetPlayerTeam(player2ID))
+	assert.Equal(t, 2, session.GetPlayerTeam(player3ID))
+
+	// friendly fire is off in team deathmatch
+	entity1, _ := session.EntityManager.GetEntity(session.playerEntities[player1ID])
+	entity2, _ := session.EntityManager.GetEntity(session.playerEntities[player2ID])
+	entity3, _ := session.EntityManager.GetEntity(session.playerEntities[player3ID])
+
+	assert.True(t, session.combatSystem.CanAttack(entity1, entity2))
+	assert.False(t, session.combatSystem.CanAttack(entity2, entity3))
+}
+
+type lockedDoorTable []struct {
+	name          string
+	keys          []*components.InventoryItem
+	consumeKey    bool
+	relockOnClose bool
+	expectedErr   error
+}
+
+func TestHandleInteractLockedDoor(t *testing.T) {
+	tableTests := lockedDoorTable{
+		{
+			name:        "no key",
+			keys:        nil,
+			expectedErr: ErrLocked,
+		},
+		{
+			name:        "wrong key",
+			keys:        []*components.InventoryItem{components.NewKeyItem("Cellar Key", "cellar")},
+			expectedErr: ErrWrongKey,
+		},
+		{
+			name:        "matching key",
+			keys:        []*components.InventoryItem{components.NewKeyItem("Vault Key", "vault")},
+			expectedErr: nil,
+		},
+		{
+			name:        "matching key consumed",
+			keys:        []*components.InventoryItem{components.NewKeyItem("Vault Key", "vault")},
+			consumeKey:  true,
+			expectedErr: nil,
+		},
+	}
+
+	for _, tableTest := range tableTests {
+		session := NewSession(createMockSender(), serializer.NewStateSerializer())
+
+		playerID := uuid.New()
+		playerEntityID := session.AddPlayer(playerID, "Player1")
+
+		for _, key := range tableTest.keys {
+			require.Nil(t, session.GiveItem(playerID, key))
+		}
+
+		doorID := session.AddDoorWithConfig(DoorConfig{
+			X: 0.1,
+			Y: 0.1,
+			Lock: LockConfig{
+				LockID:        "vault",
+				ConsumeKey:    tableTest.consumeKey,
+				RelockOnClose: tableTest.relockOnClose,
+			},
+		})
+
+		err := session.handleInteract(playerID, doorID)
+		assert.ErrorIs(t, err, tableTest.expectedErr, tableTest.name)
+
+		door, _ := session.EntityManager.GetEntity(doorID)
+		openable, _ := door.GetComponent(ecs.ComponentTypeOpenable)
+		assert.Equal(t, tableTest.expectedErr == nil, openable.(*components.OpenableComponent).IsOpen, tableTest.name)
+
+		if tableTest.consumeKey {
+			playerEntity, _ := session.EntityManager.GetEntity(playerEntityID)
+			inventoryComp, _ := playerEntity.GetComponent(ecs.ComponentTypeInventory)
+			for _, item := range inventoryComp.(*components.InventoryComponent).Items {
+				assert.NotEqual(t, components.ItemTypeKey, item.Type, "key should have been consumed")
+			}
+		}
+
+		session.Shutdown()
+	}
+}
+
+// a door that relocks on close needs the key again once it's shut
+func TestHandleInteractRelockOnClose(t *testing.T) {
+	session := NewSession(createMockSender(), serializer.NewStateSerializer())
+	defer session.Shutdown()
+
+	playerID := uuid.New()
+	session.AddPlayer(playerID, "Player1")
+
+	doorID := session.AddDoorWithConfig(DoorConfig{
+		X:    0.1,
+		Y:    0.1,
+		Lock: LockConfig{LockID: "vault", RelockOnClose: true},
+	})
+	door, _ := session.EntityManager.GetEntity(doorID)
+	lockComp, _ := door.GetComponent(ecs.ComponentTypeLock)
+	lock := lockComp.(*components.LockComponent)
+
+	require.Nil(t, session.GiveItem(playerID, components.NewKeyItem("Vault Key", "vault")))
+
+	// open
+	require.Nil(t, session.handleInteract(playerID, doorID))
+	assert.False(t, lock.IsLocked)
+
+	time.Sleep(time.Millisecond * 150) // delay to account for rate limiting
+
+	// close
+	require.Nil(t, session.handleInteract(playerID, doorID))
+	assert.True(t, lock.IsLocked)
+}
+
+// looting moves every item in the container into the player's inventory
+func TestHandleInteractLootContainer(t *testing.T) {
+	session := NewSession(createMockSender(), serializer.NewStateSerializer())
+	defer session.Shutdown()
+
+	playerID := uuid.New()
+	playerEntityID := session.AddPlayer(playerID, "Player1")
+
+	containerID := session.AddContainer(ContainerConfig{
+		X: 0.2,
+		Y: 0.2,
+		Items: []*components.InventoryItem{
+			components.NewInventoryItem(components.ItemTypeWeapon, "Rusty Sword", 1),
+		},
+	})
+
+	result, err := session.handleInteractVerb(playerID, containerID, components.VerbLoot)
+	require.Nil(t, err)
+	assert.Len(t, result["items"], 1)
+
+	playerEntity, _ := session.EntityManager.GetEntity(playerEntityID)
+	inventoryComp, _ := playerEntity.GetComponent(ecs.ComponentTypeInventory)
+	inventory := inventoryComp.(*components.InventoryComponent)
+	assert.Equal(t, "Rusty Sword", inventory.Items[len(inventory.Items)-1].Name)
+
+	time.Sleep(time.Millisecond * 150) // delay to account for rate limiting
+
+	_, err = session.handleInteractVerb(playerID, containerID, components.VerbLoot)
+	assert.ErrorIs(t, err, ErrNothingToLoot)
+
+	// doors can't be looted
+	doorID := session.AddDoor(0.1, 0.1)
+	_, err = session.handleInteractVerb(playerID, doorID, components.VerbLoot)
+	assert.ErrorIs(t, err, systems.ErrUnsupportedVerb)
+}
+
+// TestAddPlayerWithCharacterRoundTrip tests that a saved character is loaded into the session and captured back out unchanged
+func TestAddPlayerWithCharacterRoundTrip(t *testing.T) {
+	session := NewSession(createMockSender(), serializer.NewStateSerializer())
+
+	userID := uuid.New()
+	character := &types.CharacterState{
+		MemberID:      userID,
+		Name:          "veteran",
+		Level:         4,
+		Experience:    35,
+		Strength:      16,
+		Agility:       12,
+		Intelligence:  11,
+		UnspentPoints: 2,
+		CurrentHealth: 80,
+		MaxHealth:     120,
+		Inventory: []types.CharacterItem{
+			{ID: uuid.New(), Type: "key", Name: "Vault Key", Quantity: 1, LockID: "vault"},
+		},
+		Equipment: map[string]types.CharacterItem{
+			"weapon": {ID: uuid.New(), Type: "weapon", Name: "Rusty Sword", Quantity: 1},
+		},
+	}
+
+	session.AddPlayerWithCharacter(userID, "veteran", 0, character)
+
+	states := session.CharacterStates()
+	require.Len(t, states, 1)
+
+	assert.Equal(t, character, states[0])
+}
+
+// TestDepartedPlayersKeepTheirCharacter tests players who leave early are still saved at the end
+func TestDepartedPlayersKeepTheirCharacter(t *testing.T) {
+	session := NewSession(createMockSender(), serializer.NewStateSerializer())
+	defer session.Shutdown()
+
+	leaverID, stayerID := uuid.New(), uuid.New()
+	leaver := &types.CharacterState{
+		MemberID:      leaverID,
+		Name:          "leaver",
+		Level:         3,
+		Experience:    20,
+		Strength:      12,
+		Agility:       10,
+		Intelligence:  10,
+		CurrentHealth: 60,
+		MaxHealth:     100,
+		Inventory:     []types.CharacterItem{{ID: uuid.New(), Type: "potion", Name: "Health Potion", Quantity: 2}},
+		Equipment:     map[string]types.CharacterItem{},
+	}
+
+	session.AddPlayerWithCharacter(leaverID, "leaver", 0, leaver)
+	session.AddPlayer(stayerID, "stayer")
+	session.AddBot(uuid.New(), "bot", components.NoTeam, systems.DefaultBotDifficulty)
+
+	session.RemovePlayer(leaverID)
+
+	states := session.CharacterStates()
+	require.Len(t, states, 2)
+
+	saved := make(map[uuid.UUID]*types.CharacterState)
+	for _, state := range states {
+		saved[state.MemberID] = state
+	}
+	assert.Equal(t, leaver, saved[leaverID])
+	assert.Contains(t, saved, stayerID)
+}
+
+// TestAttackActionFiresProjectile tests attack messages fire the projectile and report bad ones
+func TestAttackActionFiresProjectile(t *testing.T) {
+	session, recorder := newSpectatedSession(ModeCoop)
+	defer session.Shutdown()
+
+	playerID := uuid.New()
+	session.AddPlayer(playerID, "player")
+
+	attack := func(projectile string) {
+		require.NoError(t, session.Deliver(types.ClientPackage{Message: types.Message{
+			Action: string(constants.ActionAttack),
+			Payload: map[string]interface{}{
+				"session_id": session.ID.String(),
+				"player_id":  playerID.String(),
+				"projectile": projectile,
+				"dx":         1.0,
+				"dy":         0.0,
+			},
+		}}))
+	}
+
+	attack("Fireball")
+	assert.Eventually(t, func() bool {
+		for _, entity := range session.EntityManager.GetAllEntities() {
+			if _, isProjectile := entity.GetComponent(ecs.ComponentTypeProjectile); isProjectile {
+				return true
+			}
+		}
+		return false
+	}, 2*time.Second, 10*time.Millisecond)
+
+	attack("Snowball")
+	assert.Eventually(t, func() bool {
+		failures := recorder.actions(playerID, constants.ActionAttack)
+		return len(failures) == 1 && failures[0].Payload["success"] == false
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// TestMalformedMessagesAreRejected tests bad payloads get field errors and the session keeps going
+func TestMalformedMessagesAreRejected(t *testing.T) {
+	session, recorder := newSpectatedSession(ModeCoop)
+	defer session.Shutdown()
+
+	playerID := uuid.New()
+	session.AddPlayer(playerID, "player")
+
+	move := func(vx interface{}) {
+		require.NoError(t, session.Deliver(types.ClientPackage{Message: types.Message{
+			Action: string(constants.ActionMove),
+			Payload: map[string]interface{}{
+				"session_id": session.ID.String(),
+				"player_id":  playerID.String(),
+				"vx":         vx,
+				"vy":         0.0,
+			},
+		}}))
+	}
+
+	// used to panic the session on the type assertion
+	move("fast")
+	assert.Eventually(t, func() bool {
+		return len(recorder.actions(playerID, constants.ActionMove)) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	rejected := recorder.actions(playerID, constants.ActionMove)[0]
+	assert.Equal(t, false, rejected.Payload["success"])
+	assert.Equal(t, string(constants.ErrorInvalidPayload), rejected.Payload["reason"])
+	assert.Equal(t, []types.FieldError{{Field: "vx", Message: "must be a number"}}, rejected.Payload["fields"])
+
+	// still handling messages afterwards
+	move(1.0)
+	assert.Eventually(t, func() bool {
+		direction, _ := session.PlayerDirection(playerID)
+		return direction.VX == 1.0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// TestActionsAreAcknowledged tests game actions reply with the request id they were sent with
+func TestActionsAreAcknowledged(t *testing.T) {
+	session, recorder := newSpectatedSession(ModeCoop)
+	defer session.Shutdown()
+
+	playerID := uuid.New()
+	session.AddPlayer(playerID, "player")
+
+	deliver := func(action constants.Action, requestID string, payload map[string]interface{}) {
+		payload["session_id"] = session.ID.String()
+		payload["player_id"] = playerID.String()
+		require.NoError(t, session.Deliver(types.ClientPackage{Message: types.Message{
+			Action:    string(action),
+			RequestID: requestID,
+			Payload:   payload,
+		}}))
+	}
+	replies := func(action constants.Action, count int) []types.Message {
+		require.Eventually(t, func() bool {
+			return len(recorder.actions(playerID, action)) >= count
+		}, 2*time.Second, 10*time.Millisecond)
+		return recorder.actions(playerID, action)
+	}
+
+	// moves without a request id aren't acknowledged, nobody is waiting on them
+	deliver(constants.ActionMove, "", map[string]interface{}{"vx": 1.0, "vy": 0.0})
+	deliver(constants.ActionMove, "move-1", map[string]interface{}{"vx": 0.0, "vy": 1.0})
+
+	moves := replies(constants.ActionMove, 1)
+	require.Len(t, moves, 1)
+	assert.Equal(t, "move-1", moves[0].RequestID)
+	assert.Equal(t, true, moves[0].Payload["success"])
+
+	// game errors come back with their own reason
+	doorID := session.AddDoor(100, 100)
+	deliver(constants.ActionInteract, "interact-1", map[string]interface{}{"entity_id": doorID.String()})
+
+	interaction := replies(constants.ActionInteract, 1)[0]
+	assert.Equal(t, "interact-1", interaction.RequestID)
+	assert.Equal(t, false, interaction.Payload["success"])
+	assert.Equal(t, string(constants.ErrorOutOfRange), interaction.Payload["reason"])
+	assert.Equal(t, doorID.String(), interaction.Payload["entity_id"])
+
+	missingID := uuid.New()
+	deliver(constants.ActionInteract, "interact-2", map[string]interface{}{"entity_id": missingID.String()})
+
+	missing := replies(constants.ActionInteract, 2)[1]
+	assert.Equal(t, "interact-2", missing.RequestID)
+	assert.Equal(t, string(constants.ErrorEntityNotFound), missing.Payload["reason"])
+
+	deliver(constants.ActionAllocateStat, "stat-1", map[string]interface{}{"stat": "strength", "points": 1})
+
+	allocation := replies(constants.ActionAllocateStat, 1)[0]
+	assert.Equal(t, "stat-1", allocation.RequestID)
+	assert.Equal(t, false, allocation.Payload["success"])
+	assert.Equal(t, string(constants.ErrorNotEnoughPoints), allocation.Payload["reason"])
+}
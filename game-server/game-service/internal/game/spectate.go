@@ -0,0 +1,168 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Spectators
+*
+* Users can watch a session without an entity in it. They only get the state
+* stream, held back by the mode's spectator delay, so nothing they see is
+* newer than what the delay allows. Each spectator can have their camera
+* follow one of the players.
+**/
+
+type spectatorFrame struct {
+	at    time.Time
+	state *types.ClientGameState
+}
+
+func (s *Session) AddSpectator(userID, followID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, isPlayer := s.playerEntities[userID]; isPlayer {
+		return ErrAlreadyInSession
+	}
+
+	if _, watching := s.spectators[userID]; !watching && len(s.spectators) >= s.mode.MaxSpectators {
+		return ErrSpectatorsFull
+	}
+
+	if followID != uuid.Nil {
+		if _, isPlayer := s.playerEntities[followID]; !isPlayer {
+			return ErrFollowTargetMissing
+		}
+	}
+
+	s.spectators[userID] = followID
+	return nil
+}
+
+func (s *Session) RemoveSpectator(userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, watching := s.spectators[userID]
+	delete(s.spectators, userID)
+
+	if len(s.spectators) == 0 {
+		s.spectatorFrames = nil
+	}
+
+	return watching
+}
+
+/**
+* points the spectator's camera at a player, uuid.Nil frees the camera.
+**/
+func (s *Session) FollowPlayer(spectatorID, targetID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, watching := s.spectators[spectatorID]; !watching {
+		return ErrNotSpectating
+	}
+
+	if targetID != uuid.Nil {
+		if _, isPlayer := s.playerEntities[targetID]; !isPlayer {
+			return ErrFollowTargetMissing
+		}
+	}
+
+	s.spectators[spectatorID] = targetID
+	return nil
+}
+
+func (s *Session) SpectatorCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.spectators)
+}
+
+/**
+* records the current state and sends spectators the newest state that has
+* waited out the delay.
+**/
+func (s *Session) streamToSpectators(now time.Time) {
+	if s.SpectatorCount() == 0 {
+		return
+	}
+
+	state, err := s.Snapshot()
+	if err != nil {
+		fmt.Printf("Failed to snapshot session %s for spectators: %v\n", s.ID, err)
+		return
+	}
+
+	s.mu.Lock()
+	s.spectatorFrames = append(s.spectatorFrames, spectatorFrame{at: now, state: state})
+
+	// the newest frame old enough to show, older ones are never needed again
+	show := -1
+	for i, frame := range s.spectatorFrames {
+		if now.Sub(frame.at) >= s.mode.SpectatorDelay {
+			show = i
+		}
+	}
+
+	if show == -1 {
+		s.mu.Unlock()
+		return
+	}
+
+	frame := s.spectatorFrames[show]
+	s.spectatorFrames = s.spectatorFrames[show+1:]
+
+	spectators := make(map[uuid.UUID]uuid.UUID, len(s.spectators))
+	for spectatorID, followID := range s.spectators {
+		spectators[spectatorID] = followID
+	}
+	s.mu.Unlock()
+
+	for spectatorID, followID := range spectators {
+		payload := map[string]interface{}{
+			"session_id": s.ID.String(),
+			"delay_ms":   s.mode.SpectatorDelay.Milliseconds(),
+			"state":      frame.state,
+		}
+
+		if camera := cameraHint(frame.state, followID); camera != nil {
+			payload["camera"] = camera
+		}
+
+		s.sender.SendToPlayer(spectatorID, types.Message{
+			Action:  string(constants.ActionSpectateState),
+			Payload: payload,
+		})
+	}
+}
+
+/**
+* where the camera should be to follow the player in the given state, nil
+* when nobody is followed or they're not in it.
+**/
+func cameraHint(state *types.ClientGameState, followID uuid.UUID) map[string]interface{} {
+	if followID == uuid.Nil {
+		return nil
+	}
+
+	for _, player := range state.Players {
+		if player.ID == followID && player.Position != nil {
+			return map[string]interface{}{
+				"follow_id": followID.String(),
+				"x":         player.Position.X,
+				"y":         player.Position.Y,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/serializer"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing users watching a session they don't play in.
+**/
+
+// recordingSender keeps the messages sent to each player
+type recordingSender struct {
+	mu   sync.Mutex
+	sent map[uuid.UUID][]types.Message
+}
+
+func (r *recordingSender) PushMessageToChannelQueue(playerID uuid.UUID, msg types.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sent[playerID] = append(r.sent[playerID], msg)
+	return nil
+}
+
+func (r *recordingSender) actions(playerID uuid.UUID, action constants.Action) []types.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found []types.Message
+	for _, msg := range r.sent[playerID] {
+		if msg.Action == string(action) {
+			found = append(found, msg)
+		}
+	}
+	return found
+}
+
+func newSpectatedSession(mode GameMode) (*Session, *recordingSender) {
+	recorder := &recordingSender{sent: make(map[uuid.UUID][]types.Message)}
+	session := NewSessionWithMode(messaging.NewMessageSender(recorder), serializer.NewStateSerializer(), mode)
+	return session, recorder
+}
+
+// TestSpectatorStreamIsDelayed tests spectators only see states older than the mode's delay
+func TestSpectatorStreamIsDelayed(t *testing.T) {
+	mode := ModeCoop
+	mode.SpectatorDelay = 10 * time.Second
+	session, recorder := newSpectatedSession(mode)
+	defer session.Shutdown()
+
+	playerID := uuid.New()
+	session.AddPlayer(playerID, "player")
+
+	spectatorID := uuid.New()
+	require.NoError(t, session.AddSpectator(spectatorID, playerID))
+
+	start := time.Now()
+	session.streamToSpectators(start)
+	session.streamToSpectators(start.Add(5 * time.Second))
+	assert.Empty(t, recorder.actions(spectatorID, constants.ActionSpectateState))
+
+	session.streamToSpectators(start.Add(10 * time.Second))
+	states := recorder.actions(spectatorID, constants.ActionSpectateState)
+	require.Len(t, states, 1)
+
+	assert.Equal(t, session.ID.String(), states[0].Payload["session_id"])
+	assert.Equal(t, int64(10000), states[0].Payload["delay_ms"])
+
+	camera, ok := states[0].Payload["camera"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, playerID.String(), camera["follow_id"])
+
+	// players never get the spectator stream
+	assert.Empty(t, recorder.actions(playerID, constants.ActionSpectateState))
+}
+
+// TestSpectatorLimits tests the spectator cap, players spectating and follow targets
+func TestSpectatorLimits(t *testing.T) {
+	mode := ModeCoop
+	mode.MaxSpectators = 1
+	session, _ := newSpectatedSession(mode)
+	defer session.Shutdown()
+
+	playerID := uuid.New()
+	session.AddPlayer(playerID, "player")
+
+	assert.ErrorIs(t, session.AddSpectator(playerID, uuid.Nil), ErrAlreadyInSession)
+	assert.ErrorIs(t, session.AddSpectator(uuid.New(), uuid.New()), ErrFollowTargetMissing)
+
+	spectatorID := uuid.New()
+	require.NoError(t, session.AddSpectator(spectatorID, uuid.Nil))
+	assert.ErrorIs(t, session.AddSpectator(uuid.New(), uuid.Nil), ErrSpectatorsFull)
+
+	require.NoError(t, session.FollowPlayer(spectatorID, playerID))
+	assert.ErrorIs(t, session.FollowPlayer(uuid.New(), playerID), ErrNotSpectating)
+
+	assert.True(t, session.RemoveSpectator(spectatorID))
+	assert.Equal(t, 0, session.SpectatorCount())
+}
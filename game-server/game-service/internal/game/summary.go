@@ -0,0 +1,63 @@
+package game
+
+import (
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+)
+
+/**
+* Lightweight view of a player inside a running session, used outside the
+* game loop e.g. by the rooms API.
+**/
+type PlayerSummary struct {
+	UserID    uuid.UUID
+	EntityID  uuid.UUID
+	X         float64
+	Y         float64
+	VelocityX float64
+	VelocityY float64
+	Health    int
+	IsAlive   bool
+}
+
+func (s *Session) PlayerSummary(userID uuid.UUID) (*PlayerSummary, bool) {
+	s.mu.RLock()
+	entityID, exists := s.playerEntities[userID]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+
+	entity, exists := s.EntityManager.GetEntity(entityID)
+	if !exists {
+		return nil, false
+	}
+
+	summary := &PlayerSummary{
+		UserID:   userID,
+		EntityID: entityID,
+		IsAlive:  true,
+	}
+
+	if transformComp, hasTransform := entity.GetComponent(ecs.ComponentTypeTransform); hasTransform {
+		transform := transformComp.(*components.TransformComponent)
+		summary.X = transform.X
+		summary.Y = transform.Y
+	}
+
+	if velocityComp, hasVelocity := entity.GetComponent(ecs.ComponentTypeVelocity); hasVelocity {
+		velocity := velocityComp.(*components.VelocityComponent)
+		summary.VelocityX = velocity.VX
+		summary.VelocityY = velocity.VY
+	}
+
+	if healthComp, hasHealth := entity.GetComponent(ecs.ComponentTypeHealth); hasHealth {
+		health := healthComp.(*components.HealthComponent)
+		summary.Health = health.CurrentHealth
+		summary.IsAlive = health.CurrentHealth > 0
+	}
+
+	return summary, true
+}
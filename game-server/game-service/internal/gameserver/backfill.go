@@ -0,0 +1,132 @@
+package gameserver
+
+import (
+	"fmt"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Backfill
+*
+* Sessions from queues whose mode allows it keep their open slots registered
+* with matchmaking, which fills them from the queue before starting new
+* matches. Backfilled players still answer a ready check, then join the
+* running game through the mode's join hook.
+**/
+
+type backfillSession struct {
+	queueID string
+	// players the session holds, the queue's max players
+	capacity int
+}
+
+/**
+* registers a new session with the queue it was matched in.
+**/
+func (s *Server) openBackfill(session *game.Session, queueID string) {
+	config, exists := s.matchmaker.QueueConfig(queueID)
+	if !exists {
+		return
+	}
+
+	s.mu.Lock()
+	s.backfills[session.ID] = backfillSession{queueID: queueID, capacity: config.MaxPlayers}
+	s.mu.Unlock()
+
+	s.syncBackfill(session.ID)
+}
+
+/**
+* tells matchmaking how many slots the session has open on each team, a
+* full session takes nobody until someone leaves.
+**/
+func (s *Server) syncBackfill(sessionID uuid.UUID) {
+	s.mu.RLock()
+	info, backfilled := s.backfills[sessionID]
+	session, exists := s.sessions[sessionID]
+	s.mu.RUnlock()
+
+	if !backfilled || !exists {
+		return
+	}
+
+	slot := systems.BackfillSlot{SessionID: sessionID, Open: session.OpenSlots(info.capacity)}
+	if err := s.matchmaker.OpenBackfill(info.queueID, slot); err != nil {
+		fmt.Printf("Failed to open backfill for session %s: %v\n", sessionID, err)
+	}
+}
+
+/**
+* stops filling a session that ended.
+**/
+func (s *Server) closeBackfill(sessionID uuid.UUID) {
+	s.mu.Lock()
+	info, backfilled := s.backfills[sessionID]
+	delete(s.backfills, sessionID)
+	s.mu.Unlock()
+
+	if backfilled {
+		s.matchmaker.CloseBackfill(info.queueID, sessionID)
+	}
+}
+
+/**
+* puts the players of an accepted backfill into their session, sending
+* them a snapshot of the game they're joining. When the game ended or filled
+* up in the meantime they go back to the front of the queue.
+**/
+func (s *Server) joinBackfill(check *ReadyCheck) {
+	s.mu.RLock()
+	session, exists := s.sessions[check.Match.SessionID]
+	info, backfilled := s.backfills[check.Match.SessionID]
+	s.mu.RUnlock()
+
+	if !exists || !backfilled {
+		s.failReadyCheck(check, "session_ended")
+		return
+	}
+
+	needed := make(map[int]int)
+	for _, player := range check.Match.Players {
+		needed[player.Team]++
+	}
+
+	open := session.OpenSlots(info.capacity)
+	for team, players := range needed {
+		if open[team] < players {
+			s.failReadyCheck(check, "session_full")
+			return
+		}
+	}
+
+	characters := s.loadCharacters(check.Match.Players)
+
+	for _, player := range check.Match.Players {
+		if _, err := session.JoinInProgress(player.ID, player.Username, player.Team, characters[player.ID]); err != nil {
+			fmt.Printf("Failed to backfill player %s into session %s: %v\n", player.ID, session.ID, err)
+		}
+	}
+
+	snapshot, err := session.Snapshot()
+	if err != nil {
+		fmt.Printf("Failed to snapshot session %s for backfilled players: %v\n", session.ID, err)
+	}
+
+	messaging.NewMessageSender(s).BroadcastToPlayerList(check.Match.Players, types.Message{
+		Action: string(constants.ActionGameFound),
+		Payload: map[string]any{
+			"session_id": session.ID.String(),
+			"queue_id":   check.Match.QueueID,
+			"backfill":   true,
+			"state":      snapshot,
+		},
+	})
+
+	s.syncBackfill(session.ID)
+}
@@ -0,0 +1,91 @@
+package gameserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing queued players filling slots in games already being played.
+**/
+
+// TestBackfillFillsOpenSlots tests a session with room takes queued players before a new match
+func TestBackfillFillsOpenSlots(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	queue, _ := server.matchmaker.(*systems.Matchmaker).Queue("coop_duo")
+
+	// coop duos start with two players and hold four
+	match, channels := newReadyCheckMatch(t, server, 2)
+	check := server.StartReadyCheck(match)
+	for _, player := range match.Players {
+		require.NoError(t, server.RespondToReadyCheck(check.ID, player.ID, true))
+	}
+
+	found := waitForAction(t, channels[0], constants.ActionGameFound)
+	sessionID := uuid.MustParse(found.Payload["session_id"].(string))
+	session, _ := server.GetGameSession(sessionID)
+	defer session.Shutdown()
+
+	slots := queue.Backfills()
+	require.Len(t, slots, 1)
+	assert.Equal(t, map[int]int{1: 2}, slots[0].Open)
+
+	joiner := &types.Player{ID: uuid.New(), Username: "joiner"}
+	joinerCh := registerTestConn(server, &websocket.Conn{}, joiner)
+	_, err := server.AddPlayerToQueue("coop_duo", joiner)
+	require.NoError(t, err)
+
+	// the queue's next pass fills the session
+	prompt := waitForAction(t, joinerCh, constants.ActionReadyCheck)
+	assert.Equal(t, true, prompt.Payload["backfill"])
+	assert.Equal(t, sessionID.String(), prompt.Payload["session_id"])
+
+	checkID := uuid.MustParse(prompt.Payload["check_id"].(string))
+	require.NoError(t, server.RespondToReadyCheck(checkID, joiner.ID, true))
+
+	joined := waitForAction(t, joinerCh, constants.ActionGameFound)
+	assert.Equal(t, sessionID.String(), joined.Payload["session_id"])
+	assert.Equal(t, true, joined.Payload["backfill"])
+	assert.NotNil(t, joined.Payload["state"])
+
+	assert.True(t, session.HasPlayer(joiner.ID))
+	waitForAction(t, channels[0], constants.ActionPlayerJoined)
+	assert.Equal(t, map[int]int{1: 1}, queue.Backfills()[0].Open)
+
+	// the slot closes with the game
+	server.closeBackfill(sessionID)
+	assert.Empty(t, queue.Backfills())
+}
+
+// TestBackfillSessionEnded tests players backfilling a game that ended go back to the queue
+func TestBackfillSessionEnded(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "late"}
+	ch := registerTestConn(server, &websocket.Conn{}, player)
+
+	check := server.StartReadyCheck(systems.Match{
+		QueueID:   "coop_duo",
+		GameMode:  "coop",
+		Players:   []*types.Player{player},
+		JoinedAt:  map[uuid.UUID]time.Time{player.ID: time.Now()},
+		SessionID: uuid.New(),
+	})
+	require.NoError(t, server.RespondToReadyCheck(check.ID, player.ID, true))
+
+	failed := waitForAction(t, ch, constants.ActionReadyCheckFailed)
+	assert.Equal(t, "session_ended", failed.Payload["reason"])
+	assert.Equal(t, true, failed.Payload["requeued"])
+
+	queueID, queued := server.matchmaker.QueueOf(player.ID)
+	assert.True(t, queued)
+	assert.Equal(t, "coop_duo", queueID)
+}
@@ -0,0 +1,39 @@
+package gameserver
+
+import "errors"
+
+var (
+	ErrRoomNotFound      = errors.New("Room does not exist.")
+	ErrRoomFull          = errors.New("Room is full.")
+	ErrRoomNotWaiting    = errors.New("Room is no longer accepting players.")
+	ErrRoomNotInProgress = errors.New("Room has no game in progress.")
+	ErrAlreadyInRoom     = errors.New("Player is already in a room.")
+	ErrNotInRoom         = errors.New("Player is not in this room.")
+	ErrUnknownGameMode   = errors.New("Game mode does not exist.")
+	ErrInvalidMaxPlayers = errors.New("Max players is out of range.")
+	ErrNotEnoughPlayers  = errors.New("Not enough players to start the game.")
+	ErrNotHost           = errors.New("Only the host can do this.")
+	ErrKickedFromRoom    = errors.New("You were kicked from this room.")
+	ErrCannotKickSelf    = errors.New("The host can't kick themselves.")
+	ErrPlayersNotReady   = errors.New("Not every player is ready.")
+	ErrInvalidInviteCode = errors.New("Invite code does not match any room.")
+	ErrInvalidRoomName   = errors.New("Room name is required.")
+	ErrUnknownMap        = errors.New("Map does not exist.")
+
+	ErrPartyNotFound      = errors.New("Party does not exist.")
+	ErrAlreadyInParty     = errors.New("Player is already in a party.")
+	ErrNotPartyLeader     = errors.New("Only the party leader can do this.")
+	ErrPartyFull          = errors.New("Party is full.")
+	ErrNoPartyInvite      = errors.New("No pending invite to this party.")
+	ErrInviteSelf         = errors.New("Players can't invite themselves.")
+	ErrNotInParty         = errors.New("Player is not in a party.")
+	ErrPartyMemberOffline = errors.New("Every party member has to be online to queue.")
+
+	ErrSessionNotFound = errors.New("Game session does not exist.")
+	ErrServerFull      = errors.New("Server is running as many games as it can, try again shortly.")
+
+	ErrReadyCheckNotFound = errors.New("Ready check does not exist or already finished.")
+	ErrNotInReadyCheck    = errors.New("Player is not part of this ready check.")
+	ErrInReadyCheck       = errors.New("Player already has a match waiting to be accepted.")
+	ErrAlreadyPlaying     = errors.New("Player is already playing a game.")
+)
@@ -1,11 +1,13 @@
 package gameserver
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	authPb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/auth"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/protocol"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -59,6 +61,12 @@ func (s *Server) HandleWebSocketConnection(c *gin.Context) {
 	// 立即建立 msgChan，確保重連後能收到 server 訊息
 	s.setupClientWriter(conn)
 
+	// players redirected here for a match still have to answer its ready check
+	s.resendReadyCheck(player.ID)
+
+	// players coming back mid game pick up where they left off
+	s.resumeSession(player.ID)
+
 	// handle each connected client's messages concurrently
 	go s.ServeConnectedPlayer(conn)
 }
@@ -73,6 +81,11 @@ func (s *Server) ServeConnectedPlayer(conn *websocket.Conn) {
 		s.cleanUpClient(conn)
 	}()
 
+	// half-open connections stop answering pings and time out
+	s.watchIdle(conn)
+
+	codec := protocol.For(conn)
+
 	for {
 		fmt.Println("Listening for user messages...")
 		_, message, err := conn.ReadMessage()
@@ -100,20 +113,23 @@ func (s *Server) ServeConnectedPlayer(conn *websocket.Conn) {
 			break
 		}
 
+		s.touch(conn)
+
 		fmt.Println("before decoding received message")
 
 		// --- Client Connection Handling ---
 		// Decodes Incoming client message and serves their unique connection its own goroutine
 
-		// decode message to pre-defined json structure "GameMessage"
+		// decode message to pre-defined structure "GameMessage", in the encoding
+		// the connection negotiated
 		var decodedMsg types.Message
 
-		err = json.Unmarshal(message, &decodedMsg)
+		err = codec.Decode(message, &decodedMsg)
 
 		if err != nil {
 			fmt.Println("Error when decoding payload.")
 
-			conn.WriteJSON(types.Message{Action: "Error", Payload: map[string]interface{}{"error": "Your message to server was the incorrect format and could not be decoded as JSON."}})
+			s.PushMessageToConn(conn, types.Message{Action: "Error", Payload: map[string]interface{}{"error": "Your message to server was the incorrect format and could not be decoded."}})
 			continue
 		}
 
@@ -156,22 +172,75 @@ func (s *Server) setupClientWriter(conn *websocket.Conn) {
 		return
 	}
 
+	pingInterval, _ := s.heartbeat()
+
 	// concurrently listen to all incoming messages over the channel to write game actions
-	// back to the client
+	// back to the client, pinging it in between
 	go func() {
-		for msg := range msgChan {
-			err := conn.WriteJSON(msg)
-
-			if err != nil {
-				// TODO: remove connection from channel and close, clean up client
-				// s.cleanUpClient(conn)
-				break
+		pings := time.NewTicker(pingInterval)
+		defer pings.Stop()
+
+		for {
+			select {
+			case msg, ok := <-msgChan:
+				if !ok {
+					return
+				}
+
+				conn.SetWriteDeadline(time.Now().Add(constants.WriteTimeout))
+				if err := protocol.Write(conn, msg); err != nil {
+					// fails the read goroutine, which cleans the client up
+					conn.Close()
+					return
+				}
+
+			case <-pings.C:
+				if err := ping(conn); err != nil {
+					conn.Close()
+					return
+				}
 			}
 		}
 	}()
 
 }
 
+/**
+* writes the response builder's replies through the connection's message
+* channel, in the encoding the client connected with.
+**/
+type queuedWriter struct {
+	server *Server
+	conn   *websocket.Conn
+}
+
+/**
+* the writer for replies to conn, nil without a connection so nothing is
+* sent.
+**/
+func (s *Server) WriterFor(conn *websocket.Conn) types.MessageWriter {
+	if conn == nil {
+		return nil
+	}
+
+	return &queuedWriter{server: s, conn: conn}
+}
+
+// named for types.MessageWriter, the writer goroutine does the encoding
+func (w *queuedWriter) WriteJSON(v interface{}) error {
+	response, ok := v.(types.ServerResponse)
+	if !ok {
+		return fmt.Errorf("can't queue %T as a response", v)
+	}
+
+	return w.server.PushMessageToConn(w.conn, types.Message{
+		Action:    response.Action,
+		RequestID: response.RequestID,
+		Payload:   response.Payload,
+		Response:  &response,
+	})
+}
+
 /**
 * Creates the unique game message channel for a specific connection for writing back
 * from server to client. Only creates if it doesn't already exist.
@@ -211,15 +280,30 @@ func (s *Server) getGameMsgChan(conn *websocket.Conn) (chan types.Message, error
 * Called when connection is closed or errors out.
 **/
 func (s *Server) cleanUpClient(conn *websocket.Conn) {
+	var disconnected *types.Player
+
+	// queues, parties and sessions are told once the lock is released
+	defer func() {
+		if disconnected != nil {
+			// 從 queue 中移除玩家
+			s.matchmaker.RemovePlayer(disconnected)
+			s.leavePartyOnDisconnect(disconnected.ID)
+			s.StopSpectating(disconnected.ID)
+
+			// players mid game get a chance to reconnect
+			s.holdDisconnectedPlayer(disconnected.ID)
+		}
+	}()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// 獲取玩家資訊
 	player, exists := s.connToPlayer[conn]
 	if exists {
+		disconnected = player
 		fmt.Printf("Cleaning up client: %s\n", player.Username)
-		// 從 queue 中移除玩家
-		s.queueSystem.PlayerRemoveQueue(player)
+		delete(s.latencies, player.ID)
 	}
 
 	// 關閉並刪除 msgChan
@@ -234,3 +318,15 @@ func (s *Server) cleanUpClient(conn *websocket.Conn) {
 	// 關閉 WebSocket 連線
 	conn.Close()
 }
+
+/**
+* Lists every matchmaking queue with how many players are waiting in it.
+**/
+func (s *Server) HandleGetQueues(c *gin.Context) {
+	queues := make([]map[string]interface{}, 0)
+	for _, stats := range s.GetQueueStats() {
+		queues = append(queues, QueueStatsPayload(stats))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"statusCode": http.StatusOK, "message": "Successfully retrieved queues.", "result": queues})
+}
@@ -0,0 +1,100 @@
+package gameserver
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+/**
+* Keeps connections alive with websocket pings and closes the ones that stop
+* answering. The pongs coming back measure each player's round trip.
+**/
+
+func (s *Server) SetHeartbeat(pingInterval, idleTimeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pingInterval = pingInterval
+	s.idleTimeout = idleTimeout
+}
+
+func (s *Server) heartbeat() (pingInterval, idleTimeout time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.pingInterval, s.idleTimeout
+}
+
+/**
+* gives the connection until the idle timeout to send something, every
+* message or pong read pushes the deadline back. Reads past it fail, which
+* closes the connection and cleans the player up.
+**/
+func (s *Server) watchIdle(conn *websocket.Conn) {
+	_, idleTimeout := s.heartbeat()
+	conn.SetReadDeadline(time.Now().Add(idleTimeout))
+
+	conn.SetPongHandler(func(appData string) error {
+		conn.SetReadDeadline(time.Now().Add(idleTimeout))
+
+		// pings carry when they were sent
+		if sentAt, err := strconv.ParseInt(appData, 10, 64); err == nil {
+			s.recordLatency(conn, time.Since(time.Unix(0, sentAt)))
+		}
+		return nil
+	})
+}
+
+/**
+* the connection's read goroutine got a message in, it's still alive.
+**/
+func (s *Server) touch(conn *websocket.Conn) {
+	_, idleTimeout := s.heartbeat()
+	conn.SetReadDeadline(time.Now().Add(idleTimeout))
+}
+
+func ping(conn *websocket.Conn) error {
+	sentAt := strconv.FormatInt(time.Now().UnixNano(), 10)
+	return conn.WriteControl(websocket.PingMessage, []byte(sentAt), time.Now().Add(constants.WriteTimeout))
+}
+
+/**
+* smooths the round trip into the player's latency so a single slow pong
+* doesn't swing it, then hands it to the session they're playing in.
+**/
+func (s *Server) recordLatency(conn *websocket.Conn, rtt time.Duration) {
+	s.mu.Lock()
+	player, exists := s.connToPlayer[conn]
+	if !exists {
+		s.mu.Unlock()
+		return
+	}
+
+	latency := rtt
+	if previous, measured := s.latencies[player.ID]; measured {
+		latency = (previous*3 + rtt) / 4
+	}
+	s.latencies[player.ID] = latency
+
+	session, inSession := s.sessionOf(player.ID)
+	s.mu.Unlock()
+
+	if inSession {
+		session.SetPlayerLatency(player.ID, latency)
+	}
+}
+
+/**
+* the player's round trip to the server, false until a pong came back.
+**/
+func (s *Server) PlayerLatency(playerID uuid.UUID) (time.Duration, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	latency, measured := s.latencies[playerID]
+	return latency, measured
+}
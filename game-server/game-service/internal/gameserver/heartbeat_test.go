@@ -0,0 +1,120 @@
+package gameserver
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing connections are kept alive, timed and closed once they go quiet.
+**/
+
+// dialTestPlayer connects a player to the server over a real websocket,
+// asking for the subprotocols given
+func dialTestPlayer(t *testing.T, server *Server, playerID uuid.UUID, subprotocols ...string) *websocket.Conn {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/game/ws", func(c *gin.Context) {
+		c.Set("userIdStr", playerID.String())
+		server.HandleWebSocketConnection(c)
+	})
+
+	httpServer := httptest.NewServer(router)
+	t.Cleanup(httpServer.Close)
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/game/ws"
+	dialer := websocket.Dialer{Subprotocols: subprotocols}
+	conn, _, err := dialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// TestHeartbeatMeasuresLatency tests pongs to the server's pings time the player
+func TestHeartbeatMeasuresLatency(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	server.SetHeartbeat(20*time.Millisecond, time.Second)
+
+	playerID := uuid.New()
+	conn := dialTestPlayer(t, server, playerID)
+
+	// pongs only go out while the client reads
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		_, measured := server.PlayerLatency(playerID)
+		return measured
+	}, 2*time.Second, 10*time.Millisecond)
+
+	latency, _ := server.PlayerLatency(playerID)
+	assert.Greater(t, latency, time.Duration(0))
+
+	// answering pings keeps the connection open past the idle timeout
+	time.Sleep(1200 * time.Millisecond)
+	_, connected := server.GetConnFromPlayer(playerID)
+	assert.True(t, connected)
+}
+
+// TestIdleConnectionClosed tests a client that stops answering is cleaned up
+func TestIdleConnectionClosed(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	server.SetHeartbeat(20*time.Millisecond, 100*time.Millisecond)
+
+	playerID := uuid.New()
+	// never reading means never answering a ping, like a half-open connection
+	dialTestPlayer(t, server, playerID)
+
+	require.Eventually(t, func() bool {
+		_, connected := server.GetConnFromPlayer(playerID)
+		return !connected
+	}, 2*time.Second, 10*time.Millisecond)
+
+	_, measured := server.PlayerLatency(playerID)
+	assert.False(t, measured)
+}
+
+// TestPingAction tests the ping action echoes the client's clock with the measured latency
+func TestPingAction(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "pinger"}
+	conn := &websocket.Conn{}
+	msgCh := registerTestConn(server, conn, player)
+
+	server.mu.Lock()
+	server.latencies[player.ID] = 42 * time.Millisecond
+	server.mu.Unlock()
+
+	server.GetServerChan() <- types.ClientPackage{
+		Message: types.Message{
+			Action:    string(constants.ActionPing),
+			RequestID: "ping-1",
+			Payload:   map[string]interface{}{"sent_at": float64(1234)},
+		},
+		Conn: conn,
+	}
+
+	pong := waitForAction(t, msgCh, constants.ActionPing)
+	assert.Equal(t, true, pong.Payload["success"])
+	assert.Equal(t, "ping-1", pong.RequestID)
+	assert.Equal(t, float64(1234), pong.Payload["sent_at"])
+	assert.Equal(t, float64(42), pong.Payload["latency_ms"])
+	assert.NotZero(t, pong.Payload["server_time"])
+}
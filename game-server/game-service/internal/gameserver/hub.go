@@ -1,8 +1,10 @@
 package gameserver
 
 import (
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
@@ -26,13 +28,24 @@ type messageHub struct {
 }
 
 type SessionManager interface {
-	CreateGameSession(players []*types.Player) *game.Session
+	LobbyManager
+	PartyManager
+	SpectatorManager
+
+	CreateGameSession(players []*types.Player) (*game.Session, error)
+	CreateGameSessionWithMode(players []*types.Player, mode game.GameMode) (*game.Session, error)
 	GetGameSession(id uuid.UUID) (*game.Session, bool)
 	GetServerChan() chan types.ClientPackage
-	AddPlayerToQueue(*types.Player)
+	AddPlayerToQueue(queueID string, player *types.Player) (string, error)
+	RemovePlayerFromQueue(player *types.Player) bool
+	StartReadyCheck(match systems.Match) *ReadyCheck
+	RespondToReadyCheck(checkID, playerID uuid.UUID, accept bool) error
 	GetPlayerFromConn(conn *websocket.Conn) (*types.Player, bool)
-	GetMatchedChan() chan []*types.Player
+	WriterFor(conn *websocket.Conn) types.MessageWriter
+	GetMatchedChan() chan systems.Match
 	GetQueueStatusChan() chan systems.QueueStatus
+	GetQueueStats() []systems.QueueStats
+	PlayerLatency(playerID uuid.UUID) (time.Duration, bool)
 }
 
 func NewMessageHub(sessionManager SessionManager, sender *messaging.MessageSender) *messageHub {
@@ -55,12 +68,18 @@ func (h *messageHub) Run() {
 		case clientPackage := <-h.sessionManager.GetServerChan():
 			fmt.Printf("\nincoming message: %+v\n\n", clientPackage.Message)
 
-			response := types.NewResponseBuilder()
+			response := types.NewResponseBuilder().ForRequest(clientPackage.Message.RequestID)
+			// responses queue up for the connection's writer goroutine
+			writer := h.sessionManager.WriterFor(clientPackage.Conn)
 
 			// handle message based on action
 			var gameActions map[constants.Action]bool = map[constants.Action]bool{
-				constants.ActionMove:   true,
-				constants.ActionAttack: true,
+				constants.ActionMove:     true,
+				constants.ActionAttack:   true,
+				constants.ActionChat:     true,
+				constants.ActionInteract: true,
+
+				constants.ActionAllocateStat: true,
 			}
 
 			messageAction := constants.Action(clientPackage.Message.Action)
@@ -75,7 +94,7 @@ func (h *messageHub) Run() {
 				if err != nil {
 					// 傳入 conn 作為參數
 					response.Error(
-						clientPackage.Conn,
+						writer,
 						clientPackage.Message.Action,
 						constants.ErrorInvalidSessionID,
 						"Invalid or missing session ID in payload",
@@ -83,12 +102,33 @@ func (h *messageHub) Run() {
 					continue
 				}
 
+				// malformed payloads are turned away before reaching the session
+				if _, err := clientPackage.Message.ParsePayload(); err != nil {
+					response.InvalidPayload(writer, clientPackage.Message.Action, err)
+					continue
+				}
+
+				// players only ever act as themselves, which also keeps anyone
+				// spectating from acting for the players they watch
+				player, exists := h.sessionManager.GetPlayerFromConn(clientPackage.Conn)
+				playerID, _ := clientPackage.Message.GetPlayerID()
+
+				if !exists || player.ID != playerID {
+					response.Error(
+						writer,
+						clientPackage.Message.Action,
+						constants.ErrorPlayerMismatch,
+						"player_id does not belong to this connection",
+					)
+					continue
+				}
+
 				session, exists := h.sessionManager.GetGameSession(sessionID)
 
 				if !exists {
 					// 傳入 conn 作為參數
 					response.Error(
-						clientPackage.Conn,
+						writer,
 						clientPackage.Message.Action,
 						constants.ErrorSessionNotFound,
 						fmt.Sprintf("Game session not found for session ID: %s", sessionID),
@@ -97,8 +137,67 @@ func (h *messageHub) Run() {
 					continue
 				}
 
-				// propogate message to corresponding game
-				session.MessageCh <- clientPackage
+				// propogate message to corresponding game, never blocking the hub on
+				// a session that's ending or falling behind
+				if err := session.Deliver(clientPackage); err != nil {
+					code := constants.ErrorSessionNotFound
+					if errors.Is(err, game.ErrSessionBusy) {
+						code = constants.ErrorSessionBusy
+					}
+
+					response.Error(writer, clientPackage.Message.Action, code, err.Error())
+				}
+				continue
+			}
+
+			// --- LOBBY RELATED ACTIONS ---
+			if lobbyActions[messageAction] {
+				player, exists := h.sessionManager.GetPlayerFromConn(clientPackage.Conn)
+				if !exists {
+					response.Error(
+						writer,
+						clientPackage.Message.Action,
+						constants.ErrorPlayerNotFound,
+						"Player not found for connection",
+					)
+					continue
+				}
+
+				h.handleLobbyAction(player, messageAction, clientPackage.Message.Payload, clientPackage.Message.RequestID)
+				continue
+			}
+
+			// --- PARTY RELATED ACTIONS ---
+			if partyActions[messageAction] {
+				player, exists := h.sessionManager.GetPlayerFromConn(clientPackage.Conn)
+				if !exists {
+					response.Error(
+						writer,
+						clientPackage.Message.Action,
+						constants.ErrorPlayerNotFound,
+						"Player not found for connection",
+					)
+					continue
+				}
+
+				h.handlePartyAction(player, messageAction, clientPackage.Message.Payload, clientPackage.Message.RequestID)
+				continue
+			}
+
+			// --- SPECTATOR RELATED ACTIONS ---
+			if spectateActions[messageAction] {
+				player, exists := h.sessionManager.GetPlayerFromConn(clientPackage.Conn)
+				if !exists {
+					response.Error(
+						writer,
+						clientPackage.Message.Action,
+						constants.ErrorPlayerNotFound,
+						"Player not found for connection",
+					)
+					continue
+				}
+
+				h.handleSpectateAction(player, messageAction, clientPackage.Message.Payload, clientPackage.Message.RequestID)
 				continue
 			}
 
@@ -112,29 +211,124 @@ func (h *messageHub) Run() {
 				player, exists := h.sessionManager.GetPlayerFromConn(clientPackage.Conn)
 
 				if !exists {
-					// 傳入 conn 作為參數
+					response.Error(
+						writer,
+						clientPackage.Message.Action,
+						constants.ErrorPlayerNotFound,
+						"Player not found for connection",
+					)
+					fmt.Println("Player not found for connection")
+					continue
+				}
+
+				// no queue id puts the player in the default queue
+				queueID, _ := clientPackage.Message.Payload["queue_id"].(string)
+
+				queueID, err := h.sessionManager.AddPlayerToQueue(queueID, player)
+				if err != nil {
+					// anything else means the queues couldn't be reached
+					reason := constants.ErrorQueueUnavailable
+					switch {
+					case errors.Is(err, systems.ErrQueueNotFound):
+						reason = constants.ErrorQueueNotFound
+					case errors.Is(err, systems.ErrAlreadyQueued):
+						reason = constants.ErrorAlreadyQueued
+					case errors.Is(err, systems.ErrQueuePenalty):
+						reason = constants.ErrorQueuePenalty
+					case errors.Is(err, ErrInReadyCheck):
+						reason = constants.ErrorInReadyCheck
+					case errors.Is(err, ErrAlreadyPlaying):
+						reason = constants.ErrorAlreadyInGame
+					case errors.Is(err, ErrAlreadyInRoom):
+						reason = constants.ErrorAlreadyInRoom
+					case errors.Is(err, systems.ErrPartyTooLarge),
+						errors.Is(err, ErrNotPartyLeader),
+						errors.Is(err, ErrPartyMemberOffline):
+						reason = partyErrorCode(err)
+					}
+
 					h.sender.SendToPlayer(player.ID, types.Message{
-						Action: string(constants.ActionFindGame),
+						Action:    string(constants.ActionFindGame),
+						RequestID: clientPackage.Message.RequestID,
 						Payload: map[string]interface{}{
-							"message":   "Successfully joined matchmaking queue",
-							"player_id": player.ID.String(),
-							"username":  player.Username,
+							"success":  false,
+							"queue_id": queueID,
+							"reason":   string(reason),
+							"message":  err.Error(),
 						},
 					})
-					fmt.Println("Player not found for connection")
 					continue
 				}
+				fmt.Printf("Player %s added to matchmaking queue %s\n", player.Username, queueID)
 
-				// 將 player 加入 queue，QueueSystem 會透過 channel 處理
-				// 配對成功後會自動呼叫 Server.onMatchFound callback
-				h.sessionManager.AddPlayerToQueue(player)
-				fmt.Printf("Player %s added to matchmaking queue\n", player.Username)
+				// the rest of the party finds out they're queued
+				if party, inParty := h.sessionManager.GetPlayerParty(player.ID); inParty {
+					h.sessionManager.BroadcastPartyUpdate(party)
+				}
 
 				// 傳入 conn 作為參數
-				response.Success(clientPackage.Conn, clientPackage.Message.Action, map[string]interface{}{
+				response.Success(writer, clientPackage.Message.Action, map[string]interface{}{
 					"message":   "Successfully joined matchmaking queue",
 					"player_id": player.ID.String(),
 					"username":  player.Username,
+					"queue_id":  queueID,
+				})
+
+			case constants.ActionQueueStats:
+				player, exists := h.sessionManager.GetPlayerFromConn(clientPackage.Conn)
+				if !exists {
+					response.Error(
+						writer,
+						clientPackage.Message.Action,
+						constants.ErrorPlayerNotFound,
+						"Player not found for connection",
+					)
+					continue
+				}
+
+				queues := make([]map[string]interface{}, 0)
+				for _, stats := range h.sessionManager.GetQueueStats() {
+					queues = append(queues, QueueStatsPayload(stats))
+				}
+
+				h.sender.SendToPlayer(player.ID, types.Message{
+					Action:    string(constants.ActionQueueStats),
+					RequestID: clientPackage.Message.RequestID,
+					Payload: map[string]interface{}{
+						"success": true,
+						"queues":  queues,
+					},
+				})
+
+			case constants.ActionPing:
+				player, exists := h.sessionManager.GetPlayerFromConn(clientPackage.Conn)
+				if !exists {
+					response.Error(
+						writer,
+						clientPackage.Message.Action,
+						constants.ErrorPlayerNotFound,
+						"Player not found for connection",
+					)
+					continue
+				}
+
+				// the client times the round trip with its own clock, the
+				// server's measurement comes from the pongs to its pings
+				payload := map[string]interface{}{
+					"success":     true,
+					"server_time": time.Now().UnixMilli(),
+				}
+				if sentAt, ok := clientPackage.Message.Payload["sent_at"]; ok {
+					payload["sent_at"] = sentAt
+				}
+				if latency, measured := h.sessionManager.PlayerLatency(player.ID); measured {
+					payload["latency_ms"] = float64(latency.Microseconds()) / 1000
+				}
+
+				h.sender.SendToPlayer(player.ID, types.Message{
+					Action:    string(constants.ActionPing),
+					RequestID: clientPackage.Message.RequestID,
+					Payload:   payload,
 				})
 
 			case constants.ActionLeaveQueue:
@@ -142,7 +336,7 @@ func (h *messageHub) Run() {
 				if !exists {
 					// 傳入 conn 作為參數
 					response.Error(
-						clientPackage.Conn,
+						writer,
 						clientPackage.Message.Action,
 						constants.ErrorPlayerNotFound,
 						"Player not found for connection",
@@ -150,38 +344,92 @@ func (h *messageHub) Run() {
 					continue
 				}
 
-				// TODO: 實現離開隊列邏輯
-				// h.sessionManager.RemovePlayerFromQueue(player)
-				fmt.Println("Leave game...")
+				if !h.sessionManager.RemovePlayerFromQueue(player) {
+					h.sender.SendToPlayer(player.ID, types.Message{
+						Action:    string(constants.ActionLeaveQueue),
+						RequestID: clientPackage.Message.RequestID,
+						Payload: map[string]interface{}{
+							"success": false,
+							"reason":  string(constants.ErrorNotInQueue),
+							"message": "Player is not waiting in a queue",
+						},
+					})
+					continue
+				}
 
 				// 傳入 conn 作為參數
-				response.Success(clientPackage.Conn, clientPackage.Message.Action, map[string]interface{}{
+				response.Success(writer, clientPackage.Message.Action, map[string]interface{}{
 					"message":   "Successfully left the queue",
 					"player_id": player.ID.String(),
 				})
 
+			case constants.ActionReadyCheckResponse:
+				player, exists := h.sessionManager.GetPlayerFromConn(clientPackage.Conn)
+				if !exists {
+					response.Error(
+						writer,
+						clientPackage.Message.Action,
+						constants.ErrorPlayerNotFound,
+						"Player not found for connection",
+					)
+					continue
+				}
+
+				checkIDStr, _ := clientPackage.Message.Payload["check_id"].(string)
+				accept, validAccept := clientPackage.Message.Payload["accept"].(bool)
+				checkID, err := uuid.Parse(checkIDStr)
+
+				if err != nil || !validAccept {
+					h.sender.SendToPlayer(player.ID, types.Message{
+						Action:    string(constants.ActionReadyCheckResponse),
+						RequestID: clientPackage.Message.RequestID,
+						Payload: map[string]interface{}{
+							"success": false,
+							"reason":  string(constants.ErrorInvalidPayload),
+							"message": "check_id and accept are required",
+						},
+					})
+					continue
+				}
+
+				if err := h.sessionManager.RespondToReadyCheck(checkID, player.ID, accept); err != nil {
+					h.sender.SendToPlayer(player.ID, types.Message{
+						Action:    string(constants.ActionReadyCheckResponse),
+						RequestID: clientPackage.Message.RequestID,
+						Payload: map[string]interface{}{
+							"success":  false,
+							"check_id": checkIDStr,
+							"reason":   string(constants.ErrorReadyCheck),
+							"message":  err.Error(),
+						},
+					})
+					continue
+				}
+
+				h.sender.SendToPlayer(player.ID, types.Message{
+					Action:    string(constants.ActionReadyCheckResponse),
+					RequestID: clientPackage.Message.RequestID,
+					Payload: map[string]interface{}{
+						"success":  true,
+						"check_id": checkIDStr,
+						"accept":   accept,
+					},
+				})
+
 			default:
 				// 傳入 conn 作為參數
 				response.Error(
-					clientPackage.Conn,
+					writer,
 					clientPackage.Message.Action,
 					constants.ErrorInvalidPayload,
 					fmt.Sprintf("Unknown action: %s", messageAction),
 				)
 			}
 
-		// 監聯配對成功的 channel
-		case matchedPlayers := <-h.sessionManager.GetMatchedChan():
-			fmt.Printf("Received matched players, creating game session...\n")
-			fmt.Println(matchedPlayers)
-			session := h.sessionManager.CreateGameSession(matchedPlayers)
-			h.sender.BroadcastToPlayerList(matchedPlayers,
-				types.Message{
-					Action: "game_found",
-					Payload: map[string]any{
-						"session_id": session.ID.String(),
-					},
-				})
+		// 監聯配對成功的 channel, matched players have to accept before the game starts
+		case match := <-h.sessionManager.GetMatchedChan():
+			fmt.Printf("Received matched players from queue %s, starting ready check...\n", match.QueueID)
+			h.sessionManager.StartReadyCheck(match)
 
 		// 監聽排隊狀態更新
 		case status := <-h.sessionManager.GetQueueStatusChan():
@@ -190,8 +438,9 @@ func (h *messageHub) Run() {
 				types.Message{
 					Action: "queue_status",
 					Payload: map[string]any{
-						"current": status.Current,
-						"total":   status.Total,
+						"queue_id": status.QueueID,
+						"current":  status.Current,
+						"total":    status.Total,
 					},
 				})
 		}
@@ -0,0 +1,274 @@
+package gameserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Lobby actions
+*
+* Websocket side of lobbies. Every action is answered to the player that sent
+* it with a success flag, and every change is pushed to the rest of the lobby
+* as a lobby_update.
+**/
+
+type LobbyManager interface {
+	CreateRoomWithSettings(creatorID uuid.UUID, settings RoomSettings) (*Room, error)
+	GetRoom(roomID uuid.UUID) (*Room, error)
+	ListRooms(gameMode string, limit, offset int) ([]*Room, int)
+	JoinRoom(roomID, userID uuid.UUID) (*Room, error)
+	JoinRoomByCode(code string, userID uuid.UUID) (*Room, error)
+	LeaveRoom(roomID, userID uuid.UUID) error
+	KickFromRoom(roomID, hostID, targetID uuid.UUID) (*Room, error)
+	SetReady(roomID, userID uuid.UUID, ready bool) (*Room, error)
+	UpdateRoomSettings(roomID, hostID uuid.UUID, settings RoomSettings) (*Room, error)
+	StartRoomAsHost(roomID, hostID uuid.UUID) (*Room, error)
+	BroadcastRoomUpdate(room *Room)
+}
+
+var lobbyActions = map[constants.Action]bool{
+	constants.ActionCreateLobby: true,
+	constants.ActionJoinLobby:   true,
+	constants.ActionLeaveLobby:  true,
+	constants.ActionKickPlayer:  true,
+	constants.ActionSetReady:    true,
+	constants.ActionUpdateLobby: true,
+	constants.ActionStartLobby:  true,
+	constants.ActionListLobbies: true,
+}
+
+func (h *messageHub) handleLobbyAction(player *types.Player, action constants.Action, payload map[string]interface{}, requestID string) {
+	var room *Room
+	var err error
+
+	switch action {
+	case constants.ActionCreateLobby:
+		room, err = h.sessionManager.CreateRoomWithSettings(player.ID, lobbySettingsFromPayload(RoomSettings{}, payload))
+
+	case constants.ActionJoinLobby:
+		if code, ok := payload["invite_code"].(string); ok && code != "" {
+			room, err = h.sessionManager.JoinRoomByCode(code, player.ID)
+			break
+		}
+
+		var roomID uuid.UUID
+		if roomID, err = lobbyIDFromPayload(payload); err == nil {
+			room, err = h.sessionManager.JoinRoom(roomID, player.ID)
+		}
+
+	case constants.ActionLeaveLobby:
+		var roomID uuid.UUID
+		if roomID, err = lobbyIDFromPayload(payload); err != nil {
+			break
+		}
+
+		if err = h.sessionManager.LeaveRoom(roomID, player.ID); err != nil {
+			break
+		}
+
+		h.sender.SendToPlayer(player.ID, types.Message{
+			Action:    string(action),
+			RequestID: requestID,
+			Payload: map[string]interface{}{
+				"success": true,
+				"room_id": roomID.String(),
+			},
+		})
+
+		// the room is gone once the last player leaves
+		if remaining, err := h.sessionManager.GetRoom(roomID); err == nil {
+			h.sessionManager.BroadcastRoomUpdate(remaining)
+		}
+		return
+
+	case constants.ActionKickPlayer:
+		var roomID, targetID uuid.UUID
+		if roomID, err = lobbyIDFromPayload(payload); err != nil {
+			break
+		}
+
+		targetIDStr, _ := payload["target_id"].(string)
+		if targetID, err = uuid.Parse(targetIDStr); err != nil {
+			err = fmt.Errorf("target_id must be a player id: %w", errInvalidLobbyPayload)
+			break
+		}
+
+		if room, err = h.sessionManager.KickFromRoom(roomID, player.ID, targetID); err == nil {
+			h.sender.SendToPlayer(targetID, types.Message{
+				Action: string(constants.ActionLobbyKicked),
+				Payload: map[string]interface{}{
+					"room_id": roomID.String(),
+					"message": ErrKickedFromRoom.Error(),
+				},
+			})
+		}
+
+	case constants.ActionSetReady:
+		var roomID uuid.UUID
+		if roomID, err = lobbyIDFromPayload(payload); err != nil {
+			break
+		}
+
+		ready, ok := payload["ready"].(bool)
+		if !ok {
+			err = fmt.Errorf("ready must be true or false: %w", errInvalidLobbyPayload)
+			break
+		}
+
+		room, err = h.sessionManager.SetReady(roomID, player.ID, ready)
+
+	case constants.ActionUpdateLobby:
+		var roomID uuid.UUID
+		if roomID, err = lobbyIDFromPayload(payload); err != nil {
+			break
+		}
+
+		var current *Room
+		if current, err = h.sessionManager.GetRoom(roomID); err != nil {
+			break
+		}
+
+		// only the settings in the payload change
+		settings := lobbySettingsFromPayload(RoomSettings{
+			Name:       current.Name,
+			GameMode:   current.GameMode,
+			Map:        current.Map,
+			MaxPlayers: current.MaxPlayers,
+			Private:    current.Private,
+			InviteCode: current.InviteCode != "",
+		}, payload)
+
+		room, err = h.sessionManager.UpdateRoomSettings(roomID, player.ID, settings)
+
+	case constants.ActionStartLobby:
+		var roomID uuid.UUID
+		if roomID, err = lobbyIDFromPayload(payload); err == nil {
+			room, err = h.sessionManager.StartRoomAsHost(roomID, player.ID)
+		}
+
+	case constants.ActionListLobbies:
+		gameMode, _ := payload["game_mode"].(string)
+		limit, _ := payload["limit"].(float64)
+		offset, _ := payload["offset"].(float64)
+
+		rooms, total := h.sessionManager.ListRooms(gameMode, int(limit), int(offset))
+
+		lobbies := make([]map[string]interface{}, 0, len(rooms))
+		for _, listed := range rooms {
+			lobbies = append(lobbies, RoomPayload(listed))
+		}
+
+		h.sender.SendToPlayer(player.ID, types.Message{
+			Action:    string(action),
+			RequestID: requestID,
+			Payload: map[string]interface{}{
+				"success": true,
+				"lobbies": lobbies,
+				"total":   total,
+			},
+		})
+		return
+	}
+
+	if err != nil {
+		h.sender.SendToPlayer(player.ID, types.Message{
+			Action:    string(action),
+			RequestID: requestID,
+			Payload: map[string]interface{}{
+				"success": false,
+				"reason":  string(lobbyErrorCode(err)),
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	h.sender.SendToPlayer(player.ID, types.Message{
+		Action:    string(action),
+		RequestID: requestID,
+		Payload: map[string]interface{}{
+			"success": true,
+			"lobby":   RoomPayload(room),
+		},
+	})
+
+	h.sessionManager.BroadcastRoomUpdate(room)
+}
+
+var errInvalidLobbyPayload = errors.New("invalid lobby payload")
+
+func lobbyIDFromPayload(payload map[string]interface{}) (uuid.UUID, error) {
+	roomIDStr, _ := payload["room_id"].(string)
+
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("room_id must be a lobby id: %w", errInvalidLobbyPayload)
+	}
+
+	return roomID, nil
+}
+
+/**
+* overrides the given settings with whatever the payload sets.
+**/
+func lobbySettingsFromPayload(settings RoomSettings, payload map[string]interface{}) RoomSettings {
+	if name, ok := payload["name"].(string); ok {
+		settings.Name = name
+	}
+
+	if gameMode, ok := payload["game_mode"].(string); ok {
+		settings.GameMode = gameMode
+	}
+
+	if mapName, ok := payload["map"].(string); ok {
+		settings.Map = mapName
+	}
+
+	// json numbers always decode as float64
+	if maxPlayers, ok := payload["max_players"].(float64); ok {
+		settings.MaxPlayers = int(maxPlayers)
+	}
+
+	if private, ok := payload["private"].(bool); ok {
+		settings.Private = private
+	}
+
+	if inviteCode, ok := payload["invite_code"].(bool); ok {
+		settings.InviteCode = inviteCode
+	}
+
+	return settings
+}
+
+func lobbyErrorCode(err error) constants.ErrorCode {
+	switch {
+	case errors.Is(err, errInvalidLobbyPayload):
+		return constants.ErrorInvalidPayload
+	case errors.Is(err, ErrRoomNotFound):
+		return constants.ErrorLobbyNotFound
+	case errors.Is(err, ErrRoomFull):
+		return constants.ErrorLobbyFull
+	case errors.Is(err, ErrNotHost):
+		return constants.ErrorNotHost
+	case errors.Is(err, ErrPlayersNotReady):
+		return constants.ErrorPlayersNotReady
+	case errors.Is(err, ErrServerFull):
+		return constants.ErrorServerFull
+	case errors.Is(err, ErrKickedFromRoom):
+		return constants.ErrorKicked
+	case errors.Is(err, ErrInvalidInviteCode):
+		return constants.ErrorInvalidInviteCode
+	case errors.Is(err, ErrInvalidRoomName),
+		errors.Is(err, ErrUnknownGameMode),
+		errors.Is(err, ErrUnknownMap),
+		errors.Is(err, ErrInvalidMaxPlayers):
+		return constants.ErrorInvalidLobbyConfig
+	default:
+		return constants.ErrorLobbyFailed
+	}
+}
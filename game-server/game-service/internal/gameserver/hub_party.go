@@ -0,0 +1,169 @@
+package gameserver
+
+import (
+	"errors"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Party actions
+*
+* Websocket side of parties. Every action is answered to the player that
+* sent it with a success flag, and every change is pushed to the party's
+* members as a party_update.
+**/
+
+type PartyManager interface {
+	InviteToParty(inviterID, targetID uuid.UUID) (*Party, error)
+	AcceptPartyInvite(partyID, playerID uuid.UUID) (*Party, error)
+	DeclinePartyInvite(partyID, playerID uuid.UUID) (*Party, error)
+	LeaveParty(playerID uuid.UUID) (*Party, error)
+	GetPlayerParty(playerID uuid.UUID) (*Party, bool)
+	BroadcastPartyUpdate(party *Party)
+	PartyPayload(party *Party) map[string]interface{}
+}
+
+var partyActions = map[constants.Action]bool{
+	constants.ActionInviteParty:        true,
+	constants.ActionAcceptPartyInvite:  true,
+	constants.ActionDeclinePartyInvite: true,
+	constants.ActionLeaveParty:         true,
+	constants.ActionGetParty:           true,
+}
+
+func (h *messageHub) handlePartyAction(player *types.Player, action constants.Action, payload map[string]interface{}, requestID string) {
+	var party *Party
+	var err error
+
+	switch action {
+	case constants.ActionInviteParty:
+		targetIDStr, _ := payload["target_id"].(string)
+		targetID, parseErr := uuid.Parse(targetIDStr)
+		if parseErr != nil {
+			h.sendPartyFailure(player, action, requestID, constants.ErrorInvalidPayload, "target_id must be a player id")
+			return
+		}
+
+		if party, err = h.sessionManager.InviteToParty(player.ID, targetID); err == nil {
+			h.sender.SendToPlayer(targetID, types.Message{
+				Action: string(constants.ActionPartyInvite),
+				Payload: map[string]interface{}{
+					"party_id":     party.ID.String(),
+					"from_id":      player.ID.String(),
+					"from":         player.Username,
+					"timeout_secs": int(constants.PartyInviteTimeout.Seconds()),
+				},
+			})
+		}
+
+	case constants.ActionAcceptPartyInvite, constants.ActionDeclinePartyInvite:
+		partyIDStr, _ := payload["party_id"].(string)
+		partyID, parseErr := uuid.Parse(partyIDStr)
+		if parseErr != nil {
+			h.sendPartyFailure(player, action, requestID, constants.ErrorInvalidPayload, "party_id must be a party id")
+			return
+		}
+
+		if action == constants.ActionAcceptPartyInvite {
+			party, err = h.sessionManager.AcceptPartyInvite(partyID, player.ID)
+			break
+		}
+
+		if party, err = h.sessionManager.DeclinePartyInvite(partyID, player.ID); err == nil {
+			h.sender.SendToPlayer(party.LeaderID, types.Message{
+				Action: string(constants.ActionPartyDeclined),
+				Payload: map[string]interface{}{
+					"party_id":  party.ID.String(),
+					"player_id": player.ID.String(),
+				},
+			})
+
+			h.sender.SendToPlayer(player.ID, types.Message{
+				Action:    string(action),
+				RequestID: requestID,
+				Payload:   map[string]interface{}{"success": true, "party_id": party.ID.String()},
+			})
+			return
+		}
+
+	case constants.ActionLeaveParty:
+		if party, err = h.sessionManager.LeaveParty(player.ID); err == nil {
+			h.sender.SendToPlayer(player.ID, types.Message{
+				Action:    string(action),
+				RequestID: requestID,
+				Payload:   map[string]interface{}{"success": true, "party_id": party.ID.String()},
+			})
+
+			h.sessionManager.BroadcastPartyUpdate(party)
+			return
+		}
+
+	case constants.ActionGetParty:
+		current, inParty := h.sessionManager.GetPlayerParty(player.ID)
+		if !inParty {
+			err = ErrNotInParty
+			break
+		}
+
+		h.sender.SendToPlayer(player.ID, types.Message{
+			Action:    string(action),
+			RequestID: requestID,
+			Payload:   map[string]interface{}{"success": true, "party": h.sessionManager.PartyPayload(current)},
+		})
+		return
+	}
+
+	if err != nil {
+		h.sendPartyFailure(player, action, requestID, partyErrorCode(err), err.Error())
+		return
+	}
+
+	h.sender.SendToPlayer(player.ID, types.Message{
+		Action:    string(action),
+		RequestID: requestID,
+		Payload:   map[string]interface{}{"success": true, "party": h.sessionManager.PartyPayload(party)},
+	})
+
+	h.sessionManager.BroadcastPartyUpdate(party)
+}
+
+func (h *messageHub) sendPartyFailure(player *types.Player, action constants.Action, requestID string, reason constants.ErrorCode, message string) {
+	h.sender.SendToPlayer(player.ID, types.Message{
+		Action:    string(action),
+		RequestID: requestID,
+		Payload: map[string]interface{}{
+			"success": false,
+			"reason":  string(reason),
+			"message": message,
+		},
+	})
+}
+
+func partyErrorCode(err error) constants.ErrorCode {
+	switch {
+	case errors.Is(err, ErrPartyNotFound):
+		return constants.ErrorPartyNotFound
+	case errors.Is(err, ErrAlreadyInParty):
+		return constants.ErrorAlreadyInParty
+	case errors.Is(err, ErrNotPartyLeader):
+		return constants.ErrorNotPartyLeader
+	case errors.Is(err, ErrPartyFull):
+		return constants.ErrorPartyFull
+	case errors.Is(err, ErrNoPartyInvite):
+		return constants.ErrorNoPartyInvite
+	case errors.Is(err, ErrNotInParty):
+		return constants.ErrorNotInParty
+	case errors.Is(err, ErrPartyMemberOffline):
+		return constants.ErrorPartyMemberOffline
+	case errors.Is(err, systems.ErrPartyTooLarge):
+		return constants.ErrorPartyTooLarge
+	case errors.Is(err, ErrInviteSelf):
+		return constants.ErrorInvalidPayload
+	default:
+		return constants.ErrorPartyFailed
+	}
+}
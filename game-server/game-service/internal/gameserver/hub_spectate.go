@@ -0,0 +1,113 @@
+package gameserver
+
+import (
+	"errors"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Spectator actions
+*
+* Websocket side of spectating. Every action is answered to the user that
+* sent it with a success flag, the game itself arrives as spectate_state.
+**/
+
+type SpectatorManager interface {
+	SpectateSession(sessionID, userID, followID uuid.UUID) (*game.Session, error)
+	StopSpectating(userID uuid.UUID) error
+	FollowSpectatedPlayer(userID, targetID uuid.UUID) error
+}
+
+var spectateActions = map[constants.Action]bool{
+	constants.ActionSpectate:       true,
+	constants.ActionStopSpectating: true,
+	constants.ActionSpectateFollow: true,
+}
+
+func (h *messageHub) handleSpectateAction(player *types.Player, action constants.Action, payload map[string]interface{}, requestID string) {
+	// following nobody is allowed, a malformed id isn't
+	followID := uuid.Nil
+	if followIDStr, _ := payload["follow_id"].(string); followIDStr != "" {
+		parsed, err := uuid.Parse(followIDStr)
+		if err != nil {
+			h.sendSpectateFailure(player, action, requestID, constants.ErrorInvalidPayload, "follow_id must be a player id")
+			return
+		}
+		followID = parsed
+	}
+
+	response := map[string]interface{}{"success": true}
+
+	switch action {
+	case constants.ActionSpectate:
+		sessionIDStr, _ := payload["session_id"].(string)
+		sessionID, err := uuid.Parse(sessionIDStr)
+		if err != nil {
+			h.sendSpectateFailure(player, action, requestID, constants.ErrorInvalidPayload, "session_id must be a session id")
+			return
+		}
+
+		session, err := h.sessionManager.SpectateSession(sessionID, player.ID, followID)
+		if err != nil {
+			h.sendSpectateFailure(player, action, requestID, spectateErrorCode(err), err.Error())
+			return
+		}
+
+		mode := session.Mode()
+		response["session_id"] = session.ID.String()
+		response["game_mode"] = mode.Name
+		response["delay_ms"] = mode.SpectatorDelay.Milliseconds()
+
+	case constants.ActionStopSpectating:
+		if err := h.sessionManager.StopSpectating(player.ID); err != nil {
+			h.sendSpectateFailure(player, action, requestID, spectateErrorCode(err), err.Error())
+			return
+		}
+
+	case constants.ActionSpectateFollow:
+		if err := h.sessionManager.FollowSpectatedPlayer(player.ID, followID); err != nil {
+			h.sendSpectateFailure(player, action, requestID, spectateErrorCode(err), err.Error())
+			return
+		}
+		response["follow_id"] = followID.String()
+	}
+
+	h.sender.SendToPlayer(player.ID, types.Message{
+		Action:    string(action),
+		RequestID: requestID,
+		Payload:   response,
+	})
+}
+
+func (h *messageHub) sendSpectateFailure(player *types.Player, action constants.Action, requestID string, reason constants.ErrorCode, message string) {
+	h.sender.SendToPlayer(player.ID, types.Message{
+		Action:    string(action),
+		RequestID: requestID,
+		Payload: map[string]interface{}{
+			"success": false,
+			"reason":  string(reason),
+			"message": message,
+		},
+	})
+}
+
+func spectateErrorCode(err error) constants.ErrorCode {
+	switch {
+	case errors.Is(err, ErrSessionNotFound):
+		return constants.ErrorSessionNotFound
+	case errors.Is(err, game.ErrSpectatorsFull):
+		return constants.ErrorSpectatorsFull
+	case errors.Is(err, game.ErrAlreadyInSession):
+		return constants.ErrorAlreadyInSession
+	case errors.Is(err, game.ErrNotSpectating):
+		return constants.ErrorNotSpectating
+	case errors.Is(err, game.ErrFollowTargetMissing):
+		return constants.ErrorFollowTargetMissing
+	default:
+		return constants.ErrorSpectateFailed
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
 	grpcauth "github.com/darkphotonKN/cosmic-void-server/game-service/grpc/auth"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/protocol"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -46,7 +47,7 @@ func (m *MockAuthClient) ValidateToken(ctx context.Context, req *pb.ValidateToke
 // message sent to client sends to Server →  Hub routes →  Session receives
 func TestServerHubSessionIntegration(t *testing.T) {
 	mockAuthClient := &MockAuthClient{}
-	server := NewServer(mockAuthClient)
+	server := NewServer(mockAuthClient, nil, nil)
 
 	// create test players
 	player1 := &types.Player{
@@ -61,7 +62,8 @@ func TestServerHubSessionIntegration(t *testing.T) {
 	testPlayers := []*types.Player{player1, player2}
 
 	// create game session through server
-	session := server.CreateGameSession(testPlayers)
+	session, err := server.CreateGameSession(testPlayers)
+	require.NoError(t, err)
 	session.TestMessageSpy = make(chan types.Message)
 
 	require.NotNil(t, session, "Session should be created")
@@ -83,9 +85,13 @@ func TestServerHubSessionIntegration(t *testing.T) {
 		},
 	}
 
+	// the hub only needs to know which player the connection belongs to
+	conn := &websocket.Conn{}
+	registerTestConn(server, conn, player1)
+
 	clientPackage := types.ClientPackage{
 		Message: clientMsg,
-		Conn:    nil, // no real connection needed for this test
+		Conn:    conn,
 	}
 
 	// simulating websocket server, send to servers channel
@@ -93,16 +99,21 @@ func TestServerHubSessionIntegration(t *testing.T) {
 
 	server.serverChan <- clientPackage
 
-	// the game Session should receive the message after hub reroutes it
+	// the game Session should receive the message after hub reroutes it, either
+	// still waiting in its channel or already passed on by its message spy
+	var received types.Message
 	select {
 	case receivedPackage := <-session.MessageCh:
-		assert.Equal(t, string(constants.ActionMove), receivedPackage.Message.Action)
-		assert.Equal(t, session.ID.String(), receivedPackage.Message.Payload["session_id"])
-		assert.Equal(t, 1.0, receivedPackage.Message.Payload["vx"])
-		fmt.Printf("✅ Session received message: %+v\n", receivedPackage.Message)
+		received = receivedPackage.Message
+	case received = <-session.TestMessageSpy:
 	case <-time.After(2 * time.Second):
 		t.Fatal("Message was not routed to session within timeout")
 	}
+
+	assert.Equal(t, string(constants.ActionMove), received.Action)
+	assert.Equal(t, session.ID.String(), received.Payload["session_id"])
+	assert.Equal(t, 1.0, received.Payload["vx"])
+	fmt.Printf("✅ Session received message: %+v\n", received)
 }
 
 func registerTestConn(s *Server, conn *websocket.Conn, player *types.Player) chan types.Message {
@@ -116,68 +127,73 @@ func registerTestConn(s *Server, conn *websocket.Conn, player *types.Player) cha
 	return msgCh
 }
 
+// TestQueueFindGameFlow tests players queueing over their connections all end up in a game
 func TestQueueFindGameFlow(t *testing.T) {
-	mockAuthClient := &MockAuthClient{}
-	server := NewServer(mockAuthClient)
+	server := NewServer(&MockAuthClient{}, nil, nil)
 
 	playerCount := 10
+	playerIDs := make([]uuid.UUID, playerCount)
+	conns := make([]*websocket.Conn, playerCount)
+	for i := range conns {
+		playerIDs[i] = uuid.New()
+		conns[i] = dialTestPlayer(t, server, playerIDs[i])
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(playerCount)
 
-	for i := 1; i <= playerCount; i++ {
-		time.Sleep(3 * time.Second)
-		go func(idx int) {
+	for i, conn := range conns {
+		go func(idx int, conn *websocket.Conn) {
 			defer wg.Done()
 
-			fakeConn := &websocket.Conn{}
-			player := &types.Player{ID: uuid.New(), Username: fmt.Sprintf("Player%d", idx)}
-			msgCh := registerTestConn(server, fakeConn, player)
-
-			server.serverChan <- types.ClientPackage{
-				Message: types.Message{
-					Action: string(constants.ActionFindGame),
-					Payload: map[string]interface{}{
-						"ID":       player.ID,
-						"Username": player.Username,
-					},
-				},
-				Conn: fakeConn,
+			if err := conn.WriteJSON(types.Message{Action: string(constants.ActionFindGame)}); err != nil {
+				t.Errorf("Player%d could not queue: %v", idx, err)
+				return
 			}
 
-			timeout := time.After(10 * time.Second)
+			conn.SetReadDeadline(time.Now().Add(10 * time.Second))
 			for {
-				select {
-				case msg := <-msgCh:
-					if msg.Action == "game_found" {
-						server.mu.RLock()
-						currentSessions := len(server.sessions)
-						server.mu.RUnlock()
-						fmt.Printf("✅ Player%d 收到 game_found，目前 session 数量: %d\n", idx, currentSessions)
-						return
-					}
-					// queue_status 继续等待
-				case <-timeout:
-					fmt.Printf("❌ Player%d 沒收到 game_found\n", idx)
+				var msg types.ServerResponse
+				if err := conn.ReadJSON(&msg); err != nil {
+					t.Errorf("Player%d never got a game: %v", idx, err)
+					return
+				}
+
+				switch msg.Action {
+				// accept the match so the game starts
+				case string(constants.ActionReadyCheck):
+					conn.WriteJSON(types.Message{
+						Action: string(constants.ActionReadyCheckResponse),
+						Payload: map[string]interface{}{
+							"check_id": msg.Payload["check_id"],
+							"accept":   true,
+						},
+					})
+
+				case string(constants.ActionGameFound):
 					return
 				}
 			}
-		}(i)
+		}(i, conn)
 	}
 
 	wg.Wait()
-	time.Sleep(3 * time.Second)
 
+	// the default queue backfills, so later players may join games already
+	// running rather than start their own
 	server.mu.RLock()
-	sessionCount := len(server.sessions)
-	server.mu.RUnlock()
+	defer server.mu.RUnlock()
 
-	expectedSessions := playerCount / 2
-	assert.Equal(t, expectedSessions, sessionCount)
-	fmt.Println("總共創建遊戲數量", expectedSessions)
+	for i, playerID := range playerIDs {
+		_, inSession := server.sessionOf(playerID)
+		assert.True(t, inSession, "Player%d is not in a game", i)
+	}
+	assert.LessOrEqual(t, len(server.sessions), playerCount/2)
 }
+
 func TestResponseBuilderIntegration(t *testing.T) {
 	mockAuthClient := &MockAuthClient{}
-	server := NewServer(mockAuthClient)
+	server := NewServer(mockAuthClient, nil, nil)
 	// create test players
 	player1 := &types.Player{
 		ID:       uuid.New(),
@@ -191,7 +207,8 @@ func TestResponseBuilderIntegration(t *testing.T) {
 	testPlayers := []*types.Player{player1, player2}
 
 	// create game session through server
-	session := server.CreateGameSession(testPlayers)
+	session, err := server.CreateGameSession(testPlayers)
+	require.NoError(t, err)
 
 	clientMsg := types.Message{
 		Action: string(constants.ActionMove),
@@ -202,9 +219,13 @@ func TestResponseBuilderIntegration(t *testing.T) {
 			"vy":         0.0,
 		},
 	}
+	// the hub only needs to know which player the connection belongs to
+	conn := &websocket.Conn{}
+	registerTestConn(server, conn, player1)
+
 	clientPackage := types.ClientPackage{
 		Message: clientMsg,
-		Conn:    nil, // no real connection needed for this test
+		Conn:    conn,
 	}
 
 	response := types.NewResponseBuilder()
@@ -240,7 +261,7 @@ func TestSenderToBroadcastToPlayerList(t *testing.T) {
 	consulAddr := commonhelpers.GetEnvString("CONSUL_ADDR", "localhost:8510")
 	registry, err := consul.NewRegistry(consulAddr, serviceName)
 	authClient := grpcauth.NewClient(registry)
-	server := NewServer(authClient)
+	server := NewServer(authClient, nil, nil)
 
 	newSender := messaging.NewMessageSender(server)
 	// create test players
@@ -267,3 +288,162 @@ func TestSenderToBroadcastToPlayerList(t *testing.T) {
 
 	assert.NotNil(t, err, "Broadcast should return error for missing player connection")
 }
+
+// TestHubRejectsInvalidPayload tests malformed game actions come back with the fields at fault
+func TestHubRejectsInvalidPayload(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "TestPlayer"}
+	session, err := server.CreateGameSession([]*types.Player{player})
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	conn := dialTestPlayer(t, server, player.ID)
+
+	require.NoError(t, conn.WriteJSON(types.Message{
+		Action:    string(constants.ActionMove),
+		RequestID: "move-1",
+		Payload: map[string]interface{}{
+			"session_id": session.ID.String(),
+			"player_id":  player.ID.String(),
+			"vx":         "fast",
+		},
+	}))
+
+	// joining a session in progress sends its state first
+	var response types.ServerResponse
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for response.Action != string(constants.ActionMove) {
+		response = types.ServerResponse{}
+		require.NoError(t, conn.ReadJSON(&response))
+	}
+
+	assert.False(t, response.Success)
+	assert.Equal(t, "move-1", response.RequestID)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, string(constants.ErrorInvalidPayload), response.Error.Code)
+	assert.Equal(t, []types.FieldError{{Field: "vy", Message: "is required"}}, response.Error.Fields)
+}
+
+// TestHubRepliesThroughWriterQueue tests hub replies wait for the connection's writer goroutine instead of being written directly
+func TestHubRepliesThroughWriterQueue(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	// a fake connection, writing to it directly would crash the hub
+	conn := &websocket.Conn{}
+	player := &types.Player{ID: uuid.New(), Username: "TestPlayer"}
+	msgCh := registerTestConn(server, conn, player)
+
+	server.GetServerChan() <- types.ClientPackage{
+		Message: types.Message{Action: string(constants.ActionMove), RequestID: "move-1", Payload: map[string]interface{}{}},
+		Conn:    conn,
+	}
+
+	reply := waitForAction(t, msgCh, constants.ActionMove)
+	assert.Equal(t, "move-1", reply.RequestID)
+	require.NotNil(t, reply.Response)
+	assert.False(t, reply.Response.Success)
+	require.NotNil(t, reply.Response.Error)
+	assert.Equal(t, string(constants.ErrorInvalidSessionID), reply.Response.Error.Code)
+}
+
+// TestHubRejectsActingForOthers tests game actions naming another player never reach the session
+func TestHubRejectsActingForOthers(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "TestPlayer"}
+	other := &types.Player{ID: uuid.New(), Username: "OtherPlayer"}
+	session, err := server.CreateGameSession([]*types.Player{player, other})
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	conn := dialTestPlayer(t, server, player.ID)
+
+	require.NoError(t, conn.WriteJSON(types.Message{
+		Action:    string(constants.ActionMove),
+		RequestID: "move-1",
+		Payload: map[string]interface{}{
+			"session_id": session.ID.String(),
+			"player_id":  other.ID.String(),
+			"vx":         1.0,
+			"vy":         0.0,
+		},
+	}))
+
+	var response types.ServerResponse
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for response.Action != string(constants.ActionMove) {
+		response = types.ServerResponse{}
+		require.NoError(t, conn.ReadJSON(&response))
+	}
+
+	assert.False(t, response.Success)
+	assert.Equal(t, "move-1", response.RequestID)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, string(constants.ErrorPlayerMismatch), response.Error.Code)
+}
+
+// TestHubSpeaksProtobuf tests clients negotiating protobuf are answered in binary frames
+func TestHubSpeaksProtobuf(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "TestPlayer"}
+	session, err := server.CreateGameSession([]*types.Player{player})
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	conn := dialTestPlayer(t, server, player.ID, protocol.SubprotocolProtobuf)
+	require.Equal(t, protocol.SubprotocolProtobuf, conn.Subprotocol())
+
+	send := func(message types.Message) {
+		data, err := protocol.Protobuf.Encode(message)
+		require.NoError(t, err)
+		require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, data))
+	}
+	receive := func(action constants.Action) types.ServerResponse {
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		for {
+			frameType, data, err := conn.ReadMessage()
+			require.NoError(t, err)
+			require.Equal(t, websocket.BinaryMessage, frameType)
+
+			var response types.ServerResponse
+			require.NoError(t, protocol.Protobuf.Decode(data, &response))
+			if response.Action == string(action) {
+				return response
+			}
+		}
+	}
+
+	// joining a session in progress sends its state first
+	resume := receive(constants.ActionSessionResume)
+	state, ok := resume.Payload["state"].(*types.ClientGameState)
+	require.True(t, ok)
+	assert.Equal(t, session.ID, state.SessionID)
+	require.Len(t, state.Players, 1)
+	assert.Equal(t, player.ID, state.Players[0].ID)
+
+	// rejected by the hub
+	send(types.Message{
+		Action:    string(constants.ActionMove),
+		RequestID: "move-1",
+		Payload:   map[string]interface{}{"session_id": session.ID.String(), "player_id": player.ID.String(), "vx": 1.0},
+	})
+
+	rejected := receive(constants.ActionMove)
+	assert.Equal(t, "move-1", rejected.RequestID)
+	require.NotNil(t, rejected.Error)
+	assert.Equal(t, string(constants.ErrorInvalidPayload), rejected.Error.Code)
+	assert.Equal(t, []types.FieldError{{Field: "vy", Message: "is required"}}, rejected.Error.Fields)
+
+	// acknowledged by the session
+	send(types.Message{
+		Action:    string(constants.ActionMove),
+		RequestID: "move-2",
+		Payload:   map[string]interface{}{"session_id": session.ID.String(), "player_id": player.ID.String(), "vx": 0.0, "vy": 1.0},
+	})
+
+	acknowledged := receive(constants.ActionMove)
+	assert.Equal(t, "move-2", acknowledged.RequestID)
+	assert.Equal(t, true, acknowledged.Payload["success"])
+}
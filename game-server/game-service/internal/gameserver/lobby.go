@@ -0,0 +1,294 @@
+package gameserver
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Lobbies
+*
+* Host controls on top of rooms. The host changes settings, kicks players and
+* starts the match once everyone else is ready. Every change is pushed to the
+* players in the room over their websocket as a lobby_update.
+**/
+
+type RoomSettings struct {
+	Name       string
+	GameMode   string
+	Map        string
+	MaxPlayers int
+	Private    bool
+	// public rooms can still hand out a code to share
+	InviteCode bool
+}
+
+// no 0/O or 1/I so codes can be read out loud
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+const inviteCodeLength = 6
+
+/**
+* fills in defaults and validates the settings for a room that currently has
+* the given number of players.
+**/
+func validateRoomSettings(settings RoomSettings, currentPlayers int) (RoomSettings, error) {
+	settings.Name = strings.TrimSpace(settings.Name)
+	if settings.Name == "" {
+		return settings, ErrInvalidRoomName
+	}
+
+	mode, ok := game.ModeByName(settings.GameMode)
+	if !ok {
+		return settings, ErrUnknownGameMode
+	}
+	settings.GameMode = mode.Name
+
+	if settings.Map == "" {
+		settings.Map = game.DefaultMap
+	}
+	if !game.IsKnownMap(settings.Map) {
+		return settings, ErrUnknownMap
+	}
+
+	if settings.MaxPlayers == 0 {
+		settings.MaxPlayers = constants.DefaultRoomMaxPlayers
+	}
+
+	if settings.MaxPlayers < 1 || settings.MaxPlayers > constants.MaxRoomPlayers || settings.MaxPlayers < currentPlayers {
+		return settings, ErrInvalidMaxPlayers
+	}
+
+	return settings, nil
+}
+
+// NOTE: caller must hold the lock
+func (s *Server) newInviteCode() string {
+	for {
+		code := make([]byte, inviteCodeLength)
+		for i := range code {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(inviteCodeAlphabet))))
+			if err != nil {
+				panic(err)
+			}
+			code[i] = inviteCodeAlphabet[n.Int64()]
+		}
+
+		if _, taken := s.inviteCodes[string(code)]; !taken {
+			return string(code)
+		}
+	}
+}
+
+func (s *Server) JoinRoomByCode(code string, userID uuid.UUID) (*Room, error) {
+	s.mu.RLock()
+	roomID, exists := s.inviteCodes[strings.ToUpper(strings.TrimSpace(code))]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrInvalidInviteCode
+	}
+
+	return s.JoinRoom(roomID, userID)
+}
+
+/**
+* changes the room's settings. Everyone has to ready up again since the
+* match they agreed to changed.
+**/
+func (s *Server) UpdateRoomSettings(roomID, hostID uuid.UUID, settings RoomSettings) (*Room, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, err := s.hostedWaitingRoom(roomID, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err = validateRoomSettings(settings, len(room.Players))
+	if err != nil {
+		return nil, err
+	}
+
+	room.Name = settings.Name
+	room.GameMode = settings.GameMode
+	room.Map = settings.Map
+	room.MaxPlayers = settings.MaxPlayers
+	room.Private = settings.Private
+
+	needsCode := settings.Private || settings.InviteCode
+	if needsCode && room.InviteCode == "" {
+		room.InviteCode = s.newInviteCode()
+		s.inviteCodes[room.InviteCode] = room.ID
+	}
+	if !needsCode && room.InviteCode != "" {
+		delete(s.inviteCodes, room.InviteCode)
+		room.InviteCode = ""
+	}
+
+	for i := range room.Players {
+		room.Players[i].Ready = false
+	}
+
+	return room.clone(), nil
+}
+
+/**
+* removes a player from the room, they can't join it again.
+**/
+func (s *Server) KickFromRoom(roomID, hostID, targetID uuid.UUID) (*Room, error) {
+	if hostID == targetID {
+		return nil, ErrCannotKickSelf
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, err := s.hostedWaitingRoom(roomID, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !room.hasPlayer(targetID) {
+		return nil, ErrNotInRoom
+	}
+
+	remaining := make([]RoomPlayer, 0, len(room.Players))
+	for _, player := range room.Players {
+		if player.UserID != targetID {
+			remaining = append(remaining, player)
+		}
+	}
+
+	room.Players = remaining
+	room.kicked[targetID] = true
+	delete(s.playerRooms, targetID)
+
+	return room.clone(), nil
+}
+
+func (s *Server) SetReady(roomID, userID uuid.UUID, ready bool) (*Room, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomID]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+
+	if room.Status != RoomStatusWaiting {
+		return nil, ErrRoomNotWaiting
+	}
+
+	for i := range room.Players {
+		if room.Players[i].UserID == userID {
+			room.Players[i].Ready = ready
+			return room.clone(), nil
+		}
+	}
+
+	return nil, ErrNotInRoom
+}
+
+/**
+* starts the match on behalf of the host once every other player is ready.
+**/
+func (s *Server) StartRoomAsHost(roomID, hostID uuid.UUID) (*Room, error) {
+	s.mu.RLock()
+	room, err := s.hostedWaitingRoom(roomID, hostID)
+	allReady := true
+	if err == nil {
+		for _, player := range room.Players {
+			// the host starting the match counts as ready
+			if player.UserID != hostID && !player.Ready {
+				allReady = false
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !allReady {
+		return nil, ErrPlayersNotReady
+	}
+
+	return s.StartRoom(roomID)
+}
+
+// NOTE: caller must hold the lock
+func (s *Server) hostedWaitingRoom(roomID, hostID uuid.UUID) (*Room, error) {
+	room, exists := s.rooms[roomID]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+
+	if room.CreatorID != hostID {
+		return nil, ErrNotHost
+	}
+
+	if room.Status != RoomStatusWaiting {
+		return nil, ErrRoomNotWaiting
+	}
+
+	return room, nil
+}
+
+/**
+* pushes the room's current state to everyone in it.
+**/
+func (s *Server) BroadcastRoomUpdate(room *Room) {
+	players := make([]*types.Player, 0, len(room.Players))
+	for _, player := range room.Players {
+		players = append(players, &types.Player{ID: player.UserID})
+	}
+
+	messaging.NewMessageSender(s).BroadcastToPlayerList(players, types.Message{
+		Action:  string(constants.ActionLobbyUpdate),
+		Payload: RoomPayload(room),
+	})
+}
+
+/**
+* client facing form of a room.
+**/
+func RoomPayload(room *Room) map[string]interface{} {
+	players := make([]map[string]interface{}, 0, len(room.Players))
+	for _, player := range room.Players {
+		players = append(players, map[string]interface{}{
+			"player_id": player.UserID.String(),
+			"ready":     player.Ready,
+			"is_host":   player.UserID == room.CreatorID,
+		})
+	}
+
+	payload := map[string]interface{}{
+		"room_id":     room.ID.String(),
+		"name":        room.Name,
+		"host_id":     room.CreatorID.String(),
+		"game_mode":   room.GameMode,
+		"map":         room.Map,
+		"max_players": room.MaxPlayers,
+		"private":     room.Private,
+		"status":      string(room.Status),
+		"players":     players,
+	}
+
+	if room.InviteCode != "" {
+		payload["invite_code"] = room.InviteCode
+	}
+
+	if room.SessionID != uuid.Nil {
+		payload["session_id"] = room.SessionID.String()
+	}
+
+	return payload
+}
@@ -0,0 +1,203 @@
+package gameserver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing host controls and invite codes on lobbies.
+**/
+
+// TestPrivateLobbyInviteCode tests private lobbies are hidden and joined by code
+func TestPrivateLobbyInviteCode(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	hostID := uuid.New()
+	room, err := server.CreateRoomWithSettings(hostID, RoomSettings{Name: "friends only", Private: true})
+	require.NoError(t, err)
+	require.Len(t, room.InviteCode, inviteCodeLength)
+	assert.Equal(t, "derelict_station", room.Map)
+
+	rooms, total := server.ListRooms("", 0, 0)
+	assert.Empty(t, rooms)
+	assert.Equal(t, 0, total)
+
+	_, err = server.JoinRoomByCode("NOPE00", uuid.New())
+	assert.ErrorIs(t, err, ErrInvalidInviteCode)
+
+	// codes aren't case sensitive when typed in
+	joined, err := server.JoinRoomByCode(" "+strings.ToLower(room.InviteCode)+" ", uuid.New())
+	require.NoError(t, err)
+	assert.Len(t, joined.Players, 2)
+
+	// making the lobby public drops the code
+	updated, err := server.UpdateRoomSettings(room.ID, hostID, RoomSettings{Name: "everyone", MaxPlayers: 4})
+	require.NoError(t, err)
+	assert.False(t, updated.Private)
+	assert.Empty(t, updated.InviteCode)
+
+	_, err = server.JoinRoomByCode(room.InviteCode, uuid.New())
+	assert.ErrorIs(t, err, ErrInvalidInviteCode)
+
+	rooms, _ = server.ListRooms("", 0, 0)
+	assert.Len(t, rooms, 1)
+}
+
+// TestKickFromLobby tests only the host can kick and kicked players stay out
+func TestKickFromLobby(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	hostID, playerID := uuid.New(), uuid.New()
+	room, err := server.CreateRoom("kick test", hostID, 4, "")
+	require.NoError(t, err)
+	_, err = server.JoinRoom(room.ID, playerID)
+	require.NoError(t, err)
+
+	_, err = server.KickFromRoom(room.ID, playerID, hostID)
+	assert.ErrorIs(t, err, ErrNotHost)
+
+	_, err = server.KickFromRoom(room.ID, hostID, hostID)
+	assert.ErrorIs(t, err, ErrCannotKickSelf)
+
+	kicked, err := server.KickFromRoom(room.ID, hostID, playerID)
+	require.NoError(t, err)
+	assert.Len(t, kicked.Players, 1)
+
+	_, err = server.JoinRoom(room.ID, playerID)
+	assert.ErrorIs(t, err, ErrKickedFromRoom)
+}
+
+// TestStartLobbyRequiresReady tests the host can only start once everyone is ready
+func TestStartLobbyRequiresReady(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	hostID, playerID := uuid.New(), uuid.New()
+	room, err := server.CreateRoom("ready test", hostID, 4, "")
+	require.NoError(t, err)
+	_, err = server.JoinRoom(room.ID, playerID)
+	require.NoError(t, err)
+
+	_, err = server.StartRoomAsHost(room.ID, playerID)
+	assert.ErrorIs(t, err, ErrNotHost)
+
+	_, err = server.StartRoomAsHost(room.ID, hostID)
+	assert.ErrorIs(t, err, ErrPlayersNotReady)
+
+	_, err = server.SetReady(room.ID, playerID, true)
+	require.NoError(t, err)
+
+	// changing settings means everyone has to ready up again
+	_, err = server.UpdateRoomSettings(room.ID, hostID, RoomSettings{Name: "ready test", Map: "reactor_core"})
+	require.NoError(t, err)
+
+	_, err = server.StartRoomAsHost(room.ID, hostID)
+	assert.ErrorIs(t, err, ErrPlayersNotReady)
+
+	_, err = server.SetReady(room.ID, playerID, true)
+	require.NoError(t, err)
+
+	started, err := server.StartRoomAsHost(room.ID, hostID)
+	require.NoError(t, err)
+	assert.Equal(t, RoomStatusInProgress, started.Status)
+	assert.Equal(t, "reactor_core", started.Map)
+}
+
+// TestUpdateLobbySettingsValidation tests settings can't shrink below the current players
+func TestUpdateLobbySettingsValidation(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	hostID := uuid.New()
+	room, err := server.CreateRoom("settings", hostID, 4, "")
+	require.NoError(t, err)
+	_, err = server.JoinRoom(room.ID, uuid.New())
+	require.NoError(t, err)
+
+	_, err = server.UpdateRoomSettings(room.ID, hostID, RoomSettings{Name: "settings", MaxPlayers: 1})
+	assert.ErrorIs(t, err, ErrInvalidMaxPlayers)
+
+	_, err = server.UpdateRoomSettings(room.ID, hostID, RoomSettings{Name: "settings", Map: "the_moon"})
+	assert.ErrorIs(t, err, ErrUnknownMap)
+
+	_, err = server.UpdateRoomSettings(room.ID, hostID, RoomSettings{Name: "  "})
+	assert.ErrorIs(t, err, ErrInvalidRoomName)
+}
+
+// TestLobbyUpdatesOverWebsocket tests lobby changes are pushed to everyone in the lobby
+func TestLobbyUpdatesOverWebsocket(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	host := &types.Player{ID: uuid.New(), Username: "host"}
+	guest := &types.Player{ID: uuid.New(), Username: "guest"}
+	hostConn, guestConn := &websocket.Conn{}, &websocket.Conn{}
+	hostCh := registerTestConn(server, hostConn, host)
+	guestCh := registerTestConn(server, guestConn, guest)
+
+	server.serverChan <- types.ClientPackage{
+		Conn: hostConn,
+		Message: types.Message{
+			Action:  string(constants.ActionCreateLobby),
+			Payload: map[string]interface{}{"name": "ws lobby", "private": true},
+		},
+	}
+
+	created := waitForAction(t, hostCh, constants.ActionCreateLobby)
+	require.Equal(t, true, created.Payload["success"])
+	lobby := created.Payload["lobby"].(map[string]interface{})
+
+	// the host alone in the new lobby
+	update := waitForAction(t, hostCh, constants.ActionLobbyUpdate)
+	assert.Len(t, update.Payload["players"], 1)
+
+	server.serverChan <- types.ClientPackage{
+		Conn: guestConn,
+		Message: types.Message{
+			Action:  string(constants.ActionJoinLobby),
+			Payload: map[string]interface{}{"invite_code": lobby["invite_code"]},
+		},
+	}
+
+	joined := waitForAction(t, guestCh, constants.ActionJoinLobby)
+	require.Equal(t, true, joined.Payload["success"])
+
+	update = waitForAction(t, hostCh, constants.ActionLobbyUpdate)
+	assert.Len(t, update.Payload["players"], 2)
+
+	// guests can't start the match
+	server.serverChan <- types.ClientPackage{
+		Conn: guestConn,
+		Message: types.Message{
+			Action:  string(constants.ActionStartLobby),
+			Payload: map[string]interface{}{"room_id": lobby["room_id"]},
+		},
+	}
+
+	rejected := waitForAction(t, guestCh, constants.ActionStartLobby)
+	assert.Equal(t, false, rejected.Payload["success"])
+	assert.Equal(t, string(constants.ErrorNotHost), rejected.Payload["reason"])
+}
+
+func waitForAction(t *testing.T, ch chan types.Message, action constants.Action) types.Message {
+	t.Helper()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case msg := <-ch:
+			if msg.Action == string(action) {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s", action)
+			return types.Message{}
+		}
+	}
+}
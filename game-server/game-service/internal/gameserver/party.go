@@ -0,0 +1,312 @@
+package gameserver
+
+import (
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Parties
+*
+* Players group up before queueing. The leader invites players and queues
+* the whole party, which is matched as one and put on the same team. Any
+* change to who is in the party takes it out of the queue. When the leader
+* leaves or disconnects the longest standing member takes over.
+**/
+
+type Party struct {
+	ID       uuid.UUID
+	LeaderID uuid.UUID
+	// in the order they joined
+	Members   []uuid.UUID
+	CreatedAt time.Time
+
+	// [playerId] to when their invite expires
+	invites map[uuid.UUID]time.Time
+}
+
+// copies the party so callers can read it without holding the server lock
+func (p *Party) clone() *Party {
+	clone := *p
+	clone.Members = make([]uuid.UUID, len(p.Members))
+	copy(clone.Members, p.Members)
+	clone.invites = nil
+	return &clone
+}
+
+func (p *Party) hasMember(playerID uuid.UUID) bool {
+	for _, member := range p.Members {
+		if member == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+* invites a player to the inviter's party, creating the party with the
+* inviter as leader if they aren't in one.
+**/
+func (s *Server) InviteToParty(inviterID, targetID uuid.UUID) (*Party, error) {
+	if inviterID == targetID {
+		return nil, ErrInviteSelf
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	party, inParty := s.partyOf(inviterID)
+	if !inParty {
+		party = &Party{
+			ID:        uuid.New(),
+			LeaderID:  inviterID,
+			Members:   []uuid.UUID{inviterID},
+			CreatedAt: time.Now(),
+			invites:   make(map[uuid.UUID]time.Time),
+		}
+		s.parties[party.ID] = party
+		s.playerParties[inviterID] = party.ID
+	}
+
+	if party.LeaderID != inviterID {
+		return nil, ErrNotPartyLeader
+	}
+
+	if party.hasMember(targetID) {
+		return nil, ErrAlreadyInParty
+	}
+
+	if len(party.Members) >= constants.MaxPartySize {
+		return nil, ErrPartyFull
+	}
+
+	party.invites[targetID] = time.Now().Add(constants.PartyInviteTimeout)
+
+	return party.clone(), nil
+}
+
+func (s *Server) AcceptPartyInvite(partyID, playerID uuid.UUID) (*Party, error) {
+	s.mu.Lock()
+	party, err := s.joinParty(partyID, playerID)
+	s.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the queued party no longer matches who is in it
+	s.matchmaker.RemovePlayer(&types.Player{ID: party.LeaderID})
+
+	return party, nil
+}
+
+// NOTE: caller must hold the lock
+func (s *Server) joinParty(partyID, playerID uuid.UUID) (*Party, error) {
+	party, exists := s.parties[partyID]
+	if !exists {
+		return nil, ErrPartyNotFound
+	}
+
+	expiresAt, invited := party.invites[playerID]
+	if !invited || time.Now().After(expiresAt) {
+		delete(party.invites, playerID)
+		return nil, ErrNoPartyInvite
+	}
+
+	if _, inParty := s.playerParties[playerID]; inParty {
+		return nil, ErrAlreadyInParty
+	}
+
+	if len(party.Members) >= constants.MaxPartySize {
+		return nil, ErrPartyFull
+	}
+
+	delete(party.invites, playerID)
+	party.Members = append(party.Members, playerID)
+	s.playerParties[playerID] = party.ID
+
+	return party.clone(), nil
+}
+
+func (s *Server) DeclinePartyInvite(partyID, playerID uuid.UUID) (*Party, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	party, exists := s.parties[partyID]
+	if !exists {
+		return nil, ErrPartyNotFound
+	}
+
+	if _, invited := party.invites[playerID]; !invited {
+		return nil, ErrNoPartyInvite
+	}
+
+	delete(party.invites, playerID)
+
+	return party.clone(), nil
+}
+
+/**
+* removes the player from their party, handing leadership to the longest
+* standing member. Returns the party as it is after they left, with no
+* members once the last one leaves.
+**/
+func (s *Server) LeaveParty(playerID uuid.UUID) (*Party, error) {
+	s.mu.Lock()
+	party, leaderID, err := s.removeFromParty(playerID)
+	s.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the party was queued under the leader it had before
+	s.matchmaker.RemovePlayer(&types.Player{ID: leaderID})
+
+	return party, nil
+}
+
+/**
+* takes the player out of their party, also returning who led it before.
+**/
+// NOTE: caller must hold the lock
+func (s *Server) removeFromParty(playerID uuid.UUID) (*Party, uuid.UUID, error) {
+	party, inParty := s.partyOf(playerID)
+	if !inParty {
+		return nil, uuid.Nil, ErrNotInParty
+	}
+
+	leaderID := party.LeaderID
+
+	remaining := make([]uuid.UUID, 0, len(party.Members))
+	for _, member := range party.Members {
+		if member != playerID {
+			remaining = append(remaining, member)
+		}
+	}
+
+	party.Members = remaining
+	delete(s.playerParties, playerID)
+
+	if len(party.Members) == 0 {
+		delete(s.parties, party.ID)
+		return party.clone(), leaderID, nil
+	}
+
+	if party.LeaderID == playerID {
+		party.LeaderID = party.Members[0]
+	}
+
+	return party.clone(), leaderID, nil
+}
+
+func (s *Server) GetPlayerParty(playerID uuid.UUID) (*Party, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	party, inParty := s.partyOf(playerID)
+	if !inParty {
+		return nil, false
+	}
+
+	return party.clone(), true
+}
+
+/**
+* the players the leader queues with, all of them have to be online.
+**/
+// NOTE: caller must hold the lock
+func (s *Server) partyQueueGroup(player *types.Player) ([]*types.Player, error) {
+	party, inParty := s.partyOf(player.ID)
+	if !inParty {
+		return []*types.Player{player}, nil
+	}
+
+	if party.LeaderID != player.ID {
+		return nil, ErrNotPartyLeader
+	}
+
+	online := make(map[uuid.UUID]*types.Player, len(s.connToPlayer))
+	for _, connected := range s.connToPlayer {
+		online[connected.ID] = connected
+	}
+
+	group := make([]*types.Player, 0, len(party.Members))
+	for _, memberID := range party.Members {
+		member, connected := online[memberID]
+		if !connected {
+			return nil, ErrPartyMemberOffline
+		}
+
+		queued := *member
+		queued.PartyID = party.ID
+		group = append(group, &queued)
+	}
+
+	return group, nil
+}
+
+// NOTE: caller must hold the lock
+func (s *Server) partyOf(playerID uuid.UUID) (*Party, bool) {
+	partyID, inParty := s.playerParties[playerID]
+	if !inParty {
+		return nil, false
+	}
+
+	party, exists := s.parties[partyID]
+	return party, exists
+}
+
+/**
+* takes a disconnected player out of their party so the rest can carry on.
+**/
+func (s *Server) leavePartyOnDisconnect(playerID uuid.UUID) {
+	party, err := s.LeaveParty(playerID)
+	if err != nil {
+		return
+	}
+
+	s.BroadcastPartyUpdate(party)
+}
+
+/**
+* pushes the party's current state to its members.
+**/
+func (s *Server) BroadcastPartyUpdate(party *Party) {
+	members := make([]*types.Player, 0, len(party.Members))
+	for _, member := range party.Members {
+		members = append(members, &types.Player{ID: member})
+	}
+
+	messaging.NewMessageSender(s).BroadcastToPlayerList(members, types.Message{
+		Action:  string(constants.ActionPartyUpdate),
+		Payload: s.PartyPayload(party),
+	})
+}
+
+/**
+* client facing form of a party, including the queue it's waiting in.
+**/
+func (s *Server) PartyPayload(party *Party) map[string]interface{} {
+	members := make([]string, 0, len(party.Members))
+	for _, member := range party.Members {
+		members = append(members, member.String())
+	}
+
+	payload := map[string]interface{}{
+		"party_id":  party.ID.String(),
+		"leader_id": party.LeaderID.String(),
+		"members":   members,
+		"max_size":  constants.MaxPartySize,
+	}
+
+	if queueID, queued := s.matchmaker.QueueOf(party.LeaderID); queued {
+		payload["queue_id"] = queueID
+	}
+
+	return payload
+}
@@ -0,0 +1,244 @@
+package gameserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing parties forming, changing leaders and queueing together.
+**/
+
+func newPartyPlayer(t *testing.T, server *Server, name string) (*types.Player, *websocket.Conn, chan types.Message) {
+	t.Helper()
+
+	player := &types.Player{ID: uuid.New(), Username: name}
+	conn := &websocket.Conn{}
+	return player, conn, registerTestConn(server, conn, player)
+}
+
+// TestPartyInviteAndAccept tests inviting creates the party and accepting joins it
+func TestPartyInviteAndAccept(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	leader, _, _ := newPartyPlayer(t, server, "leader")
+	friend, _, _ := newPartyPlayer(t, server, "friend")
+
+	party, err := server.InviteToParty(leader.ID, friend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, leader.ID, party.LeaderID)
+	assert.Equal(t, []uuid.UUID{leader.ID}, party.Members)
+
+	_, err = server.AcceptPartyInvite(party.ID, uuid.New())
+	assert.ErrorIs(t, err, ErrNoPartyInvite)
+
+	party, err = server.AcceptPartyInvite(party.ID, friend.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{leader.ID, friend.ID}, party.Members)
+
+	// only the leader invites
+	_, err = server.InviteToParty(friend.ID, uuid.New())
+	assert.ErrorIs(t, err, ErrNotPartyLeader)
+
+	_, err = server.InviteToParty(leader.ID, leader.ID)
+	assert.ErrorIs(t, err, ErrInviteSelf)
+}
+
+// TestPartyFull tests a party can't grow past the max size
+func TestPartyFull(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	leader, _, _ := newPartyPlayer(t, server, "leader")
+
+	var party *Party
+	for i := 1; i < constants.MaxPartySize; i++ {
+		member := uuid.New()
+
+		var err error
+		party, err = server.InviteToParty(leader.ID, member)
+		require.NoError(t, err)
+		_, err = server.AcceptPartyInvite(party.ID, member)
+		require.NoError(t, err)
+	}
+
+	_, err := server.InviteToParty(leader.ID, uuid.New())
+	assert.ErrorIs(t, err, ErrPartyFull)
+}
+
+// TestPartyLeaderHandover tests the longest standing member leads once the leader is gone
+func TestPartyLeaderHandover(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	leader, _, _ := newPartyPlayer(t, server, "leader")
+	second, _, secondCh := newPartyPlayer(t, server, "second")
+	third, _, _ := newPartyPlayer(t, server, "third")
+
+	for _, member := range []*types.Player{second, third} {
+		party, err := server.InviteToParty(leader.ID, member.ID)
+		require.NoError(t, err)
+		_, err = server.AcceptPartyInvite(party.ID, member.ID)
+		require.NoError(t, err)
+	}
+
+	party, err := server.LeaveParty(leader.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, party.LeaderID)
+	assert.Equal(t, []uuid.UUID{second.ID, third.ID}, party.Members)
+
+	_, inParty := server.GetPlayerParty(leader.ID)
+	assert.False(t, inParty)
+
+	// disconnecting leaves the party too
+	server.leavePartyOnDisconnect(second.ID)
+	party, inParty = server.GetPlayerParty(third.ID)
+	require.True(t, inParty)
+	assert.Equal(t, third.ID, party.LeaderID)
+	assert.Empty(t, secondCh)
+
+	_, err = server.LeaveParty(third.ID)
+	require.NoError(t, err)
+
+	server.mu.RLock()
+	assert.Empty(t, server.parties)
+	server.mu.RUnlock()
+}
+
+// TestPartyQueuesTogether tests the leader queues the whole party as one
+func TestPartyQueuesTogether(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	leader, _, _ := newPartyPlayer(t, server, "leader")
+	friend, _, _ := newPartyPlayer(t, server, "friend")
+
+	party, err := server.InviteToParty(leader.ID, friend.ID)
+	require.NoError(t, err)
+	_, err = server.AcceptPartyInvite(party.ID, friend.ID)
+	require.NoError(t, err)
+
+	_, err = server.AddPlayerToQueue("coop_squad", friend)
+	assert.ErrorIs(t, err, ErrNotPartyLeader)
+
+	queueID, err := server.AddPlayerToQueue("coop_squad", leader)
+	require.NoError(t, err)
+
+	friendQueue, queued := server.matchmaker.QueueOf(friend.ID)
+	require.True(t, queued)
+	assert.Equal(t, queueID, friendQueue)
+
+	payload := server.PartyPayload(party)
+	assert.Equal(t, queueID, payload["queue_id"])
+
+	// a new member takes the party out of the queue
+	newcomer, _, _ := newPartyPlayer(t, server, "newcomer")
+	_, err = server.InviteToParty(leader.ID, newcomer.ID)
+	require.NoError(t, err)
+	_, err = server.AcceptPartyInvite(party.ID, newcomer.ID)
+	require.NoError(t, err)
+
+	_, queued = server.matchmaker.QueueOf(friend.ID)
+	assert.False(t, queued)
+}
+
+// TestPartyMemberOffline tests a party can't queue with a member offline
+func TestPartyMemberOffline(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	leader, _, _ := newPartyPlayer(t, server, "leader")
+	offline := uuid.New()
+
+	party, err := server.InviteToParty(leader.ID, offline)
+	require.NoError(t, err)
+	_, err = server.AcceptPartyInvite(party.ID, offline)
+	require.NoError(t, err)
+
+	_, err = server.AddPlayerToQueue("coop_squad", leader)
+	assert.ErrorIs(t, err, ErrPartyMemberOffline)
+}
+
+// TestPartyOverWebsocket tests invites and updates reach the players involved
+func TestPartyOverWebsocket(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	leader, leaderConn, leaderCh := newPartyPlayer(t, server, "leader")
+	friend, friendConn, friendCh := newPartyPlayer(t, server, "friend")
+
+	server.serverChan <- types.ClientPackage{
+		Conn: leaderConn,
+		Message: types.Message{
+			Action:  string(constants.ActionInviteParty),
+			Payload: map[string]interface{}{"target_id": friend.ID.String()},
+		},
+	}
+
+	invite := waitForAction(t, friendCh, constants.ActionPartyInvite)
+	assert.Equal(t, leader.ID.String(), invite.Payload["from_id"])
+
+	// the party was created by the invite
+	created := waitForAction(t, leaderCh, constants.ActionPartyUpdate)
+	assert.Len(t, created.Payload["members"], 1)
+
+	server.serverChan <- types.ClientPackage{
+		Conn: friendConn,
+		Message: types.Message{
+			Action:  string(constants.ActionAcceptPartyInvite),
+			Payload: map[string]interface{}{"party_id": invite.Payload["party_id"]},
+		},
+	}
+
+	reply := waitForAction(t, friendCh, constants.ActionAcceptPartyInvite)
+	assert.Equal(t, true, reply.Payload["success"])
+
+	update := waitForAction(t, leaderCh, constants.ActionPartyUpdate)
+	assert.Len(t, update.Payload["members"], 2)
+
+	server.serverChan <- types.ClientPackage{
+		Conn:    friendConn,
+		Message: types.Message{Action: string(constants.ActionFindGame)},
+	}
+
+	failed := waitForAction(t, friendCh, constants.ActionFindGame)
+	assert.Equal(t, false, failed.Payload["success"])
+	assert.Equal(t, string(constants.ErrorNotPartyLeader), failed.Payload["reason"])
+}
+
+// matchmaking that reads the server back while removing players, as shared
+// queues waiting on another instance would
+type lockCheckingMatchmaking struct {
+	Matchmaking
+	server *Server
+}
+
+func (m *lockCheckingMatchmaking) RemovePlayer(player *types.Player) bool {
+	m.server.GetPlayerParty(player.ID)
+	return m.Matchmaking.RemovePlayer(player)
+}
+
+// TestPartyChangesReleaseLockForMatchmaking tests party changes don't hold the server lock while dequeuing
+func TestPartyChangesReleaseLockForMatchmaking(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	server.UseMatchmaking(&lockCheckingMatchmaking{Matchmaking: server.matchmaker, server: server})
+
+	leader, _, _ := newPartyPlayer(t, server, "leader")
+	friend, _, _ := newPartyPlayer(t, server, "friend")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		party, err := server.InviteToParty(leader.ID, friend.ID)
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = server.AcceptPartyInvite(party.ID, friend.ID)
+		assert.NoError(t, err)
+		_, err = server.LeaveParty(leader.ID)
+		assert.NoError(t, err)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("party changes deadlocked with matchmaking")
+	}
+}
@@ -0,0 +1,127 @@
+package gameserver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+)
+
+/**
+* Matchmaking queues players can pick from. The first queue is where players
+* that don't pick one end up.
+**/
+func DefaultQueues() []systems.QueueConfig {
+	return []systems.QueueConfig{
+		{
+			ID:            "coop_duo",
+			GameMode:      game.ModeCoop.Name,
+			MatchSize:     2,
+			MinPlayers:    2,
+			MaxPlayers:    4,
+			TeamCount:     game.ModeCoop.Teams,
+			BotFill:       45 * time.Second,
+			BotDifficulty: "normal",
+		},
+		{
+			ID:          "coop_squad",
+			GameMode:    game.ModeCoop.Name,
+			MatchSize:   4,
+			MinPlayers:  2,
+			FillTimeout: 30 * time.Second,
+			MaxPlayers:  4,
+			TeamCount:   game.ModeCoop.Teams,
+		},
+		{
+			ID:            "tdm_1v1",
+			GameMode:      game.ModeTeamDeathmatch.Name,
+			MatchSize:     2,
+			MinPlayers:    2,
+			MaxPlayers:    2,
+			TeamCount:     game.ModeTeamDeathmatch.Teams,
+			Rating:        ratingWindow(),
+			BotFill:       90 * time.Second,
+			BotDifficulty: "normal",
+		},
+		{
+			ID:          "tdm_4v4",
+			GameMode:    game.ModeTeamDeathmatch.Name,
+			MatchSize:   8,
+			MinPlayers:  6,
+			FillTimeout: 60 * time.Second,
+			MaxPlayers:  8,
+			TeamCount:   game.ModeTeamDeathmatch.Teams,
+			Rating:      ratingWindow(),
+		},
+	}
+}
+
+func ratingWindow() *systems.RatingWindow {
+	window := systems.DefaultRatingWindow()
+	return &window
+}
+
+/**
+* client facing form of a queue's stats.
+**/
+func QueueStatsPayload(stats systems.QueueStats) map[string]interface{} {
+	return map[string]interface{}{
+		"queue_id":          stats.QueueID,
+		"game_mode":         stats.GameMode,
+		"match_size":        stats.MatchSize,
+		"min_players":       stats.MinPlayers,
+		"max_players":       stats.MaxPlayers,
+		"waiting":           stats.Waiting,
+		"longest_wait_secs": int(stats.LongestWait.Seconds()),
+	}
+}
+
+/**
+* replaces the server's matchmaking, e.g. with queues shared between game
+* service instances. The new matchmaking has to report on the channels the
+* server already hands out. The matchmaking it replaces stops its loops.
+**/
+// NOTE: call before serving players
+func (s *Server) UseMatchmaking(matchmaker Matchmaking) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := s.matchmaker
+	s.matchmaker = matchmaker
+
+	if stopper, ok := previous.(interface{ Stop() }); ok && previous != matchmaker {
+		stopper.Stop()
+	}
+}
+
+/**
+* number of sessions running on this instance.
+**/
+func (s *Server) SessionCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.sessions)
+}
+
+/**
+* tells the match's players connected here that another instance hosts their
+* match, they reconnect at addr to answer its ready check.
+**/
+func (s *Server) RedirectMatch(match systems.Match, addr string) {
+	fmt.Printf("Match from queue %s is hosted at %s, redirecting players\n", match.QueueID, addr)
+
+	// players connected to other instances are skipped
+	messaging.NewMessageSender(s).BroadcastToPlayerList(match.Humans(), types.Message{
+		Action: string(constants.ActionMatchRedirect),
+		Payload: map[string]interface{}{
+			"queue_id":  match.QueueID,
+			"game_mode": match.GameMode,
+			"node_addr": addr,
+		},
+	})
+}
@@ -0,0 +1,281 @@
+package gameserver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Ready checks
+*
+* Matched players have to accept before their session is created. The game
+* starts once everyone accepts. A decline ends the check right away and a
+* timeout ends it for whoever didn't answer, either way those players can't
+* queue for a while and everyone else goes back to the front of the queue.
+* Bots accept right away and are dropped when a check fails.
+**/
+
+type ReadyCheck struct {
+	ID        uuid.UUID
+	Match     systems.Match
+	ExpiresAt time.Time
+
+	// [playerId] to whether they accepted, players that haven't answered
+	// are missing
+	responses map[uuid.UUID]bool
+	timer     *time.Timer
+}
+
+func (c *ReadyCheck) hasPlayer(playerID uuid.UUID) bool {
+	for _, player := range c.Match.Players {
+		if player.ID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ReadyCheck) acceptedCount() int {
+	accepted := 0
+	for _, answer := range c.responses {
+		if answer {
+			accepted++
+		}
+	}
+	return accepted
+}
+
+/**
+* asks every matched player to accept the match.
+**/
+func (s *Server) StartReadyCheck(match systems.Match) *ReadyCheck {
+	check := &ReadyCheck{
+		ID:        uuid.New(),
+		Match:     match,
+		ExpiresAt: time.Now().Add(s.readyCheckTimeout),
+		responses: make(map[uuid.UUID]bool, len(match.Players)),
+	}
+
+	for _, player := range match.Players {
+		if player.Bot {
+			check.responses[player.ID] = true
+		}
+	}
+
+	s.mu.Lock()
+	s.readyChecks[check.ID] = check
+	check.timer = time.AfterFunc(s.readyCheckTimeout, func() {
+		s.expireReadyCheck(check.ID)
+	})
+	s.mu.Unlock()
+
+	messaging.NewMessageSender(s).BroadcastToPlayerList(match.Humans(), types.Message{
+		Action:  string(constants.ActionReadyCheck),
+		Payload: readyCheckPayload(check),
+	})
+
+	return check
+}
+
+/**
+* sends the player's pending ready check again, for players that connect
+* after it started such as ones redirected from another instance.
+**/
+func (s *Server) resendReadyCheck(playerID uuid.UUID) {
+	s.mu.RLock()
+	var pending *ReadyCheck
+	for _, check := range s.readyChecks {
+		if check.hasPlayer(playerID) {
+			pending = check
+			break
+		}
+	}
+	s.mu.RUnlock()
+
+	if pending == nil {
+		return
+	}
+
+	messaging.NewMessageSender(s).SendToPlayer(playerID, types.Message{
+		Action:  string(constants.ActionReadyCheck),
+		Payload: readyCheckPayload(pending),
+	})
+}
+
+func readyCheckPayload(check *ReadyCheck) map[string]interface{} {
+	payload := map[string]interface{}{
+		"check_id":     check.ID.String(),
+		"queue_id":     check.Match.QueueID,
+		"game_mode":    check.Match.GameMode,
+		"players":      len(check.Match.Players),
+		"timeout_secs": int(time.Until(check.ExpiresAt).Round(time.Second).Seconds()),
+	}
+
+	// joining a game that's already being played
+	if check.Match.SessionID != uuid.Nil {
+		payload["backfill"] = true
+		payload["session_id"] = check.Match.SessionID.String()
+	}
+
+	return payload
+}
+
+/**
+* records a player's answer, starting the game once everyone accepted.
+**/
+func (s *Server) RespondToReadyCheck(checkID, playerID uuid.UUID, accept bool) error {
+	s.mu.Lock()
+	check, exists := s.readyChecks[checkID]
+	if !exists {
+		s.mu.Unlock()
+		return ErrReadyCheckNotFound
+	}
+
+	if !check.hasPlayer(playerID) {
+		s.mu.Unlock()
+		return ErrNotInReadyCheck
+	}
+
+	check.responses[playerID] = accept
+	accepted := check.acceptedCount()
+
+	finished := !accept || accepted == len(check.Match.Players)
+	if finished {
+		check.timer.Stop()
+		delete(s.readyChecks, checkID)
+	}
+	s.mu.Unlock()
+
+	switch {
+	case !accept:
+		s.failReadyCheck(check, "declined")
+	case finished:
+		s.startReadyCheckMatch(check)
+	default:
+		messaging.NewMessageSender(s).BroadcastToPlayerList(check.Match.Humans(), types.Message{
+			Action: string(constants.ActionReadyCheckUpdate),
+			Payload: map[string]interface{}{
+				"check_id": check.ID.String(),
+				"accepted": accepted,
+				"players":  len(check.Match.Players),
+			},
+		})
+	}
+
+	return nil
+}
+
+func (s *Server) expireReadyCheck(checkID uuid.UUID) {
+	s.mu.Lock()
+	check, exists := s.readyChecks[checkID]
+	if exists {
+		delete(s.readyChecks, checkID)
+	}
+	s.mu.Unlock()
+
+	// already finished
+	if !exists {
+		return
+	}
+
+	s.failReadyCheck(check, "timeout")
+}
+
+/**
+* penalizes the players at fault and requeues the rest. Players that decline
+* are at fault, and on a timeout so is everyone that didn't answer.
+**/
+// NOTE: the check must already be removed from the server
+func (s *Server) failReadyCheck(check *ReadyCheck, reason string) {
+	requeued := make([]*types.Player, 0, len(check.Match.Players))
+
+	atFault := make(map[uuid.UUID]bool, len(check.Match.Players))
+	// a party only queues as a whole, so it stays out if any member is at fault
+	faultParties := make(map[uuid.UUID]bool)
+	for _, player := range check.Match.Players {
+		accepted, answered := check.responses[player.ID]
+
+		if (answered && !accepted) || (!answered && reason == "timeout") {
+			atFault[player.ID] = true
+			if player.PartyID != uuid.Nil {
+				faultParties[player.PartyID] = true
+			}
+		}
+	}
+
+	sender := messaging.NewMessageSender(s)
+
+	for _, player := range check.Match.Humans() {
+		requeue := !atFault[player.ID] && !faultParties[player.PartyID]
+
+		if atFault[player.ID] {
+			s.matchmaker.Penalize(player.ID, s.readyCheckPenalty)
+		}
+		if requeue {
+			requeued = append(requeued, player)
+		}
+
+		payload := map[string]interface{}{
+			"check_id": check.ID.String(),
+			"reason":   reason,
+			"requeued": requeue,
+		}
+		if atFault[player.ID] {
+			payload["penalty_secs"] = int(s.readyCheckPenalty.Seconds())
+		}
+
+		sender.SendToPlayer(player.ID, types.Message{
+			Action:  string(constants.ActionReadyCheckFailed),
+			Payload: payload,
+		})
+	}
+
+	if err := s.matchmaker.RequeueFront(check.Match, requeued); err != nil {
+		fmt.Printf("Failed to requeue players from ready check %s: %v\n", check.ID, err)
+	}
+
+	// the slots they were taking are open again
+	if check.Match.SessionID != uuid.Nil {
+		s.syncBackfill(check.Match.SessionID)
+	}
+}
+
+func (s *Server) startReadyCheckMatch(check *ReadyCheck) {
+	if check.Match.SessionID != uuid.Nil {
+		s.joinBackfill(check)
+		return
+	}
+
+	mode, ok := game.ModeByName(check.Match.GameMode)
+	if !ok {
+		fmt.Printf("Queue %s has unknown game mode %s, using the default\n", check.Match.QueueID, check.Match.GameMode)
+		mode = game.DefaultGameMode()
+	}
+
+	session, err := s.CreateGameSessionWithMode(check.Match.Players, mode)
+	if err != nil {
+		// nobody is at fault, everyone goes back to the front of the queue
+		fmt.Printf("Failed to start the match from ready check %s: %v\n", check.ID, err)
+		s.failReadyCheck(check, "server_full")
+		return
+	}
+
+	if mode.Backfill {
+		s.openBackfill(session, check.Match.QueueID)
+	}
+
+	messaging.NewMessageSender(s).BroadcastToPlayerList(check.Match.Humans(), types.Message{
+		Action: string(constants.ActionGameFound),
+		Payload: map[string]any{
+			"session_id": session.ID.String(),
+			"queue_id":   check.Match.QueueID,
+		},
+	})
+}
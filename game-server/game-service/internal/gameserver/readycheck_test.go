@@ -0,0 +1,248 @@
+package gameserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing matched players accepting, declining and missing ready checks.
+**/
+
+func newReadyCheckMatch(t *testing.T, server *Server, count int) (systems.Match, []chan types.Message) {
+	t.Helper()
+
+	match := systems.Match{
+		QueueID:  "coop_duo",
+		GameMode: "coop",
+		JoinedAt: make(map[uuid.UUID]time.Time),
+	}
+
+	channels := make([]chan types.Message, 0, count)
+	for i := 0; i < count; i++ {
+		player := &types.Player{ID: uuid.New(), Username: "player"}
+		channels = append(channels, registerTestConn(server, &websocket.Conn{}, player))
+		match.Players = append(match.Players, player)
+		match.JoinedAt[player.ID] = time.Now().Add(-time.Minute)
+	}
+
+	return match, channels
+}
+
+// TestReadyCheckAccepted tests the game starts once everyone accepts
+func TestReadyCheckAccepted(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	match, channels := newReadyCheckMatch(t, server, 2)
+
+	check := server.StartReadyCheck(match)
+	waitForAction(t, channels[0], constants.ActionReadyCheck)
+
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[0].ID, true))
+	update := waitForAction(t, channels[1], constants.ActionReadyCheckUpdate)
+	assert.Equal(t, 1, update.Payload["accepted"])
+
+	// outsiders can't answer for the match
+	assert.ErrorIs(t, server.RespondToReadyCheck(check.ID, uuid.New(), true), ErrNotInReadyCheck)
+
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[1].ID, true))
+	found := waitForAction(t, channels[0], constants.ActionGameFound)
+
+	sessionID, err := uuid.Parse(found.Payload["session_id"].(string))
+	require.NoError(t, err)
+	_, exists := server.GetGameSession(sessionID)
+	assert.True(t, exists)
+
+	assert.ErrorIs(t, server.RespondToReadyCheck(check.ID, match.Players[0].ID, true), ErrReadyCheckNotFound)
+}
+
+// TestReadyCheckDeclined tests decliners are penalized and the rest requeued first
+func TestReadyCheckDeclined(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	waiting := &types.Player{ID: uuid.New(), Username: "waiting"}
+	_, err := server.AddPlayerToQueue("coop_duo", waiting)
+	require.NoError(t, err)
+
+	match, channels := newReadyCheckMatch(t, server, 2)
+	check := server.StartReadyCheck(match)
+
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[0].ID, true))
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[1].ID, false))
+
+	failed := waitForAction(t, channels[1], constants.ActionReadyCheckFailed)
+	assert.Equal(t, "declined", failed.Payload["reason"])
+	assert.Equal(t, false, failed.Payload["requeued"])
+
+	_, err = server.AddPlayerToQueue("coop_duo", match.Players[1])
+	assert.ErrorIs(t, err, systems.ErrQueuePenalty)
+
+	// the player who accepted is back in front of the player already waiting
+	queue, _ := server.matchmaker.(*systems.Matchmaker).Queue("coop_duo")
+	stats := queue.Stats()
+	assert.Equal(t, 2, stats.Waiting)
+	assert.GreaterOrEqual(t, stats.LongestWait, time.Minute)
+}
+
+// TestReadyCheckTimeout tests players who never answer are penalized
+func TestReadyCheckTimeout(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	server.readyCheckTimeout = 50 * time.Millisecond
+
+	match, channels := newReadyCheckMatch(t, server, 2)
+	check := server.StartReadyCheck(match)
+
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[0].ID, true))
+
+	accepted := waitForAction(t, channels[0], constants.ActionReadyCheckFailed)
+	assert.Equal(t, "timeout", accepted.Payload["reason"])
+	assert.Equal(t, true, accepted.Payload["requeued"])
+
+	missed := waitForAction(t, channels[1], constants.ActionReadyCheckFailed)
+	assert.Equal(t, false, missed.Payload["requeued"])
+
+	assert.Greater(t, server.matchmaker.PenaltyRemaining(match.Players[1].ID), time.Duration(0))
+	assert.Zero(t, server.matchmaker.PenaltyRemaining(match.Players[0].ID))
+
+	queueID, queued := server.matchmaker.QueueOf(match.Players[0].ID)
+	assert.True(t, queued)
+	assert.Equal(t, "coop_duo", queueID)
+}
+
+// TestReadyCheckWithBots tests bots accept on their own and never go back in the queue
+func TestReadyCheckWithBots(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	match, channels := newReadyCheckMatch(t, server, 1)
+	bot := systems.NewBotPlayer("easy", 1)
+	match.Players = append(match.Players, bot)
+
+	check := server.StartReadyCheck(match)
+	started := waitForAction(t, channels[0], constants.ActionReadyCheck)
+	assert.Equal(t, 2, started.Payload["players"])
+
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[0].ID, true))
+	found := waitForAction(t, channels[0], constants.ActionGameFound)
+
+	sessionID, err := uuid.Parse(found.Payload["session_id"].(string))
+	require.NoError(t, err)
+	session, exists := server.GetGameSession(sessionID)
+	require.True(t, exists)
+	assert.True(t, session.IsBot(bot.ID))
+	assert.Equal(t, []uuid.UUID{match.Players[0].ID}, session.HumanIDs())
+
+	// a declined check only puts the humans who accepted back
+	match, channels = newReadyCheckMatch(t, server, 2)
+	match.Players = append(match.Players, systems.NewBotPlayer("easy", 1))
+	check = server.StartReadyCheck(match)
+
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[0].ID, true))
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[1].ID, false))
+	waitForAction(t, channels[0], constants.ActionReadyCheckFailed)
+
+	queue, _ := server.matchmaker.(*systems.Matchmaker).Queue("coop_duo")
+	assert.Equal(t, 1, queue.Stats().Waiting)
+}
+
+// TestLeaveQueue tests leave_queue takes the player out of their queue
+func TestLeaveQueue(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "leaver"}
+	conn := &websocket.Conn{}
+	msgCh := registerTestConn(server, conn, player)
+
+	_, err := server.AddPlayerToQueue("", player)
+	require.NoError(t, err)
+	assert.True(t, server.RemovePlayerFromQueue(player))
+
+	server.serverChan <- types.ClientPackage{
+		Conn:    conn,
+		Message: types.Message{Action: string(constants.ActionLeaveQueue)},
+	}
+
+	reply := waitForAction(t, msgCh, constants.ActionLeaveQueue)
+	assert.Equal(t, false, reply.Payload["success"])
+	assert.Equal(t, string(constants.ErrorNotInQueue), reply.Payload["reason"])
+}
+
+// TestBusyPlayersCantQueue tests players with a match, game or room waiting can't queue again
+func TestBusyPlayersCantQueue(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	match, _ := newReadyCheckMatch(t, server, 2)
+	check := server.StartReadyCheck(match)
+
+	_, err := server.AddPlayerToQueue("coop_duo", match.Players[0])
+	assert.ErrorIs(t, err, ErrInReadyCheck)
+
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[0].ID, true))
+	require.NoError(t, server.RespondToReadyCheck(check.ID, match.Players[1].ID, true))
+
+	_, err = server.AddPlayerToQueue("coop_duo", match.Players[1])
+	assert.ErrorIs(t, err, ErrAlreadyPlaying)
+
+	host := &types.Player{ID: uuid.New(), Username: "host"}
+	_, err = server.CreateRoom("room", host.ID, 2, "")
+	require.NoError(t, err)
+
+	_, err = server.AddPlayerToQueue("coop_duo", host)
+	assert.ErrorIs(t, err, ErrAlreadyInRoom)
+
+	for _, stats := range server.GetQueueStats() {
+		assert.Zero(t, stats.Waiting, stats.QueueID)
+	}
+}
+
+// TestRatedMatchRecordsRatings tests a rated game found through the queue moves both players' ratings when it ends
+func TestRatedMatchRecordsRatings(t *testing.T) {
+	ratings := &fakeRatingStore{}
+	server := NewServer(&MockAuthClient{}, nil, ratings)
+
+	winner := &types.Player{ID: uuid.New(), Username: "winner"}
+	loser := &types.Player{ID: uuid.New(), Username: "loser"}
+	players := []*types.Player{winner, loser}
+
+	channels := make([]chan types.Message, len(players))
+	for i, player := range players {
+		channels[i] = registerTestConn(server, &websocket.Conn{}, player)
+		_, err := server.AddPlayerToQueue("tdm_1v1", player)
+		require.NoError(t, err)
+	}
+
+	readyCheck := waitForAction(t, channels[0], constants.ActionReadyCheck)
+	checkID, err := uuid.Parse(readyCheck.Payload["check_id"].(string))
+	require.NoError(t, err)
+
+	for _, player := range players {
+		require.NoError(t, server.RespondToReadyCheck(checkID, player.ID, true))
+	}
+
+	found := waitForAction(t, channels[0], constants.ActionGameFound)
+	sessionID, err := uuid.Parse(found.Payload["session_id"].(string))
+	require.NoError(t, err)
+	session, exists := server.GetGameSession(sessionID)
+	require.True(t, exists)
+	defer session.Shutdown()
+
+	winningTeam := session.GetPlayerTeam(winner.ID)
+
+	// the loser giving up ends the game
+	session.RemovePlayer(loser.ID)
+	require.Equal(t, game.SessionEnding, session.State())
+	assert.Equal(t, 1, server.reapIdleSessions())
+
+	assert.Equal(t, "team_deathmatch", ratings.gameMode)
+	assert.Equal(t, winningTeam, ratings.winningTeam)
+	require.Len(t, ratings.teams, 2)
+	assert.Equal(t, winningTeam, ratings.teams[winner.ID])
+	assert.NotEqual(t, winningTeam, ratings.teams[loser.ID])
+}
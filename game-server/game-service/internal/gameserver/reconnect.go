@@ -0,0 +1,140 @@
+package gameserver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Reconnecting
+*
+* A player whose connection drops mid game stays in their session for a
+* grace period. Reconnecting in time picks the game back up from a full
+* snapshot, otherwise they're removed from the session.
+**/
+
+func (s *Server) SetReconnectGracePeriod(grace time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reconnectGrace = grace
+}
+
+/**
+* the session the player is playing in.
+**/
+// NOTE: caller must hold the lock
+func (s *Server) sessionOf(playerID uuid.UUID) (*game.Session, bool) {
+	for _, session := range s.sessions {
+		if session.HasPlayer(playerID) {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+/**
+* whether the player has a live connection to this server.
+**/
+// NOTE: caller must hold the lock
+func (s *Server) isConnected(playerID uuid.UUID) bool {
+	for _, player := range s.connToPlayer {
+		if player.ID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+* marks the player disconnected in their session and removes them once the
+* grace period runs out. Players who already reconnected are left alone.
+**/
+func (s *Server) holdDisconnectedPlayer(playerID uuid.UUID) {
+	s.reconnectMu.Lock()
+	defer s.reconnectMu.Unlock()
+
+	s.mu.RLock()
+	session, inSession := s.sessionOf(playerID)
+	connected := s.isConnected(playerID)
+	s.mu.RUnlock()
+
+	if !inSession || connected || !session.MarkDisconnected(playerID) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, exists := s.disconnectTimers[playerID]; exists {
+		timer.Stop()
+	}
+
+	sessionID := session.ID
+	s.disconnectTimers[playerID] = time.AfterFunc(s.reconnectGrace, func() {
+		s.expireDisconnect(playerID, sessionID)
+	})
+
+	fmt.Printf("Player %s disconnected from session %s, holding for %s\n", playerID, sessionID, s.reconnectGrace)
+}
+
+func (s *Server) expireDisconnect(playerID, sessionID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.disconnectTimers, playerID)
+	session, exists := s.sessions[sessionID]
+	s.mu.Unlock()
+
+	// the session ended in the meantime
+	if !exists || !session.IsDisconnected(playerID) {
+		return
+	}
+
+	fmt.Printf("Player %s didn't reconnect to session %s in time, removing them\n", playerID, sessionID)
+	session.RemovePlayer(playerID)
+
+	// someone from the queue can take their place
+	s.syncBackfill(sessionID)
+}
+
+/**
+* puts a reconnecting player back into their session, sending them the
+* session id and a full snapshot to catch up from.
+**/
+func (s *Server) resumeSession(playerID uuid.UUID) {
+	s.reconnectMu.Lock()
+	defer s.reconnectMu.Unlock()
+
+	s.mu.Lock()
+	if timer, exists := s.disconnectTimers[playerID]; exists {
+		timer.Stop()
+		delete(s.disconnectTimers, playerID)
+	}
+	session, inSession := s.sessionOf(playerID)
+	s.mu.Unlock()
+
+	if !inSession {
+		return
+	}
+
+	session.MarkReconnected(playerID)
+
+	snapshot, err := session.Snapshot()
+	if err != nil {
+		fmt.Printf("Failed to snapshot session %s for player %s: %v\n", session.ID, playerID, err)
+		return
+	}
+
+	messaging.NewMessageSender(s).SendToPlayer(playerID, types.Message{
+		Action: string(constants.ActionSessionResume),
+		Payload: map[string]interface{}{
+			"session_id": session.ID.String(),
+			"state":      snapshot,
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package gameserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing players dropping out of a game and coming back.
+**/
+
+// TestReconnectResumesSession tests a player reconnecting in time gets a snapshot
+func TestReconnectResumesSession(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "dropper"}
+	other := &types.Player{ID: uuid.New(), Username: "stayer"}
+	session, err := server.CreateGameSession([]*types.Player{player, other})
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	otherCh := registerTestConn(server, &websocket.Conn{}, other)
+
+	server.holdDisconnectedPlayer(player.ID)
+	assert.True(t, session.IsDisconnected(player.ID))
+
+	dropped := waitForAction(t, otherCh, constants.ActionPlayerDisconnected)
+	assert.Equal(t, player.ID.String(), dropped.Payload["player_id"])
+
+	playerCh := registerTestConn(server, &websocket.Conn{}, player)
+	server.resumeSession(player.ID)
+
+	resume := waitForAction(t, playerCh, constants.ActionSessionResume)
+	assert.Equal(t, session.ID.String(), resume.Payload["session_id"])
+
+	state, ok := resume.Payload["state"].(*types.ClientGameState)
+	require.True(t, ok)
+	assert.Len(t, state.Players, 2)
+
+	assert.False(t, session.IsDisconnected(player.ID))
+	assert.True(t, session.HasPlayer(player.ID))
+	waitForAction(t, otherCh, constants.ActionPlayerReconnected)
+
+	server.mu.RLock()
+	assert.Empty(t, server.disconnectTimers)
+	server.mu.RUnlock()
+}
+
+// TestReconnectGraceExpires tests players who stay away are removed from the session
+func TestReconnectGraceExpires(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	server.SetReconnectGracePeriod(50 * time.Millisecond)
+
+	player := &types.Player{ID: uuid.New(), Username: "dropper"}
+	other := &types.Player{ID: uuid.New(), Username: "stayer"}
+	session, err := server.CreateGameSession([]*types.Player{player, other})
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	otherCh := registerTestConn(server, &websocket.Conn{}, other)
+
+	server.holdDisconnectedPlayer(player.ID)
+
+	left := waitForAction(t, otherCh, constants.ActionPlayerLeft)
+	assert.Equal(t, player.ID.String(), left.Payload["player_id"])
+	assert.False(t, session.HasPlayer(player.ID))
+	assert.Equal(t, []uuid.UUID{other.ID}, session.GetPlayerIDs())
+}
+
+// TestReconnectBeforeHold tests an old connection closing after the player reconnected doesn't hold them
+func TestReconnectBeforeHold(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "dropper"}
+	other := &types.Player{ID: uuid.New(), Username: "stayer"}
+	session, err := server.CreateGameSession([]*types.Player{player, other})
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	// the new connection is mapped before the old one is cleaned up
+	registerTestConn(server, &websocket.Conn{}, player)
+	server.resumeSession(player.ID)
+
+	server.holdDisconnectedPlayer(player.ID)
+	assert.False(t, session.IsDisconnected(player.ID))
+
+	server.mu.RLock()
+	assert.Empty(t, server.disconnectTimers)
+	server.mu.RUnlock()
+}
+
+// TestSendWhileReconnecting tests messages sent while a player's old connection is replaced never hit its closed channel
+func TestSendWhileReconnecting(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	player := &types.Player{ID: uuid.New(), Username: "flaky"}
+
+	for i := 0; i < 100; i++ {
+		msgCh := registerTestConn(server, &websocket.Conn{}, player)
+
+		stop, done := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(done)
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					server.PushMessageToChannelQueue(player.ID, types.Message{Action: string(constants.ActionPing)})
+				}
+			}
+		}()
+
+		// the new connection closes the old one's channel mid send
+		<-msgCh
+		server.MapConnToPlayer(&websocket.Conn{}, *player)
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,394 @@
+package gameserver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Rooms
+*
+* Rooms are player created games that gather players before a session
+* exists. Starting a room creates its game session with the room's mode, and
+* ending it closes the session and saves progression. A room closes along
+* with its session however the session is removed. The room's creator is its
+* host, see lobby.go for host controls.
+**/
+
+type RoomStatus string
+
+const (
+	RoomStatusWaiting    RoomStatus = "waiting"
+	RoomStatusInProgress RoomStatus = "in_progress"
+	RoomStatusFinished   RoomStatus = "finished"
+)
+
+type RoomPlayer struct {
+	UserID   uuid.UUID
+	JoinedAt time.Time
+	Ready    bool
+}
+
+type Room struct {
+	ID         uuid.UUID
+	Name       string
+	CreatorID  uuid.UUID
+	MaxPlayers int
+	GameMode   string
+	Map        string
+	Status     RoomStatus
+	Players    []RoomPlayer
+	CreatedAt  time.Time
+
+	// private rooms are hidden from listings and joined with the invite code
+	Private    bool
+	InviteCode string
+	// players the host kicked, they can't join again
+	kicked map[uuid.UUID]bool
+
+	// set once the game starts
+	SessionID uuid.UUID
+	StartedAt time.Time
+
+	// set once the game ends
+	EndedAt  time.Time
+	WinnerID uuid.UUID
+}
+
+// copies the room so callers can read it without holding the server lock
+func (r *Room) clone() *Room {
+	clone := *r
+	clone.Players = make([]RoomPlayer, len(r.Players))
+	copy(clone.Players, r.Players)
+	clone.kicked = nil
+	return &clone
+}
+
+func (r *Room) hasPlayer(userID uuid.UUID) bool {
+	for _, player := range r.Players {
+		if player.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) CreateRoom(name string, creatorID uuid.UUID, maxPlayers int, gameMode string) (*Room, error) {
+	return s.CreateRoomWithSettings(creatorID, RoomSettings{
+		Name:       name,
+		GameMode:   gameMode,
+		MaxPlayers: maxPlayers,
+	})
+}
+
+/**
+* creates a room hosted by the creator. Private rooms always get an invite
+* code since it's the only way in.
+**/
+func (s *Server) CreateRoomWithSettings(creatorID uuid.UUID, settings RoomSettings) (*Room, error) {
+	settings, err := validateRoomSettings(settings, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, inRoom := s.playerRooms[creatorID]; inRoom {
+		return nil, ErrAlreadyInRoom
+	}
+
+	now := time.Now()
+	room := &Room{
+		ID:         uuid.New(),
+		Name:       settings.Name,
+		CreatorID:  creatorID,
+		MaxPlayers: settings.MaxPlayers,
+		GameMode:   settings.GameMode,
+		Map:        settings.Map,
+		Status:     RoomStatusWaiting,
+		Players:    []RoomPlayer{{UserID: creatorID, JoinedAt: now}},
+		CreatedAt:  now,
+		Private:    settings.Private,
+		kicked:     make(map[uuid.UUID]bool),
+	}
+
+	if settings.Private || settings.InviteCode {
+		room.InviteCode = s.newInviteCode()
+		s.inviteCodes[room.InviteCode] = room.ID
+	}
+
+	s.rooms[room.ID] = room
+	s.playerRooms[creatorID] = room.ID
+
+	fmt.Printf("Room %s created by %s\n", room.ID, creatorID)
+
+	return room.clone(), nil
+}
+
+func (s *Server) GetRoom(roomID uuid.UUID) (*Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	room, exists := s.rooms[roomID]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+
+	return room.clone(), nil
+}
+
+/**
+* lists public rooms oldest first, optionally only of one game mode, along with the
+* total matching before paging.
+**/
+func (s *Server) ListRooms(gameMode string, limit, offset int) ([]*Room, int) {
+	s.mu.RLock()
+	matching := make([]*Room, 0, len(s.rooms))
+	for _, room := range s.rooms {
+		if room.Private {
+			continue
+		}
+
+		if gameMode != "" && room.GameMode != gameMode {
+			continue
+		}
+		matching = append(matching, room.clone())
+	}
+	s.mu.RUnlock()
+
+	sortRoomsByCreation(matching)
+
+	total := len(matching)
+
+	if offset >= total {
+		return []*Room{}, total
+	}
+
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+
+	return matching[offset:end], total
+}
+
+func (s *Server) JoinRoom(roomID, userID uuid.UUID) (*Room, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomID]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+
+	// joining the same room twice is harmless
+	if room.hasPlayer(userID) {
+		return room.clone(), nil
+	}
+
+	if _, inRoom := s.playerRooms[userID]; inRoom {
+		return nil, ErrAlreadyInRoom
+	}
+
+	if room.kicked[userID] {
+		return nil, ErrKickedFromRoom
+	}
+
+	if room.Status != RoomStatusWaiting {
+		return nil, ErrRoomNotWaiting
+	}
+
+	if len(room.Players) >= room.MaxPlayers {
+		return nil, ErrRoomFull
+	}
+
+	room.Players = append(room.Players, RoomPlayer{UserID: userID, JoinedAt: time.Now()})
+	s.playerRooms[userID] = room.ID
+
+	return room.clone(), nil
+}
+
+/**
+* removes a player from the room. The oldest remaining player becomes the
+* creator when the creator leaves, and empty waiting rooms are closed.
+**/
+func (s *Server) LeaveRoom(roomID, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, exists := s.rooms[roomID]
+	if !exists {
+		return ErrRoomNotFound
+	}
+
+	if !room.hasPlayer(userID) {
+		return ErrNotInRoom
+	}
+
+	remaining := make([]RoomPlayer, 0, len(room.Players))
+	for _, player := range room.Players {
+		if player.UserID != userID {
+			remaining = append(remaining, player)
+		}
+	}
+
+	room.Players = remaining
+	delete(s.playerRooms, userID)
+
+	if room.CreatorID == userID && len(room.Players) > 0 {
+		room.CreatorID = room.Players[0].UserID
+	}
+
+	if len(room.Players) == 0 && room.Status == RoomStatusWaiting {
+		s.closeRoom(room)
+		fmt.Printf("Room %s closed, everyone left\n", roomID)
+	}
+
+	return nil
+}
+
+/**
+* starts the game for everyone in the room, creating its session and letting
+* connected players know where to send game actions.
+**/
+func (s *Server) StartRoom(roomID uuid.UUID) (*Room, error) {
+	s.mu.Lock()
+	room, exists := s.rooms[roomID]
+	if !exists {
+		s.mu.Unlock()
+		return nil, ErrRoomNotFound
+	}
+
+	if room.Status != RoomStatusWaiting {
+		s.mu.Unlock()
+		return nil, ErrRoomNotWaiting
+	}
+
+	if len(room.Players) == 0 {
+		s.mu.Unlock()
+		return nil, ErrNotEnoughPlayers
+	}
+
+	// claim the room before releasing the lock so it can't be started twice
+	room.Status = RoomStatusInProgress
+	room.StartedAt = time.Now()
+
+	// connected players already have their username
+	usernames := make(map[uuid.UUID]string, len(s.connToPlayer))
+	for _, player := range s.connToPlayer {
+		usernames[player.ID] = player.Username
+	}
+
+	players := make([]*types.Player, 0, len(room.Players))
+	for _, roomPlayer := range room.Players {
+		players = append(players, &types.Player{
+			ID:       roomPlayer.UserID,
+			Username: usernames[roomPlayer.UserID],
+		})
+	}
+
+	mode, _ := game.ModeByName(room.GameMode)
+	s.mu.Unlock()
+
+	session, err := s.CreateGameSessionWithMode(players, mode)
+
+	s.mu.Lock()
+	if err != nil {
+		// the room waits to be started again
+		room.Status = RoomStatusWaiting
+		room.StartedAt = time.Time{}
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	room.SessionID = session.ID
+	started := room.clone()
+	s.mu.Unlock()
+
+	messaging.NewMessageSender(s).BroadcastToPlayerList(players, types.Message{
+		Action: "game_found",
+		Payload: map[string]any{
+			"session_id": session.ID.String(),
+			"room_id":    room.ID.String(),
+		},
+	})
+
+	return started, nil
+}
+
+/**
+* ends the room's game, saving progression. The room closes once its session
+* is removed.
+**/
+func (s *Server) EndRoom(ctx context.Context, roomID uuid.UUID, winnerID uuid.UUID) (*Room, error) {
+	s.mu.Lock()
+	room, exists := s.rooms[roomID]
+	if !exists {
+		s.mu.Unlock()
+		return nil, ErrRoomNotFound
+	}
+
+	if room.Status != RoomStatusInProgress {
+		s.mu.Unlock()
+		return nil, ErrRoomNotInProgress
+	}
+
+	room.WinnerID = winnerID
+	sessionID := room.SessionID
+	s.mu.Unlock()
+
+	err := s.EndGameSession(ctx, sessionID, winnerID)
+
+	s.mu.RLock()
+	ended := room.clone()
+	s.mu.RUnlock()
+
+	return ended, err
+}
+
+/**
+* finishes and closes the room playing the session, freeing its players to
+* join other rooms.
+**/
+// NOTE: caller must hold the lock
+func (s *Server) finishSessionRoom(sessionID uuid.UUID) {
+	for _, room := range s.rooms {
+		if room.Status != RoomStatusInProgress || room.SessionID != sessionID {
+			continue
+		}
+
+		room.Status = RoomStatusFinished
+		room.EndedAt = time.Now()
+
+		s.closeRoom(room)
+		for _, player := range room.Players {
+			delete(s.playerRooms, player.UserID)
+		}
+
+		fmt.Printf("Room %s closed, its game ended\n", room.ID)
+		return
+	}
+}
+
+// NOTE: caller must hold the lock
+func (s *Server) closeRoom(room *Room) {
+	delete(s.rooms, room.ID)
+
+	if room.InviteCode != "" {
+		delete(s.inviteCodes, room.InviteCode)
+	}
+}
+
+func sortRoomsByCreation(rooms []*Room) {
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].CreatedAt.Before(rooms[j].CreatedAt)
+	})
+}
@@ -0,0 +1,262 @@
+package gameserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing the room lifecycle from creation through to the end of its game.
+**/
+
+// TestCreateRoom tests room creation validation
+func TestCreateRoom(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxPlayers  int
+		gameMode    string
+		expectedErr error
+		expectedMax int
+	}{
+		{name: "defaults max players and mode", maxPlayers: 0, gameMode: "", expectedMax: constants.DefaultRoomMaxPlayers},
+		{name: "team deathmatch", maxPlayers: 6, gameMode: "team_deathmatch", expectedMax: 6},
+		{name: "unknown mode", maxPlayers: 4, gameMode: "capture_the_flag", expectedErr: ErrUnknownGameMode},
+		{name: "too many players", maxPlayers: constants.MaxRoomPlayers + 1, expectedErr: ErrInvalidMaxPlayers},
+		{name: "negative players", maxPlayers: -1, expectedErr: ErrInvalidMaxPlayers},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(&MockAuthClient{}, nil, nil)
+
+			room, err := server.CreateRoom("test room", uuid.New(), tt.maxPlayers, tt.gameMode)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMax, room.MaxPlayers)
+			assert.Equal(t, RoomStatusWaiting, room.Status)
+			assert.Len(t, room.Players, 1)
+		})
+	}
+}
+
+// TestJoinAndLeaveRoom tests joining limits and creator handover
+func TestJoinAndLeaveRoom(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	creatorID := uuid.New()
+	room, err := server.CreateRoom("duel", creatorID, 2, "")
+	require.NoError(t, err)
+
+	// creator can't open a second room
+	_, err = server.CreateRoom("another", creatorID, 2, "")
+	assert.ErrorIs(t, err, ErrAlreadyInRoom)
+
+	secondID := uuid.New()
+	room, err = server.JoinRoom(room.ID, secondID)
+	require.NoError(t, err)
+	assert.Len(t, room.Players, 2)
+
+	_, err = server.JoinRoom(room.ID, uuid.New())
+	assert.ErrorIs(t, err, ErrRoomFull)
+
+	assert.ErrorIs(t, server.LeaveRoom(room.ID, uuid.New()), ErrNotInRoom)
+
+	// creator leaving hands the room over
+	require.NoError(t, server.LeaveRoom(room.ID, creatorID))
+	room, err = server.GetRoom(room.ID)
+	require.NoError(t, err)
+	assert.Equal(t, secondID, room.CreatorID)
+
+	// last player leaving closes the room
+	require.NoError(t, server.LeaveRoom(room.ID, secondID))
+	_, err = server.GetRoom(room.ID)
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+}
+
+// TestStartAndEndRoom tests that starting a room creates its session and ending it cleans up
+func TestStartAndEndRoom(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	creatorID := uuid.New()
+	secondID := uuid.New()
+
+	room, err := server.CreateRoom("match", creatorID, 2, "team_deathmatch")
+	require.NoError(t, err)
+	_, err = server.JoinRoom(room.ID, secondID)
+	require.NoError(t, err)
+
+	_, err = server.EndRoom(context.Background(), room.ID, uuid.Nil)
+	assert.ErrorIs(t, err, ErrRoomNotInProgress)
+
+	started, err := server.StartRoom(room.ID)
+	require.NoError(t, err)
+	assert.Equal(t, RoomStatusInProgress, started.Status)
+
+	session, exists := server.GetGameSession(started.SessionID)
+	require.True(t, exists)
+	assert.Equal(t, "team_deathmatch", session.Mode().Name)
+	assert.ElementsMatch(t, []uuid.UUID{creatorID, secondID}, session.GetPlayerIDs())
+
+	// no joining once the game started
+	_, err = server.JoinRoom(room.ID, uuid.New())
+	assert.ErrorIs(t, err, ErrRoomNotWaiting)
+
+	_, err = server.StartRoom(room.ID)
+	assert.ErrorIs(t, err, ErrRoomNotWaiting)
+
+	ended, err := server.EndRoom(context.Background(), room.ID, creatorID)
+	require.NoError(t, err)
+	assert.Equal(t, RoomStatusFinished, ended.Status)
+	assert.Equal(t, creatorID, ended.WinnerID)
+
+	_, exists = server.GetGameSession(started.SessionID)
+	assert.False(t, exists)
+
+	// players are free to make new rooms
+	_, err = server.CreateRoom("rematch", creatorID, 2, "")
+	assert.NoError(t, err)
+}
+
+// TestReapedSessionClosesRoom tests rooms close when their session is removed without EndRoom
+func TestReapedSessionClosesRoom(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	creatorID := uuid.New()
+	room, err := server.CreateRoom("match", creatorID, 2, "coop")
+	require.NoError(t, err)
+
+	started, err := server.StartRoom(room.ID)
+	require.NoError(t, err)
+
+	session, exists := server.GetGameSession(started.SessionID)
+	require.True(t, exists)
+	session.RemovePlayer(creatorID)
+
+	assert.Equal(t, 1, server.reapIdleSessions())
+
+	_, err = server.GetRoom(room.ID)
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+
+	_, err = server.EndRoom(context.Background(), room.ID, creatorID)
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+
+	// the player isn't stuck in the old room
+	_, err = server.CreateRoom("rematch", creatorID, 2, "")
+	assert.NoError(t, err)
+}
+
+// TestListRooms tests filtering and paging
+func TestListRooms(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	for i := 0; i < 3; i++ {
+		_, err := server.CreateRoom("coop", uuid.New(), 4, "coop")
+		require.NoError(t, err)
+	}
+	_, err := server.CreateRoom("tdm", uuid.New(), 4, "team_deathmatch")
+	require.NoError(t, err)
+
+	rooms, total := server.ListRooms("", 0, 0)
+	assert.Equal(t, 4, total)
+	assert.Len(t, rooms, 4)
+
+	rooms, total = server.ListRooms("coop", 2, 0)
+	assert.Equal(t, 3, total)
+	assert.Len(t, rooms, 2)
+
+	rooms, total = server.ListRooms("coop", 2, 2)
+	assert.Equal(t, 3, total)
+	assert.Len(t, rooms, 1)
+
+	rooms, _ = server.ListRooms("coop", 2, 10)
+	assert.Empty(t, rooms)
+}
+
+type fakeRatingStore struct {
+	gameMode    string
+	teams       map[uuid.UUID]int
+	winningTeam int
+
+	// lookups block until they're cancelled
+	slow bool
+}
+
+func (f *fakeRatingStore) GetRatings(ctx context.Context, gameMode string, memberIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	if f.slow {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return map[uuid.UUID]float64{}, nil
+}
+
+func (f *fakeRatingStore) RecordMatch(ctx context.Context, gameMode string, teams map[uuid.UUID]int, winningTeam int) error {
+	f.gameMode = gameMode
+	f.teams = teams
+	f.winningTeam = winningTeam
+	return nil
+}
+
+// TestEndRoomRecordsRatings tests rated modes send the result to the rating store
+func TestEndRoomRecordsRatings(t *testing.T) {
+	ratings := &fakeRatingStore{}
+	server := NewServer(&MockAuthClient{}, nil, ratings)
+
+	creatorID, secondID := uuid.New(), uuid.New()
+	room, err := server.CreateRoom("ranked", creatorID, 2, "team_deathmatch")
+	require.NoError(t, err)
+	_, err = server.JoinRoom(room.ID, secondID)
+	require.NoError(t, err)
+
+	started, err := server.StartRoom(room.ID)
+	require.NoError(t, err)
+
+	session, _ := server.GetGameSession(started.SessionID)
+	winningTeam := session.GetPlayerTeam(secondID)
+
+	_, err = server.EndRoom(context.Background(), room.ID, secondID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "team_deathmatch", ratings.gameMode)
+	assert.Equal(t, winningTeam, ratings.winningTeam)
+	assert.Len(t, ratings.teams, 2)
+	assert.NotEqual(t, ratings.teams[creatorID], ratings.teams[secondID])
+
+	// co-op isn't rated
+	ratings.teams = nil
+	room, err = server.CreateRoom("coop", creatorID, 2, "coop")
+	require.NoError(t, err)
+	_, err = server.StartRoom(room.ID)
+	require.NoError(t, err)
+	_, err = server.EndRoom(context.Background(), room.ID, creatorID)
+	require.NoError(t, err)
+	assert.Nil(t, ratings.teams)
+}
+
+// TestSlowRatingStore tests queueing for a rated queue gives up on a slow rating store
+func TestSlowRatingStore(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, &fakeRatingStore{slow: true})
+	server.SetStoreTimeout(50 * time.Millisecond)
+
+	player := &types.Player{ID: uuid.New(), Username: "player"}
+
+	started := time.Now()
+	queueID, err := server.AddPlayerToQueue("tdm_4v4", player)
+	require.NoError(t, err)
+	assert.Equal(t, "tdm_4v4", queueID)
+	assert.Less(t, time.Since(started), time.Second)
+
+	assert.True(t, server.RemovePlayerFromQueue(player))
+}
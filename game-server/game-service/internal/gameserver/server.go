@@ -1,13 +1,17 @@
 package gameserver
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
 	grpcauth "github.com/darkphotonKN/cosmic-void-server/game-service/grpc/auth"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/messaging"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/protocol"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/serializer"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
@@ -30,7 +34,12 @@ type Server struct {
 
 	// active sessions
 	// [sessionId] to active sessions
-	sessions map[uuid.UUID]*game.Session
+	sessions    map[uuid.UUID]*game.Session
+	maxSessions int
+	// sessions still being set up, they count against maxSessions
+	startingSessions int
+	// tick overruns of sessions no longer on the server
+	removedOverruns int64
 
 	// online players
 	// [playerId] to player
@@ -40,20 +49,112 @@ type Server struct {
 	// [active connections] to player
 	connToPlayer map[*websocket.Conn]*types.Player
 
+	// connections are pinged every interval and closed once idle
+	pingInterval time.Duration
+	idleTimeout  time.Duration
+	// [playerId] to their round trip to the server
+	latencies map[uuid.UUID]time.Duration
+
+	// player created rooms
+	// [roomId] to room
+	rooms map[uuid.UUID]*Room
+	// [playerId] to the room they are in
+	playerRooms map[uuid.UUID]uuid.UUID
+	// [inviteCode] to room
+	inviteCodes map[string]uuid.UUID
+
 	mu sync.RWMutex
 
-	queueSystem *systems.QueueSystem
+	matchmaker Matchmaking
+
+	// player parties
+	// [partyId] to party
+	parties map[uuid.UUID]*Party
+	// [playerId] to the party they are in
+	playerParties map[uuid.UUID]uuid.UUID
+
+	// matches waiting on players to accept
+	// [checkId] to ready check
+	readyChecks       map[uuid.UUID]*ReadyCheck
+	readyCheckTimeout time.Duration
+	readyCheckPenalty time.Duration
+
+	// players whose connection dropped mid game
+	// [playerId] to the timer removing them from their session
+	disconnectTimers map[uuid.UUID]*time.Timer
+	reconnectGrace   time.Duration
+	// holding and resuming players one at a time, marking a player in their
+	// session can't happen under mu since the session sends through it
+	reconnectMu sync.Mutex
+
+	// sessions taking players from the queue they were matched in
+	// [sessionId] to the queue and how many players the session holds
+	backfills map[uuid.UUID]backfillSession
+
+	// users watching a session without playing in it
+	// [userId] to the session they are watching
+	spectating map[uuid.UUID]uuid.UUID
 
 	// auth client for gRPC calls
 	authClient grpcauth.AuthClient
+
+	// persistent characters, nil runs every session with fresh characters
+	characterStore CharacterStore
+	// longest queueing, starting or joining a game waits on a store
+	storeTimeout time.Duration
+
+	// skill ratings for rated queues, nil matches everyone at the default
+	// rating and skips rating updates
+	ratingStore RatingStore
 }
 
-func NewServer(authClient grpcauth.AuthClient) *Server {
+/**
+* Loads characters when players join a session and saves them back when it
+* ends.
+**/
+type CharacterStore interface {
+	LoadCharacter(ctx context.Context, memberID uuid.UUID, name string) (*types.CharacterState, error)
+	SaveCharacters(ctx context.Context, states []*types.CharacterState) error
+}
+
+/**
+* Looks up skill ratings for rated matchmaking and updates them from match
+* results.
+**/
+type RatingStore interface {
+	GetRatings(ctx context.Context, gameMode string, memberIDs []uuid.UUID) (map[uuid.UUID]float64, error)
+	RecordMatch(ctx context.Context, gameMode string, teams map[uuid.UUID]int, winningTeam int) error
+}
+
+/**
+* Forms matches from the queues players join. The server starts with queues
+* local to this process, distributed matchmaking shares them between game
+* service instances.
+**/
+type Matchmaking interface {
+	DefaultQueueID() string
+	QueueConfig(queueID string) (systems.QueueConfig, bool)
+	AddGroup(queueID string, players []*types.Player) (string, error)
+	RemovePlayer(player *types.Player) bool
+	RequeueFront(match systems.Match, players []*types.Player) error
+	OpenBackfill(queueID string, slot systems.BackfillSlot) error
+	CloseBackfill(queueID string, sessionID uuid.UUID)
+	Penalize(playerID uuid.UUID, duration time.Duration)
+	PenaltyRemaining(playerID uuid.UUID) time.Duration
+	QueueOf(playerID uuid.UUID) (string, bool)
+	Stats() []systems.QueueStats
+	Matched() chan systems.Match
+	QueueStatuses() chan systems.QueueStatus
+}
+
+func NewServer(authClient grpcauth.AuthClient, characterStore CharacterStore, ratingStore RatingStore) *Server {
 	upgrader := websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
 			// TODO: Allow all connections by default for simplicity; can add more logic here
 			return true
 		},
+		// clients asking for neither encoding get JSON
+		Subprotocols: protocol.Subprotocols,
 	}
 
 	server := &Server{
@@ -63,23 +164,54 @@ func NewServer(authClient grpcauth.AuthClient) *Server {
 		msgChan:    make(map[*websocket.Conn]chan types.Message, 10),
 
 		sessions:     make(map[uuid.UUID]*game.Session, 10),
+		maxSessions:  constants.MaxConcurrentSessions,
 		players:      make(map[uuid.UUID]*types.Player, 10),
 		connToPlayer: make(map[*websocket.Conn]*types.Player, 10),
 
-		authClient: authClient,
+		pingInterval: constants.PingInterval,
+		idleTimeout:  constants.ConnectionIdleTimeout,
+		latencies:    make(map[uuid.UUID]time.Duration, 10),
+
+		rooms:       make(map[uuid.UUID]*Room, 10),
+		playerRooms: make(map[uuid.UUID]uuid.UUID, 10),
+		inviteCodes: make(map[string]uuid.UUID, 10),
+
+		parties:       make(map[uuid.UUID]*Party, 10),
+		playerParties: make(map[uuid.UUID]uuid.UUID, 10),
+
+		readyChecks:       make(map[uuid.UUID]*ReadyCheck, 10),
+		readyCheckTimeout: constants.ReadyCheckTimeout,
+		readyCheckPenalty: constants.ReadyCheckPenalty,
+
+		disconnectTimers: make(map[uuid.UUID]*time.Timer, 10),
+		reconnectGrace:   constants.ReconnectGracePeriod,
+
+		backfills:  make(map[uuid.UUID]backfillSession, 10),
+		spectating: make(map[uuid.UUID]uuid.UUID, 10),
+
+		authClient:     authClient,
+		characterStore: characterStore,
+		storeTimeout:   constants.StoreTimeout,
+		ratingStore:    ratingStore,
 	}
 
 	// initialize message sender (inject send function)
 	newSender := messaging.NewMessageSender(server)
 
-	// initialize queue system
-	server.queueSystem = systems.NewQueueSystem(2)
-	server.queueSystem.Start()
+	// initialize matchmaking queues
+	matchmaker, err := systems.NewMatchmaker(DefaultQueues())
+	if err != nil {
+		panic(fmt.Sprintf("invalid matchmaking queues: %v", err))
+	}
+	matchmaker.Start()
+	server.matchmaker = matchmaker
 
 	// initialize message hub
 	messageHub := NewMessageHub(server, newSender)
 	go messageHub.Run()
 
+	go server.reapSessions(constants.SessionReapInterval)
+
 	return server
 }
 
@@ -132,22 +264,133 @@ func (s *Server) GetPlayerFromConn(conn *websocket.Conn) (*types.Player, bool) {
 /**
 * allows the creation of a new game session.
 **/
-func (s *Server) CreateGameSession(players []*types.Player) *game.Session {
+func (s *Server) CreateGameSession(players []*types.Player) (*game.Session, error) {
+	return s.CreateGameSessionWithMode(players, game.DefaultGameMode())
+}
+
+/**
+* creates a game session played with the rules of the given mode, failing
+* when the server already runs as many sessions as it's allowed.
+**/
+func (s *Server) CreateGameSessionWithMode(players []*types.Player, mode game.GameMode) (*game.Session, error) {
+	// the slot is taken before characters load so matches starting at the
+	// same time can't go over the limit
+	s.mu.Lock()
+	full := len(s.sessions)+s.startingSessions >= s.maxSessions
+	if !full {
+		s.startingSessions++
+	}
+	s.mu.Unlock()
+
+	if full {
+		return nil, ErrServerFull
+	}
+
 	stateSerializer := serializer.NewStateSerializer()
 	// create session with message sender
-	newGameSession := game.NewSession(messaging.NewMessageSender(s), stateSerializer)
+	newGameSession := game.NewSessionWithMode(messaging.NewMessageSender(s), stateSerializer, mode)
+
+	characters := s.loadCharacters(players)
 
 	for _, player := range players {
-		newGameSession.AddPlayer(player.ID, player.Username)
+		if player.Bot {
+			newGameSession.AddBot(player.ID, player.Username, player.Team, player.BotDifficulty)
+			continue
+		}
+
+		newGameSession.AddPlayerWithCharacter(player.ID, player.Username, player.Team, characters[player.ID])
 	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.startingSessions--
 	s.sessions[newGameSession.ID] = newGameSession
 	fmt.Printf("New game session initiated, id: %s\n", newGameSession.ID)
 
-	return newGameSession
+	return newGameSession, nil
+}
+
+func (s *Server) SetStoreTimeout(timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.storeTimeout = timeout
+}
+
+/**
+* context for a store call made while queueing, starting or joining a game,
+* so a slow store can't hold up the hub or a ready check for long.
+**/
+func (s *Server) storeContext() (context.Context, context.CancelFunc) {
+	s.mu.RLock()
+	timeout := s.storeTimeout
+	s.mu.RUnlock()
+
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+/**
+* loads the saved characters of every human player at once. Players are
+* missing from the result when persistence is off or their character
+* couldn't be loaded in time, they play with a fresh one.
+**/
+func (s *Server) loadCharacters(players []*types.Player) map[uuid.UUID]*types.CharacterState {
+	characters := make(map[uuid.UUID]*types.CharacterState, len(players))
+	if s.characterStore == nil {
+		return characters
+	}
+
+	ctx, cancel := s.storeContext()
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, player := range players {
+		if player.Bot {
+			continue
+		}
+
+		wg.Add(1)
+		go func(player *types.Player) {
+			defer wg.Done()
+
+			character, err := s.characterStore.LoadCharacter(ctx, player.ID, player.Username)
+			if err != nil {
+				fmt.Printf("Failed to load character for player %s, using a fresh one: %v\n", player.Username, err)
+				return
+			}
+
+			mu.Lock()
+			characters[player.ID] = character
+			mu.Unlock()
+		}(player)
+	}
+
+	wg.Wait()
+	return characters
+}
+
+/**
+* ends a session for the winner's team, a nil winner leaves the result to
+* the session, a draw unless it already ended itself with a winner.
+**/
+func (s *Server) EndGameSession(ctx context.Context, sessionID uuid.UUID, winnerID uuid.UUID) error {
+	s.mu.Lock()
+	session, exists := s.removeSession(sessionID)
+	s.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+
+	winningTeam := session.WinningTeam()
+	if winnerID != uuid.Nil {
+		winningTeam = session.GetPlayerTeam(winnerID)
+	}
+
+	return s.finishSession(ctx, session, winningTeam)
 }
 
 /**
@@ -161,31 +404,133 @@ func (s *Server) GetGameSession(id uuid.UUID) (*game.Session, bool) {
 }
 
 /**
-* add player to queue (delegates to QueueSystem)
+* add player to one of the matchmaking queues, an empty queue id means the
+* default queue. Returns the queue the player ended up in.
 **/
-func (s *Server) AddPlayerToQueue(player *types.Player) {
-	s.queueSystem.AddPlayerChan(player)
+func (s *Server) AddPlayerToQueue(queueID string, player *types.Player) (string, error) {
+	if queueID == "" {
+		queueID = s.matchmaker.DefaultQueueID()
+	}
+
+	// players in a party queue together through their leader
+	s.mu.RLock()
+	group, err := s.partyQueueGroup(player)
+	if err == nil {
+		err = s.queueBlocker(group)
+	}
+	for i, member := range group {
+		// matchmaking sees the latency as it was when the player queued
+		queued := *member
+		queued.Latency = s.latencies[member.ID]
+		group[i] = &queued
+	}
+	s.mu.RUnlock()
+
+	if err != nil {
+		return queueID, err
+	}
+
+	if config, exists := s.matchmaker.QueueConfig(queueID); exists && config.Rating != nil {
+		s.setRatings(group, config.GameMode)
+	}
+
+	return s.matchmaker.AddGroup(queueID, group)
 }
 
 /**
-* remove player from queue (delegates to QueueSystem)
+* why the group can't queue right now, nil when it can. Players waiting on a
+* ready check, playing a game or sitting in a room already have a game.
 **/
-func (s *Server) RemovePlayerFromQueue(player *types.Player) {
-	s.queueSystem.PlayerRemoveQueue(player)
+// NOTE: caller must hold the lock
+func (s *Server) queueBlocker(group []*types.Player) error {
+	for _, member := range group {
+		for _, check := range s.readyChecks {
+			if check.hasPlayer(member.ID) {
+				return ErrInReadyCheck
+			}
+		}
+
+		if _, inSession := s.sessionOf(member.ID); inSession {
+			return ErrAlreadyPlaying
+		}
+
+		if _, inRoom := s.playerRooms[member.ID]; inRoom {
+			return ErrAlreadyInRoom
+		}
+	}
+
+	return nil
+}
+
+/**
+* sets the group's ratings in the game mode with one store call, those that
+* can't be loaded in time get the default rating.
+**/
+// NOTE: the group must be copies the caller owns
+func (s *Server) setRatings(group []*types.Player, gameMode string) {
+	for _, member := range group {
+		member.Rating = systems.DefaultRating
+	}
+
+	if s.ratingStore == nil {
+		return
+	}
+
+	memberIDs := make([]uuid.UUID, len(group))
+	for i, member := range group {
+		memberIDs[i] = member.ID
+	}
+
+	ctx, cancel := s.storeContext()
+	defer cancel()
+
+	ratings, err := s.ratingStore.GetRatings(ctx, gameMode, memberIDs)
+	if err != nil {
+		fmt.Printf("Failed to load ratings for %d player(s), using the default: %v\n", len(group), err)
+		return
+	}
+
+	for _, member := range group {
+		if rating, exists := ratings[member.ID]; exists {
+			member.Rating = rating
+		}
+	}
+}
+
+/**
+* remove player from whichever queue they are waiting in, reporting whether
+* they were in one
+**/
+func (s *Server) RemovePlayerFromQueue(player *types.Player) bool {
+	return s.matchmaker.RemovePlayer(player)
 }
 
 /**
 * get matched channel for listening to matched players
 **/
-func (s *Server) GetMatchedChan() chan []*types.Player {
-	return s.queueSystem.MatchedChan
+func (s *Server) GetMatchedChan() chan systems.Match {
+	// the hub is already listening when matchmaking is swapped
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.matchmaker.Matched()
 }
 
 /**
 * get queue status channel for listening to queue updates
 **/
 func (s *Server) GetQueueStatusChan() chan systems.QueueStatus {
-	return s.queueSystem.QueueStatusChan
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.matchmaker.QueueStatuses()
+}
+
+/**
+* current stats of every matchmaking queue
+**/
+func (s *Server) GetQueueStats() []systems.QueueStats {
+	return s.matchmaker.Stats()
 }
 
 /**
@@ -209,25 +554,49 @@ func (s *Server) GetConnFromPlayer(playerID uuid.UUID) (*websocket.Conn, bool) {
 
 // sendMessageInternal is the core function injected into MessageSender
 func (s *Server) PushMessageToChannelQueue(playerID uuid.UUID, msg types.Message) error {
-	conn, exists := s.GetConnFromPlayer(playerID)
-	if !exists {
-		return fmt.Errorf("player %s not found", playerID)
+	// channels are only closed under the write lock, holding the read lock
+	// through the send keeps the channel open until it's done
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for conn, player := range s.connToPlayer {
+		if player.ID == playerID {
+			return s.queueMessage(conn, msg)
+		}
 	}
 
+	return fmt.Errorf("player %s not found", playerID)
+}
+
+/**
+* queues a message for a connection whether or not a player is mapped to
+* it yet.
+**/
+func (s *Server) PushMessageToConn(conn *websocket.Conn, msg types.Message) error {
 	s.mu.RLock()
-	ch, ok := s.msgChan[conn]
-	s.mu.RUnlock()
+	defer s.mu.RUnlock()
 
+	return s.queueMessage(conn, msg)
+}
+
+/**
+* hands the message to the connection's writer goroutine, the only one
+* allowed to write to it.
+**/
+// NOTE: caller must hold the lock
+func (s *Server) queueMessage(conn *websocket.Conn, msg types.Message) error {
+	ch, ok := s.msgChan[conn]
 	if !ok {
-		return fmt.Errorf("message channel not found for player %s", playerID)
+		return fmt.Errorf("message channel not found for connection")
 	}
 
-	// non-blocking send to prevent slow clients from blocking
+	// non-blocking send to prevent slow clients from blocking, so the lock
+	// is never held for long
 	select {
 	case ch <- msg:
 		return nil
 	default:
-		return fmt.Errorf("message channel full for player %s", playerID)
+		return fmt.Errorf("message channel full for connection")
 	}
 }
 
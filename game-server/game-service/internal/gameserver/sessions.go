@@ -0,0 +1,155 @@
+package gameserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Session reaping
+*
+* Sessions that finished or that every player has left are removed from
+* the server, their results saved and their goroutines shut down, so the
+* server only holds games still being played.
+**/
+
+func (s *Server) SetMaxSessions(maxSessions int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxSessions = maxSessions
+}
+
+/**
+* ticks that ran long across every session this server has run, for
+* spotting an overloaded instance.
+**/
+func (s *Server) TickOverruns() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	total := s.removedOverruns
+	for _, session := range s.sessions {
+		total += session.TickOverruns()
+	}
+	return total
+}
+
+/**
+* removes the session from the server along with everyone watching it and
+* the room it was started from.
+**/
+// NOTE: caller must hold the lock
+func (s *Server) removeSession(sessionID uuid.UUID) (*game.Session, bool) {
+	session, exists := s.sessions[sessionID]
+	if !exists {
+		return nil, false
+	}
+
+	delete(s.sessions, sessionID)
+	s.removedOverruns += session.TickOverruns()
+
+	// nothing left to watch
+	for userID, watchedID := range s.spectating {
+		if watchedID == sessionID {
+			delete(s.spectating, userID)
+		}
+	}
+
+	s.finishSessionRoom(sessionID)
+
+	return session, true
+}
+
+/**
+* reaps sessions every interval for as long as the server runs.
+**/
+// NOTE: this method should be run inside a goroutine
+func (s *Server) reapSessions(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.reapIdleSessions()
+	}
+}
+
+/**
+* wraps up every session that has ended, returning how many were reaped.
+* Sessions end themselves once everyone leaves or their mode's win
+* condition is met.
+**/
+func (s *Server) reapIdleSessions() int {
+	s.mu.Lock()
+	reaped := make([]*game.Session, 0)
+	for sessionID, session := range s.sessions {
+		state := session.State()
+		if state == game.SessionEnding || state == game.SessionClosed {
+			if removed, exists := s.removeSession(sessionID); exists {
+				reaped = append(reaped, removed)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	// sessions send to players while stopping, which needs the lock
+	for _, session := range reaped {
+		fmt.Printf("Reaping game session %s\n", session.ID)
+
+		ctx, cancel := s.storeContext()
+		if err := s.finishSession(ctx, session, session.WinningTeam()); err != nil {
+			fmt.Printf("Failed to wrap up game session %s: %v\n", session.ID, err)
+		}
+		cancel()
+	}
+
+	return len(reaped)
+}
+
+/**
+* wraps up a session already removed from the server, saving every player's
+* character when the mode keeps progression between matches and updating
+* ratings when it's rated. A winning team of 0 is a draw.
+**/
+func (s *Server) finishSession(ctx context.Context, session *game.Session, winningTeam int) error {
+	s.closeBackfill(session.ID)
+
+	// no more player actions while the results are saved
+	session.End()
+
+	mode := session.Mode()
+
+	// grab the final state before the session stops
+	var states []*types.CharacterState
+	if s.characterStore != nil && mode.PersistProgression {
+		states = session.CharacterStates()
+	}
+
+	// bots don't have ratings to move, players who left early lose theirs
+	// like everyone else on their team
+	var teams map[uuid.UUID]int
+	if s.ratingStore != nil && mode.Rated {
+		teams = session.Participants()
+	}
+
+	session.Shutdown()
+
+	if len(states) > 0 {
+		if err := s.characterStore.SaveCharacters(ctx, states); err != nil {
+			return fmt.Errorf("failed to save characters for session %s: %w", session.ID, err)
+		}
+	}
+
+	if len(teams) > 0 {
+		if err := s.ratingStore.RecordMatch(ctx, mode.Name, teams, winningTeam); err != nil {
+			return fmt.Errorf("failed to update ratings for session %s: %w", session.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package gameserver
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing the server keeping only sessions still being played.
+**/
+
+// TestReapIdleSessions tests empty and ended sessions are removed and shut down
+func TestReapIdleSessions(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "player"}
+	playing, err := server.CreateGameSession([]*types.Player{player})
+	require.NoError(t, err)
+	defer playing.Shutdown()
+
+	leaver := &types.Player{ID: uuid.New(), Username: "leaver"}
+	empty, err := server.CreateGameSession([]*types.Player{leaver})
+	require.NoError(t, err)
+	empty.RemovePlayer(leaver.ID)
+
+	ended, err := server.CreateGameSession([]*types.Player{{ID: uuid.New(), Username: "winner"}})
+	require.NoError(t, err)
+	ended.End()
+
+	assert.Equal(t, 2, server.reapIdleSessions())
+	assert.Equal(t, 1, server.SessionCount())
+
+	_, exists := server.GetGameSession(playing.ID)
+	assert.True(t, exists)
+
+	assert.Equal(t, game.SessionClosed, empty.State())
+	assert.Equal(t, game.SessionClosed, ended.State())
+}
+
+// TestReapedSessionRecordsResult tests sessions that ended themselves are wrapped up like ones the server ends
+func TestReapedSessionRecordsResult(t *testing.T) {
+	ratings := &fakeRatingStore{}
+	server := NewServer(&MockAuthClient{}, nil, ratings)
+
+	winner := &types.Player{ID: uuid.New(), Username: "winner", Team: 1}
+	loser := &types.Player{ID: uuid.New(), Username: "loser", Team: 2}
+	session, err := server.CreateGameSessionWithMode([]*types.Player{winner, loser}, game.ModeTeamDeathmatch)
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	// the other team giving up wins the game
+	session.RemovePlayer(loser.ID)
+	require.Equal(t, game.SessionEnding, session.State())
+
+	assert.Equal(t, 1, server.reapIdleSessions())
+	assert.Equal(t, game.SessionClosed, session.State())
+	assert.Equal(t, "team_deathmatch", ratings.gameMode)
+	assert.Equal(t, 1, ratings.winningTeam)
+	assert.Contains(t, ratings.teams, winner.ID)
+}
+
+// TestMaxSessions tests sessions can't be created past the limit
+func TestMaxSessions(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+	server.SetMaxSessions(1)
+
+	first, err := server.CreateGameSession([]*types.Player{{ID: uuid.New(), Username: "first"}})
+	require.NoError(t, err)
+
+	_, err = server.CreateGameSession([]*types.Player{{ID: uuid.New(), Username: "second"}})
+	assert.ErrorIs(t, err, ErrServerFull)
+
+	// ending a game frees its slot
+	require.NoError(t, server.EndGameSession(context.Background(), first.ID, uuid.Nil))
+	assert.Equal(t, game.SessionClosed, first.State())
+
+	second, err := server.CreateGameSession([]*types.Player{{ID: uuid.New(), Username: "second"}})
+	require.NoError(t, err)
+	second.Shutdown()
+}
+
+// TestMaxSessionsWhileLoading tests matches starting together can't go past the limit while their characters load
+func TestMaxSessionsWhileLoading(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, &fakeCharacterStore{slowName: "slow"}, nil)
+	server.SetMaxSessions(1)
+	server.SetStoreTimeout(50 * time.Millisecond)
+
+	var mu sync.Mutex
+	var created []*game.Session
+	var wg sync.WaitGroup
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			session, err := server.CreateGameSession([]*types.Player{{ID: uuid.New(), Username: "slow"}})
+			if err != nil {
+				assert.ErrorIs(t, err, ErrServerFull)
+				return
+			}
+
+			mu.Lock()
+			created = append(created, session)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for _, session := range created {
+		session.Shutdown()
+	}
+	assert.Len(t, created, 1)
+	assert.Equal(t, 1, server.SessionCount())
+}
+
+// characters load slowly for slowName and instantly for everyone else
+type fakeCharacterStore struct {
+	slowName string
+}
+
+func (f *fakeCharacterStore) LoadCharacter(ctx context.Context, memberID uuid.UUID, name string) (*types.CharacterState, error) {
+	if name == f.slowName {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return &types.CharacterState{MemberID: memberID, Name: name, Level: 5}, nil
+}
+
+func (f *fakeCharacterStore) SaveCharacters(ctx context.Context, states []*types.CharacterState) error {
+	return nil
+}
+
+// TestSlowCharacterStore tests sessions start with fresh characters when the store is too slow
+func TestSlowCharacterStore(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, &fakeCharacterStore{slowName: "slow"}, nil)
+	server.SetStoreTimeout(50 * time.Millisecond)
+
+	slow := &types.Player{ID: uuid.New(), Username: "slow"}
+	fast := &types.Player{ID: uuid.New(), Username: "fast"}
+
+	started := time.Now()
+	session, err := server.CreateGameSession([]*types.Player{slow, fast})
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	assert.Less(t, time.Since(started), time.Second)
+
+	levels := make(map[uuid.UUID]int)
+	for _, state := range session.CharacterStates() {
+		levels[state.MemberID] = state.Level
+	}
+	assert.Equal(t, 1, levels[slow.ID])
+	assert.Equal(t, 5, levels[fast.ID])
+}
@@ -0,0 +1,89 @@
+package gameserver
+
+import (
+	"fmt"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/google/uuid"
+)
+
+/**
+* Spectating
+*
+* Users not playing in a session can watch it. The session streams them its
+* delayed state, the server only keeps track of which session each user is
+* watching so they can switch, stop, or be cleaned up on disconnect.
+**/
+
+/**
+* starts the user watching the session, leaving whatever they watched
+* before. followID picks the player the camera follows, uuid.Nil for none.
+**/
+func (s *Server) SpectateSession(sessionID, userID, followID uuid.UUID) (*game.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, exists := s.sessions[sessionID]
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+
+	// players watch their own game by playing it
+	if _, inSession := s.sessionOf(userID); inSession {
+		return nil, game.ErrAlreadyInSession
+	}
+
+	if err := session.AddSpectator(userID, followID); err != nil {
+		return nil, err
+	}
+
+	if previousID, watching := s.spectating[userID]; watching && previousID != sessionID {
+		if previous, exists := s.sessions[previousID]; exists {
+			previous.RemoveSpectator(userID)
+		}
+	}
+
+	s.spectating[userID] = sessionID
+	fmt.Printf("User %s is spectating session %s\n", userID, sessionID)
+
+	return session, nil
+}
+
+func (s *Server) StopSpectating(userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessionID, watching := s.spectating[userID]
+	if !watching {
+		return game.ErrNotSpectating
+	}
+
+	delete(s.spectating, userID)
+
+	if session, exists := s.sessions[sessionID]; exists {
+		session.RemoveSpectator(userID)
+	}
+
+	return nil
+}
+
+/**
+* points the spectator's camera at another player in the session they're
+* watching, uuid.Nil frees the camera.
+**/
+func (s *Server) FollowSpectatedPlayer(userID, targetID uuid.UUID) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sessionID, watching := s.spectating[userID]
+	if !watching {
+		return game.ErrNotSpectating
+	}
+
+	session, exists := s.sessions[sessionID]
+	if !exists {
+		return ErrSessionNotFound
+	}
+
+	return session.FollowPlayer(userID, targetID)
+}
@@ -0,0 +1,100 @@
+package gameserver
+
+import (
+	"testing"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing users spectating sessions through the server.
+**/
+
+// TestSpectateSession tests watching, switching and stopping
+func TestSpectateSession(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "player"}
+	first, err := server.CreateGameSession([]*types.Player{player})
+	require.NoError(t, err)
+	defer first.Shutdown()
+
+	other := &types.Player{ID: uuid.New(), Username: "other"}
+	second, err := server.CreateGameSession([]*types.Player{other})
+	require.NoError(t, err)
+	defer second.Shutdown()
+
+	spectatorID := uuid.New()
+
+	_, err = server.SpectateSession(uuid.New(), spectatorID, uuid.Nil)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+
+	_, err = server.SpectateSession(second.ID, player.ID, uuid.Nil)
+	assert.ErrorIs(t, err, game.ErrAlreadyInSession)
+
+	_, err = server.SpectateSession(first.ID, spectatorID, player.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.SpectatorCount())
+
+	// watching another session leaves the first
+	_, err = server.SpectateSession(second.ID, spectatorID, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, first.SpectatorCount())
+	assert.Equal(t, 1, second.SpectatorCount())
+
+	assert.ErrorIs(t, server.FollowSpectatedPlayer(spectatorID, player.ID), game.ErrFollowTargetMissing)
+	require.NoError(t, server.FollowSpectatedPlayer(spectatorID, other.ID))
+
+	require.NoError(t, server.StopSpectating(spectatorID))
+	assert.Equal(t, 0, second.SpectatorCount())
+	assert.ErrorIs(t, server.StopSpectating(spectatorID), game.ErrNotSpectating)
+}
+
+// TestSpectateOverWebsocket tests the spectate action reply and the live stream
+func TestSpectateOverWebsocket(t *testing.T) {
+	server := NewServer(&MockAuthClient{}, nil, nil)
+
+	player := &types.Player{ID: uuid.New(), Username: "player"}
+	session, err := server.CreateGameSession([]*types.Player{player})
+	require.NoError(t, err)
+	defer session.Shutdown()
+
+	spectator := &types.Player{ID: uuid.New(), Username: "watcher"}
+	conn := &websocket.Conn{}
+	ch := registerTestConn(server, conn, spectator)
+
+	server.serverChan <- types.ClientPackage{
+		Conn: conn,
+		Message: types.Message{
+			Action: string(constants.ActionSpectate),
+			Payload: map[string]interface{}{
+				"session_id": session.ID.String(),
+				"follow_id":  player.ID.String(),
+			},
+		},
+	}
+
+	reply := waitForAction(t, ch, constants.ActionSpectate)
+	assert.Equal(t, true, reply.Payload["success"])
+	assert.Equal(t, "coop", reply.Payload["game_mode"])
+
+	// co-op streams without a delay
+	state := waitForAction(t, ch, constants.ActionSpectateState)
+	assert.Equal(t, session.ID.String(), state.Payload["session_id"])
+
+	server.serverChan <- types.ClientPackage{
+		Conn: conn,
+		Message: types.Message{
+			Action:  string(constants.ActionSpectateFollow),
+			Payload: map[string]interface{}{"follow_id": "not-an-id"},
+		},
+	}
+	failed := waitForAction(t, ch, constants.ActionSpectateFollow)
+	assert.Equal(t, string(constants.ErrorInvalidPayload), failed.Payload["reason"])
+}
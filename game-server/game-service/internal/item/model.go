@@ -0,0 +1,88 @@
+package item
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+/**
+* Base item definition from the catalog.
+**/
+type BaseItem struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	Name       string    `db:"name" json:"name"`
+	Type       string    `db:"type" json:"type"`
+	Category   string    `db:"category" json:"category"`
+	Slot       string    `db:"slot" json:"slot"`
+	ImageURL   string    `db:"image_url" json:"imageUrl"`
+	IsTwoHands bool      `db:"is_two_hands" json:"isTwoHands"`
+
+	RequiredLevel        int `db:"required_level" json:"requiredLevel"`
+	RequiredStrength     int `db:"required_strength" json:"requiredStrength"`
+	RequiredAgility      int `db:"required_agility" json:"requiredAgility"`
+	RequiredIntelligence int `db:"required_intelligence" json:"requiredIntelligence"`
+
+	// weapon
+	DamageMin   int     `db:"damage_min" json:"damageMin"`
+	DamageMax   int     `db:"damage_max" json:"damageMax"`
+	AttackSpeed float64 `db:"attack_speed" json:"attackSpeed"`
+
+	// armour
+	Armour       int `db:"armour" json:"armour"`
+	Evasion      int `db:"evasion" json:"evasion"`
+	EnergyShield int `db:"energy_shield" json:"energyShield"`
+
+	Implicit  pq.StringArray `db:"implicit" json:"implicit"`
+	CreatedAt time.Time      `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time      `db:"updated_at" json:"updatedAt"`
+}
+
+/**
+* Modifier that can roll onto an item. The # in Stat is replaced by the
+* rolled value.
+**/
+type ItemMod struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	Affix     string    `db:"affix" json:"affix"`
+	Name      string    `db:"name" json:"name"`
+	Level     int       `db:"level" json:"level"`
+	Stat      string    `db:"stat" json:"stat"`
+	MinValue  int       `db:"min_value" json:"minValue"`
+	MaxValue  int       `db:"max_value" json:"maxValue"`
+	Tags      string    `db:"tags" json:"tags"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+/**
+* Item owned by a member.
+**/
+type ItemInstance struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	MemberID  uuid.UUID `db:"member_id" json:"memberId"`
+	ItemID    uuid.UUID `db:"item_id" json:"itemId"`
+	Rarity    string    `db:"rarity" json:"rarity"`
+	ItemLevel int       `db:"item_level" json:"itemLevel"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// modifier rolled onto an instance, joined with its definition
+type InstanceModifier struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	InstanceID uuid.UUID `db:"instance_id" json:"instanceId"`
+	ModID      uuid.UUID `db:"mod_id" json:"modId"`
+	Affix      string    `db:"affix" json:"affix"`
+	Name       string    `db:"name" json:"name"`
+	Stat       string    `db:"stat" json:"stat"`
+	Value      int       `db:"value" json:"value"`
+}
+
+type ListItemsParams struct {
+	Category string
+	Slot     string
+	Limit    int
+	Offset   int
+}
@@ -0,0 +1,206 @@
+package item
+
+import (
+	"fmt"
+	"strings"
+
+	commonhelpers "github.com/darkphotonKN/cosmic-void-server/common/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository struct {
+	DB *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{
+		DB: db,
+	}
+}
+
+/**
+* --- Catalog ---
+**/
+
+/**
+* lists base items, optionally filtered by category and slot, along with the
+* total matching the filters.
+**/
+func (r *Repository) ListItems(params ListItemsParams) ([]BaseItem, int, error) {
+	conditions := make([]string, 0, 2)
+	args := make([]interface{}, 0, 4)
+
+	if params.Category != "" {
+		args = append(args, params.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+
+	if params.Slot != "" {
+		args = append(args, params.Slot)
+		conditions = append(conditions, fmt.Sprintf("slot = $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM items %s`, where)
+	if err := r.DB.Get(&total, countQuery, args...); err != nil {
+		return nil, 0, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	args = append(args, params.Limit, params.Offset)
+	query := fmt.Sprintf(`
+	SELECT * FROM items
+	%s
+	ORDER BY required_level, name
+	LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+
+	items := make([]BaseItem, 0)
+	if err := r.DB.Select(&items, query, args...); err != nil {
+		return nil, 0, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return items, total, nil
+}
+
+func (r *Repository) GetItemByID(id uuid.UUID) (*BaseItem, error) {
+	query := `SELECT * FROM items WHERE items.id = $1`
+
+	var item BaseItem
+	if err := r.DB.Get(&item, query, id); err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return &item, nil
+}
+
+func (r *Repository) GetItemsByIDs(ids []uuid.UUID) ([]BaseItem, error) {
+	items := make([]BaseItem, 0, len(ids))
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM items WHERE items.id IN (?)`, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.DB.Select(&items, r.DB.Rebind(query), args...); err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return items, nil
+}
+
+/**
+* gets every modifier that is allowed to roll on an item of this level.
+**/
+func (r *Repository) GetModsUpToLevel(level int) ([]ItemMod, error) {
+	query := `SELECT * FROM item_mods WHERE item_mods.level <= $1 ORDER BY level, name`
+
+	mods := make([]ItemMod, 0)
+	if err := r.DB.Select(&mods, query, level); err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return mods, nil
+}
+
+/**
+* --- Instances ---
+**/
+
+/**
+* creates an item instance along with its rolled modifiers in one
+* transaction.
+**/
+func (r *Repository) CreateInstance(instance *ItemInstance, mods []RolledMod) error {
+	return commonhelpers.ExecTx(r.DB, func(tx *sqlx.Tx) error {
+		query := `
+		INSERT INTO item_instances(member_id, item_id, rarity, item_level)
+		VALUES($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+		`
+
+		err := tx.QueryRowx(query, instance.MemberID, instance.ItemID, instance.Rarity, instance.ItemLevel).
+			Scan(&instance.ID, &instance.CreatedAt, &instance.UpdatedAt)
+		if err != nil {
+			return commonhelpers.AnalyzeDBErr(err)
+		}
+
+		modQuery := `
+		INSERT INTO item_instance_mods(instance_id, mod_id, value)
+		VALUES($1, $2, $3)
+		`
+
+		for _, mod := range mods {
+			if _, err := tx.Exec(modQuery, instance.ID, mod.Mod.ID, mod.Value); err != nil {
+				return commonhelpers.AnalyzeDBErr(err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) GetInstanceByID(id uuid.UUID) (*ItemInstance, error) {
+	query := `SELECT * FROM item_instances WHERE item_instances.id = $1`
+
+	var instance ItemInstance
+	if err := r.DB.Get(&instance, query, id); err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return &instance, nil
+}
+
+func (r *Repository) ListInstancesByMember(memberID uuid.UUID) ([]ItemInstance, error) {
+	query := `SELECT * FROM item_instances WHERE item_instances.member_id = $1 ORDER BY created_at DESC`
+
+	instances := make([]ItemInstance, 0)
+	if err := r.DB.Select(&instances, query, memberID); err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return instances, nil
+}
+
+/**
+* gets the rolled modifiers of every instance given, joined with their
+* definitions.
+**/
+func (r *Repository) GetInstanceModifiers(instanceIDs []uuid.UUID) ([]InstanceModifier, error) {
+	modifiers := make([]InstanceModifier, 0)
+	if len(instanceIDs) == 0 {
+		return modifiers, nil
+	}
+
+	query, args, err := sqlx.In(`
+	SELECT
+		item_instance_mods.id,
+		item_instance_mods.instance_id,
+		item_instance_mods.mod_id,
+		item_mods.affix,
+		item_mods.name,
+		item_mods.stat,
+		item_instance_mods.value
+	FROM item_instance_mods
+	JOIN item_mods ON item_mods.id = item_instance_mods.mod_id
+	WHERE item_instance_mods.instance_id IN (?)
+	ORDER BY item_mods.affix, item_instance_mods.created_at
+	`, instanceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.DB.Select(&modifiers, r.DB.Rebind(query), args...); err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return modifiers, nil
+}
@@ -0,0 +1,160 @@
+package item
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+/**
+* Modifier rolling
+*
+* Rarity decides how many modifiers an item gets and how many of them can be
+* prefixes or suffixes. Only modifiers at or below the item level can roll,
+* and an item never gets two modifiers for the same stat.
+**/
+
+type Rarity string
+
+const (
+	RarityNormal Rarity = "normal"
+	RarityMagic  Rarity = "magic"
+	RarityRare   Rarity = "rare"
+)
+
+const (
+	AffixPrefix = "prefix"
+	AffixSuffix = "suffix"
+)
+
+var ErrUnknownRarity = errors.New("Rarity does not exist.")
+
+type affixLimits struct {
+	minMods     int
+	maxMods     int
+	maxPrefixes int
+	maxSuffixes int
+}
+
+var rarityLimits = map[Rarity]affixLimits{
+	RarityNormal: {minMods: 0, maxMods: 0, maxPrefixes: 0, maxSuffixes: 0},
+	RarityMagic:  {minMods: 1, maxMods: 2, maxPrefixes: 1, maxSuffixes: 1},
+	RarityRare:   {minMods: 3, maxMods: 6, maxPrefixes: 3, maxSuffixes: 3},
+}
+
+type RolledMod struct {
+	Mod   ItemMod
+	Value int
+}
+
+func ParseRarity(rarity string) (Rarity, error) {
+	if rarity == "" {
+		return RarityNormal, nil
+	}
+
+	parsed := Rarity(rarity)
+	if _, ok := rarityLimits[parsed]; !ok {
+		return "", ErrUnknownRarity
+	}
+
+	return parsed, nil
+}
+
+/**
+* rolls modifiers for an item of the given rarity and level out of the pool.
+* Fewer modifiers than the rarity allows are rolled when the pool runs dry.
+**/
+func RollModifiers(rng *rand.Rand, rarity Rarity, itemLevel int, pool []ItemMod) ([]RolledMod, error) {
+	limits, ok := rarityLimits[rarity]
+	if !ok {
+		return nil, ErrUnknownRarity
+	}
+
+	if limits.maxMods == 0 {
+		return []RolledMod{}, nil
+	}
+
+	prefixes := make([]ItemMod, 0)
+	suffixes := make([]ItemMod, 0)
+
+	for _, mod := range pool {
+		if mod.Level > itemLevel {
+			continue
+		}
+
+		switch mod.Affix {
+		case AffixPrefix:
+			prefixes = append(prefixes, mod)
+		case AffixSuffix:
+			suffixes = append(suffixes, mod)
+		}
+	}
+
+	rng.Shuffle(len(prefixes), func(i, j int) { prefixes[i], prefixes[j] = prefixes[j], prefixes[i] })
+	rng.Shuffle(len(suffixes), func(i, j int) { suffixes[i], suffixes[j] = suffixes[j], suffixes[i] })
+
+	target := limits.minMods + rng.Intn(limits.maxMods-limits.minMods+1)
+
+	rolled := make([]RolledMod, 0, target)
+	usedStats := make(map[string]bool)
+	prefixCount, suffixCount := 0, 0
+
+	// takes the next mod off the list whose stat hasn't been rolled yet
+	take := func(mods *[]ItemMod) (ItemMod, bool) {
+		for len(*mods) > 0 {
+			mod := (*mods)[0]
+			*mods = (*mods)[1:]
+
+			if !usedStats[mod.Stat] {
+				return mod, true
+			}
+		}
+		return ItemMod{}, false
+	}
+
+	for len(rolled) < target {
+		canPrefix := prefixCount < limits.maxPrefixes && len(prefixes) > 0
+		canSuffix := suffixCount < limits.maxSuffixes && len(suffixes) > 0
+
+		if !canPrefix && !canSuffix {
+			break
+		}
+
+		usePrefix := canPrefix && (!canSuffix || rng.Intn(2) == 0)
+
+		var mod ItemMod
+		var found bool
+
+		if usePrefix {
+			mod, found = take(&prefixes)
+		} else {
+			mod, found = take(&suffixes)
+		}
+
+		if !found {
+			continue
+		}
+
+		usedStats[mod.Stat] = true
+		if usePrefix {
+			prefixCount++
+		} else {
+			suffixCount++
+		}
+
+		rolled = append(rolled, RolledMod{
+			Mod:   mod,
+			Value: mod.MinValue + rng.Intn(mod.MaxValue-mod.MinValue+1),
+		})
+	}
+
+	return rolled, nil
+}
+
+/**
+* fills in the rolled value for a modifier's stat text.
+**/
+func FormatStat(stat string, value int) string {
+	return strings.Replace(stat, "#", strconv.Itoa(value), 1)
+}
@@ -0,0 +1,109 @@
+package item
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testModPool() []ItemMod {
+	pool := make([]ItemMod, 0)
+
+	for i := 0; i < 5; i++ {
+		pool = append(pool,
+			ItemMod{ID: uuid.New(), Affix: AffixPrefix, Name: fmt.Sprintf("Prefix %d", i), Level: 1, Stat: fmt.Sprintf("+# to prefix stat %d", i), MinValue: 5, MaxValue: 10},
+			ItemMod{ID: uuid.New(), Affix: AffixSuffix, Name: fmt.Sprintf("Suffix %d", i), Level: 1, Stat: fmt.Sprintf("+# to suffix stat %d", i), MinValue: 1, MaxValue: 3},
+		)
+	}
+
+	// only allowed on high level items
+	pool = append(pool, ItemMod{ID: uuid.New(), Affix: AffixPrefix, Name: "Godly", Level: 80, Stat: "+# to everything", MinValue: 100, MaxValue: 200})
+
+	return pool
+}
+
+// TestRollModifiers tests that rolled modifiers respect rarity limits, item level and value ranges
+func TestRollModifiers(t *testing.T) {
+	tests := []struct {
+		name        string
+		rarity      Rarity
+		minMods     int
+		maxMods     int
+		maxPrefixes int
+		maxSuffixes int
+	}{
+		{name: "normal items have no modifiers", rarity: RarityNormal, minMods: 0, maxMods: 0},
+		{name: "magic items have one prefix and one suffix at most", rarity: RarityMagic, minMods: 1, maxMods: 2, maxPrefixes: 1, maxSuffixes: 1},
+		{name: "rare items have three prefixes and three suffixes at most", rarity: RarityRare, minMods: 3, maxMods: 6, maxPrefixes: 3, maxSuffixes: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(42))
+
+			for i := 0; i < 100; i++ {
+				rolled, err := RollModifiers(rng, tt.rarity, 10, testModPool())
+				require.NoError(t, err)
+
+				assert.GreaterOrEqual(t, len(rolled), tt.minMods)
+				assert.LessOrEqual(t, len(rolled), tt.maxMods)
+
+				prefixes, suffixes := 0, 0
+				stats := make(map[string]bool)
+
+				for _, mod := range rolled {
+					assert.LessOrEqual(t, mod.Mod.Level, 10, "mod above item level rolled")
+					assert.GreaterOrEqual(t, mod.Value, mod.Mod.MinValue)
+					assert.LessOrEqual(t, mod.Value, mod.Mod.MaxValue)
+					assert.False(t, stats[mod.Mod.Stat], "same stat rolled twice")
+					stats[mod.Mod.Stat] = true
+
+					if mod.Mod.Affix == AffixPrefix {
+						prefixes++
+					} else {
+						suffixes++
+					}
+				}
+
+				assert.LessOrEqual(t, prefixes, tt.maxPrefixes)
+				assert.LessOrEqual(t, suffixes, tt.maxSuffixes)
+			}
+		})
+	}
+}
+
+// TestRollModifiersSmallPool tests that rolling stops early when there aren't enough modifiers
+func TestRollModifiersSmallPool(t *testing.T) {
+	pool := []ItemMod{
+		{ID: uuid.New(), Affix: AffixPrefix, Name: "Healthy", Level: 1, Stat: "+# to maximum Life", MinValue: 10, MaxValue: 19},
+		{ID: uuid.New(), Affix: AffixPrefix, Name: "Sanguine", Level: 1, Stat: "+# to maximum Life", MinValue: 20, MaxValue: 29},
+	}
+
+	rolled, err := RollModifiers(rand.New(rand.NewSource(1)), RarityRare, 10, pool)
+	require.NoError(t, err)
+
+	// both share a stat so only one can roll
+	assert.Len(t, rolled, 1)
+}
+
+// TestRollModifiersUnknownRarity tests that unknown rarities are rejected
+func TestRollModifiersUnknownRarity(t *testing.T) {
+	_, err := RollModifiers(rand.New(rand.NewSource(1)), Rarity("legendary"), 10, testModPool())
+	assert.ErrorIs(t, err, ErrUnknownRarity)
+
+	_, err = ParseRarity("legendary")
+	assert.ErrorIs(t, err, ErrUnknownRarity)
+
+	rarity, err := ParseRarity("")
+	require.NoError(t, err)
+	assert.Equal(t, RarityNormal, rarity)
+}
+
+func TestFormatStat(t *testing.T) {
+	assert.Equal(t, "+15 to maximum Life", FormatStat("+# to maximum Life", 15))
+	assert.Equal(t, "7% increased Attack Speed", FormatStat("#% increased Attack Speed", 7))
+}
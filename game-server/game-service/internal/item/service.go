@@ -0,0 +1,311 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/game"
+	commonconstants "github.com/darkphotonKN/cosmic-void-server/common/constants"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+type service struct {
+	Repo *Repository
+
+	// rand.Rand isn't safe for concurrent use
+	rng   *rand.Rand
+	rngMu sync.Mutex
+}
+
+func NewService(repo *Repository) *service {
+	return &service{
+		Repo: repo,
+		rng:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *service) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	offset := int(req.Offset)
+	if offset < 0 {
+		offset = 0
+	}
+
+	items, total, err := s.Repo.ListItems(ListItemsParams{
+		Category: req.Category,
+		Slot:     req.Slot,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	response := &pb.ListItemsResponse{
+		Items: make([]*pb.BaseItem, 0, len(items)),
+		Total: int32(total),
+	}
+
+	for i := range items {
+		response.Items = append(response.Items, baseItemToProto(&items[i]))
+	}
+
+	return response, nil
+}
+
+func (s *service) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.BaseItem, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid item id: %s", req.Id)
+	}
+
+	item, err := s.Repo.GetItemByID(id)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	return baseItemToProto(item), nil
+}
+
+/**
+* creates an item for a member, rolling its modifiers from every modifier
+* allowed at its item level. Item level defaults to the base item's required
+* level.
+**/
+func (s *service) CreateItemInstance(ctx context.Context, req *pb.CreateItemInstanceRequest) (*pb.ItemInstance, error) {
+	memberID, err := uuid.Parse(req.MemberId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid member id: %s", req.MemberId)
+	}
+
+	baseItemID, err := uuid.Parse(req.BaseItemId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid base item id: %s", req.BaseItemId)
+	}
+
+	rarity, err := ParseRarity(req.Rarity)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown rarity: %s", req.Rarity)
+	}
+
+	if req.ItemLevel < 0 {
+		return nil, status.Error(codes.InvalidArgument, "item level can't be negative")
+	}
+
+	baseItem, err := s.Repo.GetItemByID(baseItemID)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	itemLevel := int(req.ItemLevel)
+	if itemLevel == 0 {
+		itemLevel = baseItem.RequiredLevel
+	}
+
+	pool, err := s.Repo.GetModsUpToLevel(itemLevel)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	s.rngMu.Lock()
+	rolled, err := RollModifiers(s.rng, rarity, itemLevel, pool)
+	s.rngMu.Unlock()
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	instance := &ItemInstance{
+		MemberID:  memberID,
+		ItemID:    baseItem.ID,
+		Rarity:    string(rarity),
+		ItemLevel: itemLevel,
+	}
+
+	if err := s.Repo.CreateInstance(instance, rolled); err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	modifiers := make([]InstanceModifier, 0, len(rolled))
+	for _, mod := range rolled {
+		modifiers = append(modifiers, InstanceModifier{
+			InstanceID: instance.ID,
+			ModID:      mod.Mod.ID,
+			Affix:      mod.Mod.Affix,
+			Name:       mod.Mod.Name,
+			Stat:       mod.Mod.Stat,
+			Value:      mod.Value,
+		})
+	}
+
+	return instanceToProto(instance, baseItem, modifiers), nil
+}
+
+func (s *service) GetItemInstance(ctx context.Context, req *pb.GetItemInstanceRequest) (*pb.ItemInstance, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid item instance id: %s", req.Id)
+	}
+
+	instance, err := s.Repo.GetInstanceByID(id)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	instances, err := s.hydrateInstances([]ItemInstance{*instance})
+	if err != nil {
+		return nil, err
+	}
+
+	return instances[0], nil
+}
+
+func (s *service) ListMemberItems(ctx context.Context, req *pb.ListMemberItemsRequest) (*pb.ListMemberItemsResponse, error) {
+	memberID, err := uuid.Parse(req.MemberId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid member id: %s", req.MemberId)
+	}
+
+	instances, err := s.Repo.ListInstancesByMember(memberID)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	items, err := s.hydrateInstances(instances)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListMemberItemsResponse{Items: items}, nil
+}
+
+/**
+* loads base items and modifiers for the instances in bulk instead of one
+* query per instance.
+**/
+func (s *service) hydrateInstances(instances []ItemInstance) ([]*pb.ItemInstance, error) {
+	instanceIDs := make([]uuid.UUID, 0, len(instances))
+	baseItemIDs := make([]uuid.UUID, 0, len(instances))
+	seenBaseItems := make(map[uuid.UUID]bool)
+
+	for _, instance := range instances {
+		instanceIDs = append(instanceIDs, instance.ID)
+
+		if !seenBaseItems[instance.ItemID] {
+			seenBaseItems[instance.ItemID] = true
+			baseItemIDs = append(baseItemIDs, instance.ItemID)
+		}
+	}
+
+	baseItems, err := s.Repo.GetItemsByIDs(baseItemIDs)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	baseItemsByID := make(map[uuid.UUID]*BaseItem, len(baseItems))
+	for i := range baseItems {
+		baseItemsByID[baseItems[i].ID] = &baseItems[i]
+	}
+
+	modifiers, err := s.Repo.GetInstanceModifiers(instanceIDs)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	modifiersByInstance := make(map[uuid.UUID][]InstanceModifier, len(instances))
+	for _, modifier := range modifiers {
+		modifiersByInstance[modifier.InstanceID] = append(modifiersByInstance[modifier.InstanceID], modifier)
+	}
+
+	result := make([]*pb.ItemInstance, 0, len(instances))
+	for i := range instances {
+		instance := &instances[i]
+		result = append(result, instanceToProto(instance, baseItemsByID[instance.ItemID], modifiersByInstance[instance.ID]))
+	}
+
+	return result, nil
+}
+
+func baseItemToProto(item *BaseItem) *pb.BaseItem {
+	if item == nil {
+		return nil
+	}
+
+	return &pb.BaseItem{
+		Id:                   item.ID.String(),
+		Name:                 item.Name,
+		Type:                 item.Type,
+		Category:             item.Category,
+		Slot:                 item.Slot,
+		ImageUrl:             item.ImageURL,
+		IsTwoHands:           item.IsTwoHands,
+		RequiredLevel:        int32(item.RequiredLevel),
+		RequiredStrength:     int32(item.RequiredStrength),
+		RequiredAgility:      int32(item.RequiredAgility),
+		RequiredIntelligence: int32(item.RequiredIntelligence),
+		DamageMin:            int32(item.DamageMin),
+		DamageMax:            int32(item.DamageMax),
+		AttackSpeed:          item.AttackSpeed,
+		Armour:               int32(item.Armour),
+		Evasion:              int32(item.Evasion),
+		EnergyShield:         int32(item.EnergyShield),
+		Implicit:             item.Implicit,
+	}
+}
+
+func instanceToProto(instance *ItemInstance, baseItem *BaseItem, modifiers []InstanceModifier) *pb.ItemInstance {
+	result := &pb.ItemInstance{
+		Id:        instance.ID.String(),
+		MemberId:  instance.MemberID.String(),
+		BaseItem:  baseItemToProto(baseItem),
+		Rarity:    instance.Rarity,
+		ItemLevel: int32(instance.ItemLevel),
+		Modifiers: make([]*pb.ItemModifier, 0, len(modifiers)),
+		CreatedAt: instance.CreatedAt.Format(time.RFC3339),
+	}
+
+	for _, modifier := range modifiers {
+		result.Modifiers = append(result.Modifiers, &pb.ItemModifier{
+			Id:    modifier.ModID.String(),
+			Affix: modifier.Affix,
+			Name:  modifier.Name,
+			Stat:  FormatStat(modifier.Stat, modifier.Value),
+			Value: int32(modifier.Value),
+		})
+	}
+
+	return result
+}
+
+/**
+* maps repository errors onto grpc status codes for the gateway.
+**/
+func toStatusErr(err error) error {
+	switch {
+	case errors.Is(err, commonconstants.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, commonconstants.ErrConstraintViolation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, commonconstants.ErrDuplicateResource):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
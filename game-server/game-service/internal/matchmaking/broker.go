@@ -0,0 +1,117 @@
+package matchmaking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	commonconstants "github.com/darkphotonKN/cosmic-void-server/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+/**
+* Match notifications
+*
+* The matcher publishes every match it allocates to a fanout exchange. Each
+* game node consumes them, the host node starts the match and the others
+* redirect any of its players connected to them.
+**/
+
+/**
+* MatchAllocatedEventPayload
+*
+* Published by the elected matcher.
+* Consumed by:
+* - every game-service instance
+**/
+type MatchAllocatedEventPayload struct {
+	Match    systems.Match `json:"match"`
+	NodeID   string        `json:"nodeId"`
+	NodeAddr string        `json:"nodeAddr"`
+}
+
+type MatchPublisher interface {
+	PublishMatch(ctx context.Context, payload MatchAllocatedEventPayload) error
+}
+
+type amqpPublisher struct {
+	publishCh *amqp.Channel
+}
+
+func NewPublisher(ch *amqp.Channel) MatchPublisher {
+	return &amqpPublisher{publishCh: ch}
+}
+
+func (p *amqpPublisher) PublishMatch(ctx context.Context, payload MatchAllocatedEventPayload) error {
+	marshalledPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return p.publishCh.PublishWithContext(
+		ctx,
+		commonconstants.MatchAllocatedEvent,
+		"",
+		false,
+		false,
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        marshalledPayload,
+		})
+}
+
+/**
+* consumes allocated matches on a queue of this node's own.
+**/
+func (d *Distributed) Listen(ch *amqp.Channel) error {
+	queueName := fmt.Sprintf("game.%s.%s", d.node.ID, commonconstants.MatchAllocatedEvent)
+
+	// matches are only useful while this node is up, so the queue goes
+	// away with it
+	queue, err := ch.QueueDeclare(queueName, false, true, true, false, nil)
+	if err != nil {
+		return err
+	}
+
+	err = ch.QueueBind(
+		queue.Name,
+		"",
+		commonconstants.MatchAllocatedEvent,
+		false,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for msg := range msgs {
+			var payload MatchAllocatedEventPayload
+
+			if err := json.Unmarshal(msg.Body, &payload); err != nil {
+				fmt.Printf("Error when unmarshalling match allocated event body: %s\n", err.Error())
+				continue
+			}
+
+			d.handleAllocated(payload)
+		}
+	}()
+
+	fmt.Printf("Node %s listening for allocated matches.\n", d.node.ID)
+	return nil
+}
+
+func (d *Distributed) handleAllocated(payload MatchAllocatedEventPayload) {
+	if payload.NodeID == d.node.ID {
+		d.matched <- payload.Match
+		return
+	}
+
+	d.host.RedirectMatch(payload.Match, payload.NodeAddr)
+}
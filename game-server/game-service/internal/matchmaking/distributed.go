@@ -0,0 +1,511 @@
+package matchmaking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/common/utils/cache"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Distributed matchmaker
+*
+* Queues shared by every game service instance through the cache, so players
+* are matched no matter which instance they're connected to. Each tick one
+* instance wins the matcher lock and forms matches, allocating each one to
+* the game node with the most room and announcing it through the broker.
+* Players backfilling a running session go to the node hosting it.
+**/
+
+/**
+* The game server on this instance.
+**/
+type Host interface {
+	SessionCount() int
+	RedirectMatch(match systems.Match, addr string)
+	GetMatchedChan() chan systems.Match
+	GetQueueStatusChan() chan systems.QueueStatus
+}
+
+type Distributed struct {
+	store     cache.Cache
+	publisher MatchPublisher
+	host      Host
+
+	queues       map[string]systems.QueueConfig
+	queueIDs     []string
+	defaultQueue string
+
+	node    Node
+	tick    time.Duration
+	nodeTTL time.Duration
+
+	matched  chan systems.Match
+	statuses chan systems.QueueStatus
+	// statuses waiting to be handed to the host, newer ones are dropped
+	// while it's behind
+	reports chan systems.QueueStatus
+}
+
+/**
+* shares the configured queues through the store, the first one is the
+* default queue. Matches hosted on this node come out of the host's matched
+* channel.
+**/
+func NewDistributed(store cache.Cache, configs []systems.QueueConfig, node Node, host Host, publisher MatchPublisher) (*Distributed, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("matchmaker needs at least one queue")
+	}
+
+	if node.ID == "" || node.MaxSessions < 1 {
+		return nil, fmt.Errorf("game node needs an id and room for at least one session")
+	}
+
+	d := &Distributed{
+		store:        store,
+		publisher:    publisher,
+		host:         host,
+		queues:       make(map[string]systems.QueueConfig, len(configs)),
+		defaultQueue: configs[0].ID,
+		node:         node,
+		tick:         time.Second,
+		nodeTTL:      3 * time.Second,
+		matched:      host.GetMatchedChan(),
+		statuses:     host.GetQueueStatusChan(),
+		reports:      make(chan systems.QueueStatus, len(configs)),
+	}
+
+	for _, config := range configs {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+
+		if _, exists := d.queues[config.ID]; exists {
+			return nil, fmt.Errorf("queue %s is configured twice", config.ID)
+		}
+
+		if config.TeamCount < 1 {
+			config.TeamCount = 1
+		}
+
+		d.queues[config.ID] = config
+		d.queueIDs = append(d.queueIDs, config.ID)
+	}
+
+	sort.Strings(d.queueIDs)
+
+	return d, nil
+}
+
+func (d *Distributed) Start() {
+	go d.forwardStatuses()
+	go d.run()
+	fmt.Printf("Distributed matchmaking started on node %s\n", d.node.ID)
+}
+
+func (d *Distributed) run() {
+	ticker := time.NewTicker(d.tick)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+
+		if err := d.heartbeat(ctx); err != nil {
+			fmt.Printf("Failed to send heartbeat for node %s: %v\n", d.node.ID, err)
+		}
+
+		if d.electMatcher(ctx) {
+			d.matchOnce(ctx, now)
+		}
+
+		d.reportStatuses(ctx)
+
+		cancel()
+	}
+}
+
+/**
+* whether this node forms matches this tick. The lock runs out on its own
+* after the tick so whichever node gets it first next tick takes over.
+**/
+func (d *Distributed) electMatcher(ctx context.Context) bool {
+	elected, err := d.store.Lock(ctx, matcherKey(), d.tick)
+	if err != nil {
+		fmt.Printf("Failed to run matcher election: %v\n", err)
+		return false
+	}
+	return elected
+}
+
+/**
+* forms every match the queues can start and allocates each to a node.
+**/
+func (d *Distributed) matchOnce(ctx context.Context, now time.Time) {
+	nodes, err := d.liveNodes(ctx)
+	if err != nil {
+		fmt.Printf("Failed to load game nodes: %v\n", err)
+		return
+	}
+
+	for _, queueID := range d.queueIDs {
+		config := d.queues[queueID]
+
+		for {
+			var match *systems.Match
+			host := -1
+			full := false
+
+			err := withQueue(ctx, d.store, config, func(queue *systems.QueueSystem) error {
+				// running sessions are filled before new ones start, sessions
+				// on nodes that went away can't be
+				hosts := make(map[uuid.UUID]int)
+				for _, slot := range queue.Backfills() {
+					index := nodeIndex(nodes, slot.Host)
+					if index == -1 {
+						queue.CloseBackfill(slot.SessionID)
+						continue
+					}
+					hosts[slot.SessionID] = index
+				}
+
+				if match = queue.TakeBackfill(); match != nil {
+					host = hosts[match.SessionID]
+					return nil
+				}
+
+				next, available := pickNode(nodes)
+				if !available {
+					full = true
+					return nil
+				}
+
+				host = next
+				match = queue.TakeMatch(now)
+				return nil
+			})
+
+			if err != nil {
+				fmt.Printf("Failed to match queue %s: %v\n", queueID, err)
+				break
+			}
+
+			if full {
+				fmt.Println("No game node has room for another session, waiting")
+				return
+			}
+
+			if match == nil {
+				break
+			}
+
+			if match.SessionID != uuid.Nil {
+				fmt.Printf("Backfilling session %s from queue %s on node %s\n", match.SessionID, queueID, nodes[host].ID)
+				d.allocate(ctx, *match, nodes[host])
+				continue
+			}
+
+			fmt.Printf("Match found in queue %s, hosting on node %s\n", queueID, nodes[host].ID)
+			d.allocate(ctx, *match, nodes[host])
+
+			// matches later in the pass spread out
+			nodes[host].Sessions++
+		}
+	}
+}
+
+func (d *Distributed) allocate(ctx context.Context, match systems.Match, node Node) {
+	err := d.publisher.PublishMatch(ctx, MatchAllocatedEventPayload{
+		Match:    match,
+		NodeID:   node.ID,
+		NodeAddr: node.Addr,
+	})
+
+	if err == nil {
+		return
+	}
+
+	fmt.Printf("Failed to publish match from queue %s, requeueing players: %v\n", match.QueueID, err)
+	if err := d.RequeueFront(match, match.Players); err != nil {
+		fmt.Printf("Failed to requeue players from queue %s: %v\n", match.QueueID, err)
+	}
+}
+
+/**
+* tells waiting players how full their queue is, the host only reaches the
+* ones connected here.
+**/
+func (d *Distributed) reportStatuses(ctx context.Context) {
+	for _, queueID := range d.queueIDs {
+		config := d.queues[queueID]
+
+		queue, err := loadQueue(ctx, d.store, config)
+		if err != nil {
+			fmt.Printf("Failed to load queue %s: %v\n", queueID, err)
+			continue
+		}
+
+		players := make([]*types.Player, 0)
+		for _, group := range queue.Snapshot() {
+			players = append(players, group.Players...)
+		}
+
+		if len(players) == 0 {
+			continue
+		}
+
+		// the next tick reports again, there's no point queueing up old counts
+		select {
+		case d.reports <- systems.QueueStatus{
+			QueueID: config.ID,
+			Players: players,
+			Current: len(players),
+			Total:   config.MatchSize,
+		}:
+		default:
+		}
+	}
+}
+
+// hands queue statuses to the host one at a time, however slow it is
+func (d *Distributed) forwardStatuses() {
+	for status := range d.reports {
+		d.statuses <- status
+	}
+}
+
+func (d *Distributed) DefaultQueueID() string {
+	return d.defaultQueue
+}
+
+func (d *Distributed) QueueConfig(queueID string) (systems.QueueConfig, bool) {
+	config, exists := d.queues[queueID]
+	return config, exists
+}
+
+/**
+* queues players that are matched together and put on the same team, an
+* empty queue id means the default queue.
+**/
+func (d *Distributed) AddGroup(queueID string, players []*types.Player) (string, error) {
+	if queueID == "" {
+		queueID = d.defaultQueue
+	}
+
+	config, exists := d.queues[queueID]
+	if !exists {
+		return queueID, systems.ErrQueueNotFound
+	}
+
+	if len(players) > config.TeamSize() {
+		return queueID, systems.ErrPartyTooLarge
+	}
+
+	for _, player := range players {
+		if d.PenaltyRemaining(player.ID) > 0 {
+			return queueID, systems.ErrQueuePenalty
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	err := withQueue(ctx, d.store, config, func(queue *systems.QueueSystem) error {
+		// checked under the lock so two nodes can't both queue the player
+		for _, player := range players {
+			if queue.Contains(player.ID) {
+				return systems.ErrAlreadyQueued
+			}
+
+			exists, err := d.store.Exists(ctx, playerKey(player.ID))
+			if err != nil {
+				return err
+			}
+			if exists {
+				return systems.ErrAlreadyQueued
+			}
+		}
+
+		queue.GroupJoinQueue(players)
+		return nil
+	})
+
+	return queueID, err
+}
+
+/**
+* takes the player out of whichever queue they're waiting in, reporting
+* whether they were waiting at all.
+**/
+func (d *Distributed) RemovePlayer(player *types.Player) bool {
+	queueID, queued := d.QueueOf(player.ID)
+	if !queued {
+		return false
+	}
+
+	config, exists := d.queues[queueID]
+	if !exists {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	removed := false
+	err := withQueue(ctx, d.store, config, func(queue *systems.QueueSystem) error {
+		removed = queue.Contains(player.ID)
+		queue.PlayerRemoveQueue(player)
+		return nil
+	})
+
+	if err != nil {
+		fmt.Printf("Failed to remove player %s from queue %s: %v\n", player.ID, queueID, err)
+		return false
+	}
+
+	return removed
+}
+
+/**
+* puts players from a match that didn't start back at the front of the
+* queue they matched in.
+**/
+func (d *Distributed) RequeueFront(match systems.Match, players []*types.Player) error {
+	config, exists := d.queues[match.QueueID]
+	if !exists {
+		return systems.ErrQueueNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	return withQueue(ctx, d.store, config, func(queue *systems.QueueSystem) error {
+		queue.RequeueFront(players, match.JoinedAt)
+		return nil
+	})
+}
+
+/**
+* opens a session hosted on this node to players from the queue it was
+* matched in.
+**/
+func (d *Distributed) OpenBackfill(queueID string, slot systems.BackfillSlot) error {
+	config, exists := d.queues[queueID]
+	if !exists {
+		return systems.ErrQueueNotFound
+	}
+
+	slot.Host = d.node.ID
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	return withQueue(ctx, d.store, config, func(queue *systems.QueueSystem) error {
+		queue.OpenBackfill(slot)
+		return nil
+	})
+}
+
+func (d *Distributed) CloseBackfill(queueID string, sessionID uuid.UUID) {
+	config, exists := d.queues[queueID]
+	if !exists {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	err := withQueue(ctx, d.store, config, func(queue *systems.QueueSystem) error {
+		queue.CloseBackfill(sessionID)
+		return nil
+	})
+
+	if err != nil {
+		fmt.Printf("Failed to close backfill for session %s: %v\n", sessionID, err)
+	}
+}
+
+/**
+* stops the player from queueing on any node for the given time.
+**/
+func (d *Distributed) Penalize(playerID uuid.UUID, duration time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	until := time.Now().Add(duration).Format(time.RFC3339Nano)
+	if err := d.store.Set(ctx, penaltyKey(playerID), until, duration); err != nil {
+		fmt.Printf("Failed to penalize player %s: %v\n", playerID, err)
+	}
+}
+
+func (d *Distributed) PenaltyRemaining(playerID uuid.UUID) time.Duration {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	raw, err := d.store.Get(ctx, penaltyKey(playerID))
+	if err != nil {
+		if !errors.Is(err, cache.ErrCacheMiss) {
+			fmt.Printf("Failed to load penalty for player %s: %v\n", playerID, err)
+		}
+		return 0
+	}
+
+	until, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return 0
+	}
+
+	if remaining := time.Until(until); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+func (d *Distributed) QueueOf(playerID uuid.UUID) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	queueID, err := d.store.Get(ctx, playerKey(playerID))
+	if err != nil {
+		if !errors.Is(err, cache.ErrCacheMiss) {
+			fmt.Printf("Failed to look up queue for player %s: %v\n", playerID, err)
+		}
+		return "", false
+	}
+
+	return queueID, true
+}
+
+/**
+* stats for every queue ordered by id.
+**/
+func (d *Distributed) Stats() []systems.QueueStats {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	stats := make([]systems.QueueStats, 0, len(d.queueIDs))
+	for _, queueID := range d.queueIDs {
+		queue, err := loadQueue(ctx, d.store, d.queues[queueID])
+		if err != nil {
+			fmt.Printf("Failed to load queue %s: %v\n", queueID, err)
+			continue
+		}
+
+		stats = append(stats, queue.Stats())
+	}
+
+	return stats
+}
+
+func (d *Distributed) Matched() chan systems.Match {
+	return d.matched
+}
+
+func (d *Distributed) QueueStatuses() chan systems.QueueStatus {
+	return d.statuses
+}
@@ -0,0 +1,371 @@
+package matchmaking
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/common/utils/cache"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing queues shared between game nodes through the cache.
+**/
+
+// memoryCache stands in for redis, shared by every node in a test
+type memoryCache struct {
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string]string), expires: make(map[string]time.Time)}
+}
+
+// NOTE: caller must hold the lock
+func (c *memoryCache) live(key string) bool {
+	if expiry, expiring := c.expires[key]; expiring && time.Now().After(expiry) {
+		delete(c.values, key)
+		delete(c.expires, key)
+	}
+	_, exists := c.values[key]
+	return exists
+}
+
+func (c *memoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = value.(string)
+	delete(c.expires, key)
+	if expiration > 0 {
+		c.expires[key] = time.Now().Add(expiration)
+	}
+	return nil
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.live(key) {
+		return "", cache.ErrCacheMiss
+	}
+	return c.values[key], nil
+}
+
+func (c *memoryCache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.values, key)
+		delete(c.expires, key)
+	}
+	return nil
+}
+
+func (c *memoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.live(key), nil
+}
+
+func (c *memoryCache) Close() error {
+	return nil
+}
+
+func (c *memoryCache) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lockKey := "lock:" + key
+	if c.live(lockKey) {
+		return false, nil
+	}
+
+	c.values[lockKey] = "locked"
+	c.expires[lockKey] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (c *memoryCache) Unlock(ctx context.Context, key string) error {
+	return c.Del(ctx, "lock:"+key)
+}
+
+func (c *memoryCache) LockWithToken(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lockKey := "lock:" + key
+	if c.live(lockKey) {
+		return false, nil
+	}
+
+	c.values[lockKey] = token
+	c.expires[lockKey] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (c *memoryCache) UnlockWithToken(ctx context.Context, key string, token string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lockKey := "lock:" + key
+	if c.live(lockKey) && c.values[lockKey] == token {
+		delete(c.values, lockKey)
+		delete(c.expires, lockKey)
+	}
+	return nil
+}
+
+type fakeHost struct {
+	sessions   int
+	redirected []string
+	matched    chan systems.Match
+	statuses   chan systems.QueueStatus
+}
+
+func newFakeHost(sessions int) *fakeHost {
+	return &fakeHost{
+		sessions: sessions,
+		matched:  make(chan systems.Match, 10),
+		statuses: make(chan systems.QueueStatus, 10),
+	}
+}
+
+func (h *fakeHost) SessionCount() int {
+	return h.sessions
+}
+
+func (h *fakeHost) RedirectMatch(match systems.Match, addr string) {
+	h.redirected = append(h.redirected, addr)
+}
+
+func (h *fakeHost) GetMatchedChan() chan systems.Match {
+	return h.matched
+}
+
+func (h *fakeHost) GetQueueStatusChan() chan systems.QueueStatus {
+	return h.statuses
+}
+
+// fakePublisher records matches instead of sending them to the broker
+type fakePublisher struct {
+	published []MatchAllocatedEventPayload
+}
+
+func (p *fakePublisher) PublishMatch(ctx context.Context, payload MatchAllocatedEventPayload) error {
+	p.published = append(p.published, payload)
+	return nil
+}
+
+func testQueues() []systems.QueueConfig {
+	return []systems.QueueConfig{
+		{ID: "duo", GameMode: "coop", MatchSize: 2, MinPlayers: 2, MaxPlayers: 2, TeamCount: 1},
+		{ID: "squad", GameMode: "coop", MatchSize: 4, MinPlayers: 4, MaxPlayers: 4, TeamCount: 1},
+	}
+}
+
+func newTestNode(t *testing.T, store cache.Cache, publisher MatchPublisher, id string, sessions int) (*Distributed, *fakeHost) {
+	t.Helper()
+
+	host := newFakeHost(sessions)
+	node := Node{ID: id, Addr: id + ":5555", MaxSessions: 2}
+
+	d, err := NewDistributed(store, testQueues(), node, host, publisher)
+	require.NoError(t, err)
+	require.NoError(t, d.heartbeat(context.Background()))
+
+	return d, host
+}
+
+// TestDistributedSharesQueues tests players on different nodes are matched together
+func TestDistributedSharesQueues(t *testing.T) {
+	store := newMemoryCache()
+	publisher := &fakePublisher{}
+	nodeA, hostA := newTestNode(t, store, publisher, "node-a", 0)
+	nodeB, hostB := newTestNode(t, store, publisher, "node-b", 1)
+
+	p1 := &types.Player{ID: uuid.New(), Username: "p1"}
+	p2 := &types.Player{ID: uuid.New(), Username: "p2"}
+
+	_, err := nodeA.AddGroup("", []*types.Player{p1})
+	require.NoError(t, err)
+	_, err = nodeB.AddGroup("duo", []*types.Player{p2})
+	require.NoError(t, err)
+
+	queueID, queued := nodeA.QueueOf(p2.ID)
+	assert.True(t, queued)
+	assert.Equal(t, "duo", queueID)
+
+	// one queue at a time, whichever node they ask
+	_, err = nodeA.AddGroup("squad", []*types.Player{p2})
+	assert.ErrorIs(t, err, systems.ErrAlreadyQueued)
+	_, err = nodeA.AddGroup("duo", []*types.Player{p2})
+	assert.ErrorIs(t, err, systems.ErrAlreadyQueued)
+
+	require.True(t, nodeB.electMatcher(context.Background()))
+	assert.False(t, nodeA.electMatcher(context.Background()))
+
+	nodeB.matchOnce(context.Background(), time.Now())
+
+	require.Len(t, publisher.published, 1)
+	allocated := publisher.published[0]
+	assert.Len(t, allocated.Match.Players, 2)
+	// node a runs fewer sessions
+	assert.Equal(t, "node-a", allocated.NodeID)
+
+	_, queued = nodeA.QueueOf(p1.ID)
+	assert.False(t, queued)
+
+	nodeA.handleAllocated(allocated)
+	nodeB.handleAllocated(allocated)
+
+	match := <-hostA.matched
+	assert.Equal(t, "duo", match.QueueID)
+	assert.Equal(t, []string{"node-a:5555"}, hostB.redirected)
+}
+
+// TestDistributedSkipsFullNodes tests matches wait while every node is full
+func TestDistributedSkipsFullNodes(t *testing.T) {
+	store := newMemoryCache()
+	publisher := &fakePublisher{}
+	node, _ := newTestNode(t, store, publisher, "node-a", 2)
+
+	for i := 0; i < 2; i++ {
+		_, err := node.AddGroup("duo", []*types.Player{{ID: uuid.New()}})
+		require.NoError(t, err)
+	}
+
+	node.matchOnce(context.Background(), time.Now())
+	assert.Empty(t, publisher.published)
+
+	stats := node.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, 2, stats[0].Waiting)
+}
+
+// TestDistributedRemoveAndPenalize tests leaving and penalties are seen by every node
+func TestDistributedRemoveAndPenalize(t *testing.T) {
+	store := newMemoryCache()
+	publisher := &fakePublisher{}
+	nodeA, _ := newTestNode(t, store, publisher, "node-a", 0)
+	nodeB, _ := newTestNode(t, store, publisher, "node-b", 0)
+
+	player := &types.Player{ID: uuid.New()}
+	_, err := nodeA.AddGroup("squad", []*types.Player{player})
+	require.NoError(t, err)
+
+	assert.True(t, nodeB.RemovePlayer(player))
+	assert.False(t, nodeA.RemovePlayer(player))
+
+	nodeA.Penalize(player.ID, time.Minute)
+	assert.Greater(t, nodeB.PenaltyRemaining(player.ID), time.Duration(0))
+
+	_, err = nodeB.AddGroup("squad", []*types.Player{player})
+	assert.ErrorIs(t, err, systems.ErrQueuePenalty)
+}
+
+// TestDistributedPrunesDeadNodes tests nodes without a heartbeat aren't given matches
+func TestDistributedPrunesDeadNodes(t *testing.T) {
+	store := newMemoryCache()
+	publisher := &fakePublisher{}
+	nodeA, _ := newTestNode(t, store, publisher, "node-a", 0)
+	_, _ = newTestNode(t, store, publisher, "node-b", 0)
+
+	require.NoError(t, store.Del(context.Background(), nodeKey("node-b")))
+
+	nodes, err := nodeA.liveNodes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	assert.Equal(t, "node-a", nodes[0].ID)
+
+	var nodeIDs []string
+	_, err = getJSON(context.Background(), store, nodesKey(), &nodeIDs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node-a"}, nodeIDs)
+}
+
+// TestDistributedBackfillGoesToHost tests backfills are allocated to the node running the session
+func TestDistributedBackfillGoesToHost(t *testing.T) {
+	store := newMemoryCache()
+	publisher := &fakePublisher{}
+	nodeA, _ := newTestNode(t, store, publisher, "node-a", 0)
+	// node b is full but still gets players for the game it hosts
+	nodeB, _ := newTestNode(t, store, publisher, "node-b", 2)
+
+	sessionID := uuid.New()
+	require.NoError(t, nodeB.OpenBackfill("squad", systems.BackfillSlot{SessionID: sessionID, Open: map[int]int{1: 1}}))
+
+	_, err := nodeA.AddGroup("squad", []*types.Player{{ID: uuid.New()}})
+	require.NoError(t, err)
+
+	nodeA.matchOnce(context.Background(), time.Now())
+
+	require.Len(t, publisher.published, 1)
+	allocated := publisher.published[0]
+	assert.Equal(t, sessionID, allocated.Match.SessionID)
+	assert.Equal(t, "node-b", allocated.NodeID)
+
+	// filled up, and closing it again is harmless
+	queue, err := loadQueue(context.Background(), store, testQueues()[1])
+	require.NoError(t, err)
+	assert.Empty(t, queue.Backfills())
+	nodeB.CloseBackfill("squad", sessionID)
+}
+
+// TestQueueLockOnlyReleasedByHolder tests a lock that ran out and was taken over isn't released by its old holder
+func TestQueueLockOnlyReleasedByHolder(t *testing.T) {
+	store := newMemoryCache()
+	ctx := context.Background()
+	key := queueKey("duo")
+
+	stale, err := acquire(ctx, store, key)
+	require.NoError(t, err)
+
+	// the lock runs out and another node takes it
+	require.NoError(t, store.Del(ctx, "lock:"+key))
+	holder, err := acquire(ctx, store, key)
+	require.NoError(t, err)
+
+	release(store, key, stale)
+	locked, err := store.LockWithToken(ctx, key, "other", lockTTL)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	release(store, key, holder)
+	locked, err = store.LockWithToken(ctx, key, "other", lockTTL)
+	require.NoError(t, err)
+	assert.True(t, locked)
+}
+
+// TestStatusesDroppedWhileHostIsBehind tests queue statuses don't pile up when nobody takes them
+func TestStatusesDroppedWhileHostIsBehind(t *testing.T) {
+	store := newMemoryCache()
+	node, host := newTestNode(t, store, &fakePublisher{}, "node-a", 0)
+	// nobody reads from the host
+	host.statuses = make(chan systems.QueueStatus)
+	node.statuses = host.statuses
+
+	for _, queueID := range []string{"duo", "squad"} {
+		_, err := node.AddGroup(queueID, []*types.Player{{ID: uuid.New()}})
+		require.NoError(t, err)
+	}
+
+	goroutines := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		node.reportStatuses(context.Background())
+	}
+
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+	assert.Len(t, node.reports, cap(node.reports))
+}
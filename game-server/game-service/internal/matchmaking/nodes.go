@@ -0,0 +1,153 @@
+package matchmaking
+
+import (
+	"context"
+	"fmt"
+)
+
+/**
+* Game nodes
+*
+* Every game service instance sends a heartbeat with how many sessions it
+* runs. The matcher hosts each match on the live node with the most room,
+* nodes that stop sending heartbeats are dropped.
+**/
+
+type Node struct {
+	ID string `json:"id"`
+	// websocket address players connect to for matches hosted here
+	Addr        string `json:"addr"`
+	MaxSessions int    `json:"maxSessions"`
+	Sessions    int    `json:"sessions"`
+}
+
+func (n Node) hasCapacity() bool {
+	return n.Sessions < n.MaxSessions
+}
+
+func (n Node) load() float64 {
+	return float64(n.Sessions) / float64(n.MaxSessions)
+}
+
+/**
+* refreshes this node's heartbeat and makes sure it's in the node list.
+**/
+func (d *Distributed) heartbeat(ctx context.Context) error {
+	node := d.node
+	node.Sessions = d.host.SessionCount()
+
+	if err := setJSON(ctx, d.store, nodeKey(node.ID), node, d.nodeTTL); err != nil {
+		return err
+	}
+
+	var nodeIDs []string
+	if _, err := getJSON(ctx, d.store, nodesKey(), &nodeIDs); err != nil {
+		return err
+	}
+
+	for _, id := range nodeIDs {
+		if id == node.ID {
+			return nil
+		}
+	}
+
+	return d.updateNodeList(ctx, func(nodeIDs []string) []string {
+		for _, id := range nodeIDs {
+			if id == node.ID {
+				return nodeIDs
+			}
+		}
+		return append(nodeIDs, node.ID)
+	})
+}
+
+/**
+* nodes with a current heartbeat, pruning the rest from the node list.
+**/
+func (d *Distributed) liveNodes(ctx context.Context) ([]Node, error) {
+	var nodeIDs []string
+	if _, err := getJSON(ctx, d.store, nodesKey(), &nodeIDs); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]Node, 0, len(nodeIDs))
+	dead := make(map[string]bool)
+
+	for _, id := range nodeIDs {
+		var node Node
+		alive, err := getJSON(ctx, d.store, nodeKey(id), &node)
+		if err != nil {
+			return nil, err
+		}
+
+		if !alive {
+			dead[id] = true
+			continue
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	if len(dead) > 0 {
+		err := d.updateNodeList(ctx, func(nodeIDs []string) []string {
+			remaining := make([]string, 0, len(nodeIDs))
+			for _, id := range nodeIDs {
+				if !dead[id] {
+					remaining = append(remaining, id)
+				}
+			}
+			return remaining
+		})
+
+		if err != nil {
+			fmt.Printf("Failed to prune dead game nodes: %v\n", err)
+		}
+	}
+
+	return nodes, nil
+}
+
+func (d *Distributed) updateNodeList(ctx context.Context, update func(nodeIDs []string) []string) error {
+	token, err := acquire(ctx, d.store, nodesKey())
+	if err != nil {
+		return err
+	}
+	defer release(d.store, nodesKey(), token)
+
+	var nodeIDs []string
+	if _, err := getJSON(ctx, d.store, nodesKey(), &nodeIDs); err != nil {
+		return err
+	}
+
+	return setJSON(ctx, d.store, nodesKey(), update(nodeIDs), 0)
+}
+
+/**
+* index of the node with the given id, -1 when it isn't live.
+**/
+func nodeIndex(nodes []Node, id string) int {
+	for i, node := range nodes {
+		if node.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+/**
+* index of the least loaded node with room for another session.
+**/
+func pickNode(nodes []Node) (int, bool) {
+	best := -1
+	for i, node := range nodes {
+		if !node.hasCapacity() {
+			continue
+		}
+
+		if best == -1 || node.load() < nodes[best].load() {
+			best = i
+		}
+	}
+
+	return best, best != -1
+}
@@ -0,0 +1,225 @@
+package matchmaking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/common/utils/cache"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/google/uuid"
+)
+
+/**
+* Shared queue state
+*
+* Every queue is stored as a single value holding its entries in order, with
+* the sessions it backfills stored next to it, and changed only while
+* holding the queue's lock so instances can't overwrite each other. Each
+* queued player also gets a key pointing at their queue so any instance can
+* tell where they wait.
+**/
+
+var ErrQueueBusy = errors.New("Queue is locked by another game service instance.")
+
+const (
+	keyPrefix = "matchmaking"
+
+	storeTimeout = 3 * time.Second
+	// how long a lock is held before it's given up on, in case the
+	// instance holding it dies. Longer than any operation holding it can
+	// run so it never runs out underneath one
+	lockTTL = 2 * storeTimeout
+	// how long to wait for another instance to release a lock
+	lockWait  = time.Second
+	lockRetry = 10 * time.Millisecond
+)
+
+func queueKey(queueID string) string {
+	return fmt.Sprintf("%s:queue:%s", keyPrefix, queueID)
+}
+
+func backfillKey(queueID string) string {
+	return fmt.Sprintf("%s:backfill:%s", keyPrefix, queueID)
+}
+
+func playerKey(playerID uuid.UUID) string {
+	return fmt.Sprintf("%s:player:%s", keyPrefix, playerID)
+}
+
+func penaltyKey(playerID uuid.UUID) string {
+	return fmt.Sprintf("%s:penalty:%s", keyPrefix, playerID)
+}
+
+func nodeKey(nodeID string) string {
+	return fmt.Sprintf("%s:node:%s", keyPrefix, nodeID)
+}
+
+func nodesKey() string {
+	return fmt.Sprintf("%s:nodes", keyPrefix)
+}
+
+func matcherKey() string {
+	return fmt.Sprintf("%s:matcher", keyPrefix)
+}
+
+/**
+* waits for the lock until lockWait runs out, returning the token it's held
+* with.
+**/
+func acquire(ctx context.Context, store cache.Cache, key string) (string, error) {
+	token := uuid.NewString()
+	deadline := time.Now().Add(lockWait)
+
+	for {
+		locked, err := store.LockWithToken(ctx, key, token, lockTTL)
+		if err != nil {
+			return "", err
+		}
+
+		if locked {
+			return token, nil
+		}
+
+		if time.Now().After(deadline) {
+			return "", ErrQueueBusy
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(lockRetry):
+		}
+	}
+}
+
+/**
+* releases the lock if it's still held with the token, a lock that ran out
+* and was taken by another instance is left alone. Released even when the
+* operation's context has run out.
+**/
+func release(store cache.Cache, key string, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := store.UnlockWithToken(ctx, key, token); err != nil {
+		fmt.Printf("Failed to release lock %s: %v\n", key, err)
+	}
+}
+
+/**
+* reads a json value, reporting false when the key doesn't exist.
+**/
+func getJSON(ctx context.Context, store cache.Cache, key string, value interface{}) (bool, error) {
+	raw, err := store.Get(ctx, key)
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal([]byte(raw), value); err != nil {
+		return false, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+func setJSON(ctx context.Context, store cache.Cache, key string, value interface{}, ttl time.Duration) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return store.Set(ctx, key, string(raw), ttl)
+}
+
+/**
+* loads the queue into a local queue system, the matching and team packing
+* are the same as for queues that aren't shared.
+**/
+func loadQueue(ctx context.Context, store cache.Cache, config systems.QueueConfig) (*systems.QueueSystem, error) {
+	var groups []systems.QueuedGroup
+	if _, err := getJSON(ctx, store, queueKey(config.ID), &groups); err != nil {
+		return nil, err
+	}
+
+	var slots []systems.BackfillSlot
+	if _, err := getJSON(ctx, store, backfillKey(config.ID), &slots); err != nil {
+		return nil, err
+	}
+
+	queue := systems.NewQueueSystem(config)
+	queue.Restore(groups)
+	for _, slot := range slots {
+		queue.OpenBackfill(slot)
+	}
+	return queue, nil
+}
+
+/**
+* runs fn on the queue while holding its lock and saves the result, keeping
+* the player keys in step with who is left waiting.
+**/
+func withQueue(ctx context.Context, store cache.Cache, config systems.QueueConfig, fn func(queue *systems.QueueSystem) error) error {
+	key := queueKey(config.ID)
+
+	token, err := acquire(ctx, store, key)
+	if err != nil {
+		return err
+	}
+	defer release(store, key, token)
+
+	queue, err := loadQueue(ctx, store, config)
+	if err != nil {
+		return err
+	}
+
+	before := queuedPlayers(queue.Snapshot())
+
+	if err := fn(queue); err != nil {
+		return err
+	}
+
+	groups := queue.Snapshot()
+	after := queuedPlayers(groups)
+
+	if err := setJSON(ctx, store, key, groups, 0); err != nil {
+		return err
+	}
+
+	if err := setJSON(ctx, store, backfillKey(config.ID), queue.Backfills(), 0); err != nil {
+		return err
+	}
+
+	for playerID := range before {
+		if !after[playerID] {
+			if err := store.Del(ctx, playerKey(playerID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for playerID := range after {
+		if !before[playerID] {
+			if err := store.Set(ctx, playerKey(playerID), config.ID, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func queuedPlayers(groups []systems.QueuedGroup) map[uuid.UUID]bool {
+	players := make(map[uuid.UUID]bool)
+	for _, group := range groups {
+		for _, player := range group.Players {
+			players[player.ID] = true
+		}
+	}
+	return players
+}
@@ -30,8 +30,9 @@ func (s *MessageSender) SendToPlayer(playerID uuid.UUID, message types.Message)
 	fmt.Println("Sending message to player:", playerID)
 
 	msg := types.Message{
-		Action:  message.Action,
-		Payload: message.Payload,
+		Action:    message.Action,
+		Payload:   message.Payload,
+		RequestID: message.RequestID,
 	}
 	return s.dispatcher.PushMessageToChannelQueue(playerID, msg)
 }
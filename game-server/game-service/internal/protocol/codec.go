@@ -0,0 +1,208 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/realtime"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+/**
+* --- Wire Encodings ---
+*
+* Messages go over the websocket as JSON text frames or protobuf binary
+* frames. Clients pick one with a websocket subprotocol when connecting,
+* those asking for neither get JSON so the current clients and anyone
+* debugging with a plain websocket tool keep working.
+**/
+
+const (
+	SubprotocolJSON     = "cosmic-void.json"
+	SubprotocolProtobuf = "cosmic-void.protobuf"
+)
+
+// offered by the server in order of preference
+var Subprotocols = []string{SubprotocolProtobuf, SubprotocolJSON}
+
+/**
+* encodes outgoing messages into frames and decodes incoming frames.
+* Encode takes a types.Message or types.ServerResponse, Decode fills in a
+* *types.Message or *types.ServerResponse.
+**/
+type Codec interface {
+	// the websocket frame type the codec's messages are written in
+	FrameType() int
+	Encode(v interface{}) ([]byte, error)
+	Decode(data []byte, v interface{}) error
+}
+
+var (
+	JSON     Codec = jsonCodec{}
+	Protobuf Codec = protobufCodec{}
+)
+
+/**
+* the codec for a negotiated subprotocol, JSON when none was.
+**/
+func ForSubprotocol(subprotocol string) Codec {
+	if subprotocol == SubprotocolProtobuf {
+		return Protobuf
+	}
+
+	return JSON
+}
+
+/**
+* the codec the connection negotiated when it was opened.
+**/
+func For(conn *websocket.Conn) Codec {
+	if conn == nil {
+		return JSON
+	}
+
+	return ForSubprotocol(conn.Subprotocol())
+}
+
+/**
+* encodes v in the connection's encoding and writes it as one frame. Only
+* the connection's writer goroutine may call it.
+**/
+func Write(conn *websocket.Conn, v interface{}) error {
+	// queued replies go out in the response shape
+	if message, ok := v.(types.Message); ok && message.Response != nil {
+		v = *message.Response
+	}
+
+	codec := For(conn)
+
+	data, err := codec.Encode(v)
+	if err != nil {
+		return err
+	}
+
+	return conn.WriteMessage(codec.FrameType(), data)
+}
+
+/**
+* --- JSON ---
+**/
+
+type jsonCodec struct{}
+
+func (jsonCodec) FrameType() int {
+	return websocket.TextMessage
+}
+
+func (jsonCodec) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Decode(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+/**
+* --- Protobuf ---
+**/
+
+type protobufCodec struct{}
+
+func (protobufCodec) FrameType() int {
+	return websocket.BinaryMessage
+}
+
+func (protobufCodec) Encode(v interface{}) ([]byte, error) {
+	var envelope *pb.Envelope
+	var err error
+
+	switch message := v.(type) {
+	case types.Message:
+		envelope, err = toEnvelope(message.Action, message.RequestID, message.Payload)
+	case *types.Message:
+		envelope, err = toEnvelope(message.Action, message.RequestID, message.Payload)
+	case types.ServerResponse:
+		envelope, err = responseToEnvelope(&message)
+	case *types.ServerResponse:
+		envelope, err = responseToEnvelope(message)
+	default:
+		return nil, fmt.Errorf("protobuf codec can't encode %T", v)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(envelope)
+}
+
+func (protobufCodec) Decode(data []byte, v interface{}) error {
+	envelope := &pb.Envelope{}
+	if err := proto.Unmarshal(data, envelope); err != nil {
+		return err
+	}
+
+	payload, err := fromEnvelope(envelope)
+	if err != nil {
+		return err
+	}
+
+	switch message := v.(type) {
+	case *types.Message:
+		*message = types.Message{
+			Action:    envelope.Action,
+			RequestID: envelope.RequestId,
+			Payload:   payload,
+		}
+	case *types.ServerResponse:
+		*message = types.ServerResponse{
+			Action:    envelope.Action,
+			RequestID: envelope.RequestId,
+			Payload:   payload,
+			Success:   envelope.Success,
+			Error:     fromError(envelope.Error),
+		}
+	default:
+		return fmt.Errorf("protobuf codec can't decode into %T", v)
+	}
+
+	return nil
+}
+
+func responseToEnvelope(response *types.ServerResponse) (*pb.Envelope, error) {
+	envelope, err := toEnvelope(response.Action, response.RequestID, response.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope.Success = response.Success
+	envelope.Error = toError(response.Error)
+	return envelope, nil
+}
+
+func toError(err *types.ErrorResponse) *pb.Error {
+	if err == nil {
+		return nil
+	}
+
+	fields := make([]*pb.FieldError, len(err.Fields))
+	for i, field := range err.Fields {
+		fields[i] = &pb.FieldError{Field: field.Field, Message: field.Message}
+	}
+
+	return &pb.Error{Code: err.Code, Message: err.Message, Fields: fields}
+}
+
+func fromError(err *pb.Error) *types.ErrorResponse {
+	if err == nil {
+		return nil
+	}
+
+	response := &types.ErrorResponse{Code: err.Code, Message: err.Message}
+	for _, field := range err.Fields {
+		response.Fields = append(response.Fields, types.FieldError{Field: field.Field, Message: field.Message})
+	}
+
+	return response
+}
@@ -0,0 +1,200 @@
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/realtime"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+/**
+* testing protobuf frames decode into what the same message sent as JSON
+* would have.
+**/
+
+func encodeEnvelope(t *testing.T, v interface{}) *pb.Envelope {
+	data, err := Protobuf.Encode(v)
+	require.NoError(t, err)
+
+	envelope := &pb.Envelope{}
+	require.NoError(t, proto.Unmarshal(data, envelope))
+	return envelope
+}
+
+// roundTrip decodes v from both encodings, returning each as JSON to compare
+func roundTrip(t *testing.T, v interface{}) (fromJSON, fromProtobuf string) {
+	var jsonResponse, protobufResponse types.ServerResponse
+
+	data, err := JSON.Encode(v)
+	require.NoError(t, err)
+	require.NoError(t, JSON.Decode(data, &jsonResponse))
+
+	data, err = Protobuf.Encode(v)
+	require.NoError(t, err)
+	require.NoError(t, Protobuf.Decode(data, &protobufResponse))
+
+	jsonOut, _ := json.Marshal(jsonResponse)
+	protobufOut, _ := json.Marshal(protobufResponse)
+	return string(jsonOut), string(protobufOut)
+}
+
+// TestProtobufMatchesJSON tests every kind of message decodes the same from both encodings
+func TestProtobufMatchesJSON(t *testing.T) {
+	sessionID, playerID := uuid.New().String(), uuid.New().String()
+	state := &types.ClientGameState{
+		SessionID: uuid.New(),
+		Players: []*types.PlayerState{{
+			ID:        uuid.New(),
+			EntityID:  uuid.New(),
+			Username:  "pilot",
+			Team:      2,
+			Position:  &types.Position{X: 1.5, Y: -3},
+			Direction: &types.PlayerDirection{VX: 1, Speed: 5},
+		}},
+		Items: []string{},
+		Doors: []*types.DoorState{{EntityID: uuid.New(), Position: types.Position{X: 4, Y: 2}, IsOpen: true}},
+	}
+
+	tests := []struct {
+		name    string
+		message interface{}
+		body    string
+	}{
+		{
+			name: "move input keeps a zero velocity",
+			message: types.Message{Action: string(constants.ActionMove), RequestID: "move-1", Payload: map[string]interface{}{
+				"session_id": sessionID, "player_id": playerID, "vx": 0.0, "vy": -1.0,
+			}},
+			body: "move",
+		},
+		{
+			name: "interact input without a verb",
+			message: types.Message{Action: string(constants.ActionInteract), Payload: map[string]interface{}{
+				"session_id": sessionID, "player_id": playerID, "entity_id": uuid.New().String(),
+			}},
+			body: "interact",
+		},
+		{
+			name: "allocate stat input with whole points",
+			message: types.Message{Action: string(constants.ActionAllocateStat), Payload: map[string]interface{}{
+				"session_id": sessionID, "player_id": playerID, "stat": "strength", "points": 2,
+			}},
+			body: "allocate_stat",
+		},
+		{
+			name:    "ping input",
+			message: types.Message{Action: string(constants.ActionPing), Payload: map[string]interface{}{"sent_at": int64(1700000000000000)}},
+			body:    "ping",
+		},
+		{
+			name: "chat event",
+			message: types.Message{Action: string(constants.ActionChat), Payload: map[string]interface{}{
+				"player_id": playerID, "username": "pilot", "channel": "all", "message": "hello",
+			}},
+			body: "chat_event",
+		},
+		{
+			name: "projectile hit event",
+			message: types.Message{Action: string(constants.ActionProjectileHit), Payload: map[string]interface{}{
+				"projectile_id": uuid.New().String(), "attacker_id": uuid.New().String(),
+				"target_id": uuid.New().String(), "damage": 12, "killed": true,
+			}},
+			body: "projectile_hit",
+		},
+		{
+			name: "player event",
+			message: types.Message{Action: string(constants.ActionPlayerJoined), Payload: map[string]interface{}{
+				"session_id": sessionID, "player_id": playerID, "username": "pilot", "team": 1,
+			}},
+			body: "player_event",
+		},
+		{
+			name: "acknowledgement falls back to data",
+			message: types.Message{Action: string(constants.ActionMove), RequestID: "move-2", Payload: map[string]interface{}{
+				"success": true,
+			}},
+			body: "data",
+		},
+		{
+			name: "payload with structs falls back to data",
+			message: types.Message{Action: string(constants.ActionMove), Payload: map[string]interface{}{
+				"success": false, "reason": string(constants.ErrorInvalidPayload),
+				"fields": []types.FieldError{{Field: "vx", Message: "must be a number"}},
+			}},
+			body: "data",
+		},
+		{
+			name: "snapshot",
+			message: types.Message{Action: string(constants.ActionSessionResume), Payload: map[string]interface{}{
+				"session_id": sessionID, "state": state,
+			}},
+			body: "snapshot",
+		},
+		{
+			name: "error response",
+			message: types.ServerResponse{Action: string(constants.ActionMove), RequestID: "move-3", Error: &types.ErrorResponse{
+				Code: string(constants.ErrorInvalidPayload), Message: "bad", Fields: []types.FieldError{{Field: "vy", Message: "is required"}},
+			}},
+		},
+		{
+			name: "success response",
+			message: types.ServerResponse{Action: string(constants.ActionFindGame), Success: true, Payload: map[string]interface{}{
+				"queue_id": "coop_duo", "players": []interface{}{"a", "b"},
+			}},
+			body: "data",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fromJSON, fromProtobuf := roundTrip(t, test.message)
+			assert.JSONEq(t, fromJSON, fromProtobuf)
+
+			if test.body == "" {
+				return
+			}
+
+			envelope := encodeEnvelope(t, test.message)
+			field := envelope.ProtoReflect().WhichOneof(envelopeBody)
+			require.NotNil(t, field)
+			assert.Equal(t, test.body, string(field.Name()))
+		})
+	}
+}
+
+// TestProtobufKeepsMissingFields tests inputs missing a field still fail validation
+func TestProtobufKeepsMissingFields(t *testing.T) {
+	data, err := Protobuf.Encode(types.Message{Action: string(constants.ActionMove), Payload: map[string]interface{}{
+		"session_id": uuid.New().String(),
+		"player_id":  uuid.New().String(),
+		"vx":         1.0,
+	}})
+	require.NoError(t, err)
+
+	var message types.Message
+	require.NoError(t, Protobuf.Decode(data, &message))
+	assert.NotContains(t, message.Payload, "vy")
+
+	_, err = message.ParsePayload()
+	var payloadErr *types.PayloadError
+	require.ErrorAs(t, err, &payloadErr)
+	assert.Equal(t, []types.FieldError{{Field: "vy", Message: "is required"}}, payloadErr.Fields)
+}
+
+// TestForSubprotocol tests JSON is used unless protobuf was negotiated
+func TestForSubprotocol(t *testing.T) {
+	assert.Equal(t, Protobuf, ForSubprotocol(SubprotocolProtobuf))
+	assert.Equal(t, JSON, ForSubprotocol(SubprotocolJSON))
+	assert.Equal(t, JSON, ForSubprotocol(""))
+	assert.Equal(t, JSON, For(nil))
+
+	assert.Equal(t, websocket.BinaryMessage, Protobuf.FrameType())
+	assert.Equal(t, websocket.TextMessage, JSON.FrameType())
+}
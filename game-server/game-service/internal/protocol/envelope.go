@@ -0,0 +1,345 @@
+package protocol
+
+import (
+	"encoding/json"
+	"math"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/realtime"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+/**
+* --- Envelopes ---
+*
+* Converts between the action and payload map the game works with and the
+* protobuf envelope. A payload goes out in a typed body when every one of its
+* fields fits, otherwise it keeps its JSON shape in the data body. Typed
+* bodies decode back into the same map JSON would have given, so nothing past
+* the connection knows which encoding a client uses.
+**/
+
+// [action] to the envelope bodies its payload can go out as, tried in order
+var actionBodies = map[constants.Action][]protoreflect.Name{
+	constants.ActionMove:               {"move"},
+	constants.ActionInteract:           {"interact"},
+	constants.ActionChat:               {"chat", "chat_event"},
+	constants.ActionAttack:             {"attack"},
+	constants.ActionAllocateStat:       {"allocate_stat"},
+	constants.ActionPing:               {"ping"},
+	constants.ActionProjectileHit:      {"projectile_hit"},
+	constants.ActionPlayerJoined:       {"player_event"},
+	constants.ActionPlayerLeft:         {"player_event"},
+	constants.ActionPlayerDisconnected: {"player_event"},
+	constants.ActionPlayerReconnected:  {"player_event"},
+}
+
+var (
+	envelopeFields = (&pb.Envelope{}).ProtoReflect().Descriptor().Fields()
+	envelopeBody   = (&pb.Envelope{}).ProtoReflect().Descriptor().Oneofs().ByName("body")
+)
+
+func toEnvelope(action string, requestID string, payload map[string]interface{}) (*pb.Envelope, error) {
+	envelope := &pb.Envelope{Action: action, RequestId: requestID}
+	if payload == nil {
+		return envelope, nil
+	}
+
+	// states are the largest payloads sent, they never go through JSON
+	if state, ok := payload["state"].(*types.ClientGameState); ok && state != nil {
+		fields, err := toStruct(withoutKey(payload, "state"))
+		if err != nil {
+			return nil, err
+		}
+
+		envelope.Body = &pb.Envelope_Snapshot{Snapshot: &pb.StateSnapshot{State: toGameState(state), Fields: fields}}
+		return envelope, nil
+	}
+
+	message := envelope.ProtoReflect()
+	for _, name := range actionBodies[constants.Action(action)] {
+		field := envelopeFields.ByName(name)
+		body := message.NewField(field).Message()
+
+		if fill(body, payload) {
+			message.Set(field, protoreflect.ValueOfMessage(body))
+			return envelope, nil
+		}
+	}
+
+	data, err := toStruct(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope.Body = &pb.Envelope_Data{Data: data}
+	return envelope, nil
+}
+
+func fromEnvelope(envelope *pb.Envelope) (map[string]interface{}, error) {
+	switch body := envelope.Body.(type) {
+	case nil:
+		return nil, nil
+
+	case *pb.Envelope_Data:
+		return body.Data.AsMap(), nil
+
+	case *pb.Envelope_Snapshot:
+		payload := body.Snapshot.Fields.AsMap()
+		payload["state"] = fromGameState(body.Snapshot.State)
+		return payload, nil
+	}
+
+	message := envelope.ProtoReflect()
+	field := message.WhichOneof(envelopeBody)
+	return toMap(message.Get(field).Message()), nil
+}
+
+/**
+* sets the body's fields from the payload, false when the payload has a field
+* the body doesn't or a value of the wrong type.
+**/
+func fill(body protoreflect.Message, payload map[string]interface{}) bool {
+	fields := body.Descriptor().Fields()
+
+	for key, value := range payload {
+		field := fields.ByName(protoreflect.Name(key))
+		if field == nil {
+			return false
+		}
+
+		converted, ok := scalarValue(field, value)
+		if !ok {
+			return false
+		}
+		body.Set(field, converted)
+	}
+
+	return true
+}
+
+/**
+* the body's set fields as a payload, numbers are float64 like they are when
+* decoded from JSON.
+**/
+func toMap(body protoreflect.Message) map[string]interface{} {
+	payload := make(map[string]interface{})
+
+	body.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		name := string(field.Name())
+
+		switch field.Kind() {
+		case protoreflect.StringKind:
+			payload[name] = value.String()
+		case protoreflect.BoolKind:
+			payload[name] = value.Bool()
+		case protoreflect.DoubleKind:
+			payload[name] = value.Float()
+		case protoreflect.Int32Kind, protoreflect.Int64Kind:
+			payload[name] = float64(value.Int())
+		}
+		return true
+	})
+
+	return payload
+}
+
+func scalarValue(field protoreflect.FieldDescriptor, value interface{}) (protoreflect.Value, bool) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		if s, ok := value.(string); ok {
+			return protoreflect.ValueOfString(s), true
+		}
+
+	case protoreflect.BoolKind:
+		if b, ok := value.(bool); ok {
+			return protoreflect.ValueOfBool(b), true
+		}
+
+	case protoreflect.DoubleKind:
+		if f, ok := toFloat(value); ok {
+			return protoreflect.ValueOfFloat64(f), true
+		}
+
+	case protoreflect.Int32Kind:
+		if i, ok := toInt(value); ok && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return protoreflect.ValueOfInt32(int32(i)), true
+		}
+
+	case protoreflect.Int64Kind:
+		if i, ok := toInt(value); ok {
+			return protoreflect.ValueOfInt64(i), true
+		}
+	}
+
+	return protoreflect.Value{}, false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+
+	if i, ok := toInt(value); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
+
+// whole numbers only, a float with a fraction doesn't fit an integer field
+func toInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return int64(v), true
+		}
+	}
+
+	return 0, false
+}
+
+/**
+* payloads keep their JSON shape, values structpb can't take directly like
+* uuids and structs go through JSON first.
+**/
+func toStruct(payload map[string]interface{}) (*structpb.Struct, error) {
+	if data, err := structpb.NewStruct(payload); err == nil {
+		return data, nil
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+
+	return structpb.NewStruct(normalized)
+}
+
+func withoutKey(payload map[string]interface{}, key string) map[string]interface{} {
+	rest := make(map[string]interface{}, len(payload))
+	for k, v := range payload {
+		if k != key {
+			rest[k] = v
+		}
+	}
+	return rest
+}
+
+/**
+* --- Game State ---
+**/
+
+func toGameState(state *types.ClientGameState) *pb.GameState {
+	players := make([]*pb.PlayerState, 0, len(state.Players))
+	for _, player := range state.Players {
+		players = append(players, &pb.PlayerState{
+			Id:        player.ID.String(),
+			EntityId:  player.EntityID.String(),
+			Username:  player.Username,
+			Team:      int32(player.Team),
+			Bot:       player.Bot,
+			Position:  toPosition(player.Position),
+			Direction: toDirection(player.Direction),
+		})
+	}
+
+	doors := make([]*pb.DoorState, 0, len(state.Doors))
+	for _, door := range state.Doors {
+		doors = append(doors, &pb.DoorState{
+			EntityId: door.EntityID.String(),
+			Position: toPosition(&door.Position),
+			IsOpen:   door.IsOpen,
+		})
+	}
+
+	return &pb.GameState{
+		SessionId: state.SessionID.String(),
+		Players:   players,
+		Items:     state.Items,
+		Doors:     doors,
+	}
+}
+
+func fromGameState(state *pb.GameState) *types.ClientGameState {
+	if state == nil {
+		return nil
+	}
+
+	decoded := &types.ClientGameState{
+		SessionID: parseUUID(state.SessionId),
+		Players:   make([]*types.PlayerState, 0, len(state.Players)),
+		Items:     append(make([]string, 0, len(state.Items)), state.Items...),
+		Doors:     make([]*types.DoorState, 0, len(state.Doors)),
+	}
+
+	for _, player := range state.Players {
+		decoded.Players = append(decoded.Players, &types.PlayerState{
+			ID:        parseUUID(player.Id),
+			EntityID:  parseUUID(player.EntityId),
+			Username:  player.Username,
+			Team:      int(player.Team),
+			Bot:       player.Bot,
+			Position:  fromPosition(player.Position),
+			Direction: fromDirection(player.Direction),
+		})
+	}
+
+	for _, door := range state.Doors {
+		decoded.Doors = append(decoded.Doors, &types.DoorState{
+			EntityID: parseUUID(door.EntityId),
+			Position: *fromPosition(door.Position),
+			IsOpen:   door.IsOpen,
+		})
+	}
+
+	return decoded
+}
+
+func toPosition(position *types.Position) *pb.Position {
+	if position == nil {
+		return nil
+	}
+	return &pb.Position{X: position.X, Y: position.Y}
+}
+
+// always a position, the origin when none was sent
+func fromPosition(position *pb.Position) *types.Position {
+	return &types.Position{X: position.GetX(), Y: position.GetY()}
+}
+
+func toDirection(direction *types.PlayerDirection) *pb.Direction {
+	if direction == nil {
+		return nil
+	}
+	return &pb.Direction{Vx: direction.VX, Vy: direction.VY, Speed: direction.Speed}
+}
+
+func fromDirection(direction *pb.Direction) *types.PlayerDirection {
+	if direction == nil {
+		return nil
+	}
+	return &types.PlayerDirection{VX: direction.Vx, VY: direction.Vy, Speed: direction.Speed}
+}
+
+// ids the server sent are always uuids, a bad one comes back as nil
+func parseUUID(id string) uuid.UUID {
+	parsed, _ := uuid.Parse(id)
+	return parsed
+}
@@ -0,0 +1,19 @@
+package rating
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MemberRating struct {
+	MemberID    uuid.UUID `db:"member_id" json:"memberId"`
+	GameMode    string    `db:"game_mode" json:"gameMode"`
+	Rating      float64   `db:"rating" json:"rating"`
+	GamesPlayed int       `db:"games_played" json:"gamesPlayed"`
+	Wins        int       `db:"wins" json:"wins"`
+	Losses      int       `db:"losses" json:"losses"`
+	Draws       int       `db:"draws" json:"draws"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
@@ -0,0 +1,61 @@
+package rating
+
+import (
+	"context"
+
+	commonhelpers "github.com/darkphotonKN/cosmic-void-server/common/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository struct {
+	DB *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{
+		DB: db,
+	}
+}
+
+/**
+* gets the ratings members have in a game mode, members that never played it
+* are left out.
+**/
+func (r *Repository) GetByMembers(ctx context.Context, gameMode string, memberIDs []uuid.UUID) ([]MemberRating, error) {
+	ratings := make([]MemberRating, 0, len(memberIDs))
+	if len(memberIDs) == 0 {
+		return ratings, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM member_ratings WHERE game_mode = ? AND member_id IN (?)`, gameMode, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.DB.SelectContext(ctx, &ratings, r.DB.Rebind(query), args...); err != nil {
+		return nil, commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return ratings, nil
+}
+
+func (r *Repository) UpsertTx(ctx context.Context, tx *sqlx.Tx, rating *MemberRating) error {
+	query := `
+	INSERT INTO member_ratings(member_id, game_mode, rating, games_played, wins, losses, draws)
+	VALUES(:member_id, :game_mode, :rating, :games_played, :wins, :losses, :draws)
+	ON CONFLICT (member_id, game_mode) DO UPDATE SET
+		rating = EXCLUDED.rating,
+		games_played = EXCLUDED.games_played,
+		wins = EXCLUDED.wins,
+		losses = EXCLUDED.losses,
+		draws = EXCLUDED.draws,
+		updated_at = NOW()
+	`
+
+	if _, err := tx.NamedExecContext(ctx, query, rating); err != nil {
+		return commonhelpers.AnalyzeDBErr(err)
+	}
+
+	return nil
+}
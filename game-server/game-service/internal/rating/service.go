@@ -0,0 +1,106 @@
+package rating
+
+import (
+	"context"
+	"fmt"
+
+	commonhelpers "github.com/darkphotonKN/cosmic-void-server/common/utils"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/systems"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+/**
+* Loads and updates member skill ratings.
+**/
+
+type Service struct {
+	Repo *Repository
+	elo  systems.EloConfig
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{
+		Repo: repo,
+		elo:  systems.DefaultEloConfig(),
+	}
+}
+
+/**
+* gets every member's rating in the game mode, members that never played it
+* start at the default rating.
+**/
+func (s *Service) GetRatings(ctx context.Context, gameMode string, memberIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	stored, err := s.Repo.GetByMembers(ctx, gameMode, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings := make(map[uuid.UUID]float64, len(memberIDs))
+	for _, memberID := range memberIDs {
+		ratings[memberID] = systems.DefaultRating
+	}
+
+	for _, rating := range stored {
+		ratings[rating.MemberID] = rating.Rating
+	}
+
+	return ratings, nil
+}
+
+/**
+* updates the ratings of everyone in a finished match. Teams map members to
+* the team they played on, a winning team of 0 is a draw.
+**/
+func (s *Service) RecordMatch(ctx context.Context, gameMode string, teams map[uuid.UUID]int, winningTeam int) error {
+	memberIDs := make([]uuid.UUID, 0, len(teams))
+	for memberID := range teams {
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	stored, err := s.Repo.GetByMembers(ctx, gameMode, memberIDs)
+	if err != nil {
+		return err
+	}
+
+	records := make(map[uuid.UUID]*MemberRating, len(memberIDs))
+	for i := range stored {
+		records[stored[i].MemberID] = &stored[i]
+	}
+
+	current := make(map[uuid.UUID]float64, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if _, exists := records[memberID]; !exists {
+			records[memberID] = &MemberRating{
+				MemberID: memberID,
+				GameMode: gameMode,
+				Rating:   systems.DefaultRating,
+			}
+		}
+		current[memberID] = records[memberID].Rating
+	}
+
+	updated := systems.UpdateTeamRatings(s.elo, current, teams, winningTeam)
+
+	return commonhelpers.ExecTxContext(ctx, s.Repo.DB, func(tx *sqlx.Tx) error {
+		for memberID, record := range records {
+			record.Rating = updated[memberID]
+			record.GamesPlayed++
+
+			switch {
+			case winningTeam == 0:
+				record.Draws++
+			case teams[memberID] == winningTeam:
+				record.Wins++
+			default:
+				record.Losses++
+			}
+
+			if err := s.Repo.UpsertTx(ctx, tx, record); err != nil {
+				return fmt.Errorf("failed to save rating for member %s: %w", memberID, err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,296 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/darkphotonKN/cosmic-void-server/common/api/proto/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/game"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/gameserver"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/**
+* Exposes the game server's rooms over gRPC.
+**/
+
+type service struct {
+	rooms RoomManager
+}
+
+type RoomManager interface {
+	CreateRoom(name string, creatorID uuid.UUID, maxPlayers int, gameMode string) (*gameserver.Room, error)
+	GetRoom(roomID uuid.UUID) (*gameserver.Room, error)
+	ListRooms(gameMode string, limit, offset int) ([]*gameserver.Room, int)
+	JoinRoom(roomID, userID uuid.UUID) (*gameserver.Room, error)
+	LeaveRoom(roomID, userID uuid.UUID) error
+	StartRoom(roomID uuid.UUID) (*gameserver.Room, error)
+	EndRoom(ctx context.Context, roomID uuid.UUID, winnerID uuid.UUID) (*gameserver.Room, error)
+	GetGameSession(id uuid.UUID) (*game.Session, bool)
+	BroadcastRoomUpdate(room *gameserver.Room)
+}
+
+func NewService(rooms RoomManager) *service {
+	return &service{
+		rooms: rooms,
+	}
+}
+
+func (s *service) CreateRoom(ctx context.Context, req *pb.CreateRoomRequest) (*pb.Room, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Name field is required")
+	}
+
+	creatorID, err := uuid.Parse(req.CreatorId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid creator id: %s", req.CreatorId)
+	}
+
+	room, err := s.rooms.CreateRoom(req.Name, creatorID, int(req.MaxPlayers), req.GameMode)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	return s.roomToProto(room), nil
+}
+
+func (s *service) GetRoom(ctx context.Context, req *pb.GetRoomRequest) (*pb.Room, error) {
+	roomID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid room id: %s", req.Id)
+	}
+
+	room, err := s.rooms.GetRoom(roomID)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	return s.roomToProto(room), nil
+}
+
+func (s *service) ListRooms(ctx context.Context, req *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset can't be negative")
+	}
+
+	rooms, total := s.rooms.ListRooms(req.GameMode, int(req.Limit), int(req.Offset))
+
+	response := &pb.ListRoomsResponse{
+		Rooms: make([]*pb.Room, 0, len(rooms)),
+		Total: int32(total),
+	}
+
+	for _, room := range rooms {
+		response.Rooms = append(response.Rooms, s.roomToProto(room))
+	}
+
+	return response, nil
+}
+
+func (s *service) JoinRoom(ctx context.Context, req *pb.JoinRoomRequest) (*pb.JoinRoomResponse, error) {
+	roomID, userID, err := parseRoomAndUser(req.RoomId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.rooms.JoinRoom(roomID, userID)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	s.rooms.BroadcastRoomUpdate(room)
+
+	roomProto := s.roomToProto(room)
+
+	var player *pb.Player
+	for _, roomPlayer := range roomProto.Players {
+		if roomPlayer.UserId == userID.String() {
+			player = roomPlayer
+		}
+	}
+
+	return &pb.JoinRoomResponse{
+		Room:   roomProto,
+		Player: player,
+	}, nil
+}
+
+func (s *service) LeaveRoom(ctx context.Context, req *pb.LeaveRoomRequest) (*pb.LeaveRoomResponse, error) {
+	roomID, userID, err := parseRoomAndUser(req.RoomId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.rooms.LeaveRoom(roomID, userID); err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	// let whoever is still in the lobby know
+	if room, err := s.rooms.GetRoom(roomID); err == nil {
+		s.rooms.BroadcastRoomUpdate(room)
+	}
+
+	return &pb.LeaveRoomResponse{
+		Success: true,
+		Message: "Successfully left the room",
+	}, nil
+}
+
+func (s *service) StartGame(ctx context.Context, req *pb.StartGameRequest) (*pb.StartGameResponse, error) {
+	roomID, err := uuid.Parse(req.RoomId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid room id: %s", req.RoomId)
+	}
+
+	room, err := s.rooms.StartRoom(roomID)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	return &pb.StartGameResponse{
+		Room:      s.roomToProto(room),
+		StartedAt: room.StartedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (s *service) EndGame(ctx context.Context, req *pb.EndGameRequest) (*pb.EndGameResponse, error) {
+	roomID, err := uuid.Parse(req.RoomId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid room id: %s", req.RoomId)
+	}
+
+	// a game can end without a winner e.g. a draw
+	winnerID := uuid.Nil
+	if req.WinnerId != "" {
+		winnerID, err = uuid.Parse(req.WinnerId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid winner id: %s", req.WinnerId)
+		}
+	}
+
+	// grab the final player state before the session shuts down
+	var finalRoom *pb.Room
+	if room, err := s.rooms.GetRoom(roomID); err == nil {
+		finalRoom = s.roomToProto(room)
+	}
+
+	room, err := s.rooms.EndRoom(ctx, roomID, winnerID)
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+
+	if finalRoom == nil {
+		finalRoom = s.roomToProto(room)
+	}
+	finalRoom.Status = string(room.Status)
+
+	response := &pb.EndGameResponse{
+		Room:    finalRoom,
+		EndedAt: room.EndedAt.Format(time.RFC3339),
+	}
+
+	if room.WinnerID != uuid.Nil {
+		response.WinnerId = room.WinnerID.String()
+	}
+
+	return response, nil
+}
+
+/**
+* converts a room to its proto form, filling in live player state when the
+* room's game is running.
+**/
+func (s *service) roomToProto(room *gameserver.Room) *pb.Room {
+	var session *game.Session
+	if room.SessionID != uuid.Nil {
+		session, _ = s.rooms.GetGameSession(room.SessionID)
+	}
+
+	result := &pb.Room{
+		Id:             room.ID.String(),
+		Name:           room.Name,
+		CreatorId:      room.CreatorID.String(),
+		MaxPlayers:     int32(room.MaxPlayers),
+		CurrentPlayers: int32(len(room.Players)),
+		GameMode:       room.GameMode,
+		Status:         string(room.Status),
+		CreatedAt:      room.CreatedAt.Format(time.RFC3339),
+		Players:        make([]*pb.Player, 0, len(room.Players)),
+	}
+
+	for _, roomPlayer := range room.Players {
+		player := &pb.Player{
+			Id:       roomPlayer.UserID.String(),
+			UserId:   roomPlayer.UserID.String(),
+			RoomId:   room.ID.String(),
+			IsAlive:  true,
+			JoinedAt: roomPlayer.JoinedAt.Format(time.RFC3339),
+		}
+
+		if session != nil {
+			if summary, inSession := session.PlayerSummary(roomPlayer.UserID); inSession {
+				player.Id = summary.EntityID.String()
+				player.X = summary.X
+				player.Y = summary.Y
+				player.VelocityX = summary.VelocityX
+				player.VelocityY = summary.VelocityY
+				player.Health = int32(summary.Health)
+				player.IsAlive = summary.IsAlive
+			}
+		}
+
+		result.Players = append(result.Players, player)
+	}
+
+	return result
+}
+
+func parseRoomAndUser(roomIDStr, userIDStr string) (uuid.UUID, uuid.UUID, error) {
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid room id: %s", roomIDStr)
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid user id: %s", userIDStr)
+	}
+
+	return roomID, userID, nil
+}
+
+/**
+* maps room errors onto grpc status codes for the gateway.
+**/
+func toStatusErr(err error) error {
+	switch {
+	case errors.Is(err, gameserver.ErrRoomNotFound),
+		errors.Is(err, gameserver.ErrNotInRoom),
+		errors.Is(err, gameserver.ErrInvalidInviteCode):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, gameserver.ErrUnknownGameMode),
+		errors.Is(err, gameserver.ErrInvalidMaxPlayers),
+		errors.Is(err, gameserver.ErrInvalidRoomName),
+		errors.Is(err, gameserver.ErrUnknownMap),
+		errors.Is(err, gameserver.ErrCannotKickSelf):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, gameserver.ErrNotHost),
+		errors.Is(err, gameserver.ErrKickedFromRoom):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, gameserver.ErrAlreadyInRoom):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, gameserver.ErrServerFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, gameserver.ErrRoomFull),
+		errors.Is(err, gameserver.ErrRoomNotWaiting),
+		errors.Is(err, gameserver.ErrRoomNotInProgress),
+		errors.Is(err, gameserver.ErrNotEnoughPlayers),
+		errors.Is(err, gameserver.ErrPlayersNotReady):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
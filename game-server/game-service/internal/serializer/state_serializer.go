@@ -38,10 +38,19 @@ func (s *StateSerializer) Serialize(sessionID uuid.UUID, entities map[uuid.UUID]
 			vc, _ := entity.GetComponent(ecs.ComponentTypeVelocity)
 			velocity := vc.(*components.VelocityComponent)
 
+			team := components.NoTeam
+			if teamComp, hasTeam := entity.GetComponent(ecs.ComponentTypeTeam); hasTeam {
+				team = teamComp.(*components.TeamComponent).TeamID
+			}
+
+			_, isBot := entity.GetComponent(ecs.ComponentTypeBot)
+
 			state.Players = append(state.Players, &types.PlayerState{
 				ID:       player.UserID,
 				EntityID: entityID,
 				Username: player.Username,
+				Team:     team,
+				Bot:      isBot,
 				Position: &types.Position{
 					X: transform.X,
 					Y: transform.Y,
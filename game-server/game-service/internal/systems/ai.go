@@ -0,0 +1,213 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* AI System
+*
+* Drives bot players. Each bot decides what to do as often as its difficulty
+* allows, heading for the closest player it could attack or, when there's
+* nobody to fight, sticking with the closest human ally. Who counts as which
+* comes from the same faction rules combat and skills use. Decisions come
+* back as the same move inputs a human sends so bots go through the session
+* like anyone else.
+**/
+
+type BotDifficulty struct {
+	Name string
+	// how long a bot waits between decisions
+	ReactionTime time.Duration
+	// most a bot strays from where it wants to go, in radians
+	Wander float64
+}
+
+const DefaultBotDifficulty = "normal"
+
+var botDifficulties = map[string]BotDifficulty{
+	"easy":   {Name: "easy", ReactionTime: 1500 * time.Millisecond, Wander: 0.6},
+	"normal": {Name: "normal", ReactionTime: time.Second, Wander: 0.3},
+	"hard":   {Name: "hard", ReactionTime: 500 * time.Millisecond, Wander: 0},
+}
+
+/**
+* finds a difficulty by name, an empty name gives the default.
+**/
+func BotDifficultyByName(name string) (BotDifficulty, bool) {
+	if name == "" {
+		name = DefaultBotDifficulty
+	}
+
+	difficulty, ok := botDifficulties[name]
+	return difficulty, ok
+}
+
+/**
+* a server controlled player to fill a match with.
+**/
+func NewBotPlayer(difficulty string, number int) *types.Player {
+	return &types.Player{
+		ID:            uuid.New(),
+		Username:      fmt.Sprintf("Bot %d", number),
+		Bot:           true,
+		BotDifficulty: difficulty,
+	}
+}
+
+// bots following a teammate stop once they're this close
+const botFollowDistance = 2.0
+
+// the move a bot decided on, the same as a player's move action
+type BotInput struct {
+	PlayerID uuid.UUID
+	Vx       float64
+	Vy       float64
+}
+
+type AISystem struct {
+	combat *CombatSystem
+	skills *SkillSystem
+
+	// rand.Rand isn't safe for concurrent use
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func NewAISystem(combat *CombatSystem, skills *SkillSystem) *AISystem {
+	return &AISystem{
+		combat: combat,
+		skills: skills,
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+type botView struct {
+	entity *ecs.Entity
+	userID uuid.UUID
+	bot    bool
+	x, y   float64
+}
+
+// NOTE: this runs every game tick
+func (s *AISystem) Update(now time.Time, entities []*ecs.Entity) []BotInput {
+	players := make([]botView, 0, len(entities))
+	bots := make(map[uuid.UUID]*components.BotComponent)
+
+	for _, entity := range entities {
+		playerComp, isPlayer := entity.GetComponent(ecs.ComponentTypePlayer)
+		transformComp, hasTransform := entity.GetComponent(ecs.ComponentTypeTransform)
+		if !isPlayer || !hasTransform {
+			continue
+		}
+
+		if healthComp, hasHealth := entity.GetComponent(ecs.ComponentTypeHealth); hasHealth &&
+			healthComp.(*components.HealthComponent).CurrentHealth <= 0 {
+			continue
+		}
+
+		view := botView{
+			entity: entity,
+			userID: playerComp.(*components.PlayerComponent).UserID,
+			x:      transformComp.(*components.TransformComponent).X,
+			y:      transformComp.(*components.TransformComponent).Y,
+		}
+
+		if botComp, isBot := entity.GetComponent(ecs.ComponentTypeBot); isBot {
+			view.bot = true
+			bots[view.userID] = botComp.(*components.BotComponent)
+		}
+
+		players = append(players, view)
+	}
+
+	inputs := make([]BotInput, 0, len(bots))
+	for _, bot := range players {
+		botComp, isBot := bots[bot.userID]
+		if !isBot || now.Before(botComp.NextDecision) {
+			continue
+		}
+
+		difficulty, ok := BotDifficultyByName(botComp.Difficulty)
+		if !ok {
+			difficulty, _ = BotDifficultyByName(DefaultBotDifficulty)
+		}
+		botComp.NextDecision = now.Add(difficulty.ReactionTime)
+
+		vx, vy := s.decide(bot, players, difficulty)
+		inputs = append(inputs, BotInput{PlayerID: bot.userID, Vx: vx, Vy: vy})
+	}
+
+	return inputs
+}
+
+/**
+* the direction the bot moves in, standing still when there's nobody to go
+* after.
+**/
+func (s *AISystem) decide(bot botView, players []botView, difficulty BotDifficulty) (float64, float64) {
+	var enemy, teammate *botView
+	enemyDistance, teammateDistance := math.MaxFloat64, math.MaxFloat64
+
+	entities := make([]*ecs.Entity, 0, len(players))
+	for _, other := range players {
+		entities = append(entities, other.entity)
+	}
+
+	attackable := make(map[uuid.UUID]bool)
+	for _, target := range s.combat.ValidTargets(bot.entity, entities) {
+		attackable[target.ID] = true
+	}
+
+	for i := range players {
+		other := &players[i]
+		if other.userID == bot.userID {
+			continue
+		}
+
+		distance := math.Hypot(other.x-bot.x, other.y-bot.y)
+
+		// allies stay allies even when friendly fire would let them be hit
+		if s.skills.CanAffect(bot.entity, other.entity, true) {
+			// bots only follow humans, not each other
+			if !other.bot && distance < teammateDistance {
+				teammate, teammateDistance = other, distance
+			}
+			continue
+		}
+
+		if attackable[other.entity.ID] && distance < enemyDistance {
+			enemy, enemyDistance = other, distance
+		}
+	}
+
+	target, distance := enemy, enemyDistance
+	if target == nil {
+		if teammate == nil || teammateDistance <= botFollowDistance {
+			return 0, 0
+		}
+		target, distance = teammate, teammateDistance
+	}
+
+	if distance == 0 {
+		return 0, 0
+	}
+
+	angle := math.Atan2(target.y-bot.y, target.x-bot.x)
+	if difficulty.Wander > 0 {
+		s.mu.Lock()
+		angle += (s.rng.Float64()*2 - 1) * difficulty.Wander
+		s.mu.Unlock()
+	}
+
+	return math.Cos(angle), math.Sin(angle)
+}
@@ -0,0 +1,99 @@
+package systems
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAIPlayer(x, y float64, teamID int, difficulty string) *ecs.Entity {
+	entity := newTeamEntity(teamID, components.FactionPlayers)
+	entity.AddComponent(components.NewPlayerComponent(uuid.New(), "player"))
+	entity.AddComponent(components.NewTransformComponent(x, y))
+	if difficulty != "" {
+		entity.AddComponent(components.NewBotComponent(difficulty))
+	}
+	return entity
+}
+
+func newTestAISystem(friendlyFire bool) *AISystem {
+	factions := NewFactionTable()
+	return NewAISystem(NewCombatSystem(factions, friendlyFire), NewSkillSystem(factions, friendlyFire))
+}
+
+func userIDOf(entity *ecs.Entity) uuid.UUID {
+	playerComp, _ := entity.GetComponent(ecs.ComponentTypePlayer)
+	return playerComp.(*components.PlayerComponent).UserID
+}
+
+// TestAIChasesNearestEnemy tests bots head for the closest player on another team
+func TestAIChasesNearestEnemy(t *testing.T) {
+	system := newTestAISystem(false)
+
+	bot := newAIPlayer(0, 0, 1, "hard")
+	near := newAIPlayer(0, 5, 2, "")
+	far := newAIPlayer(10, 0, 2, "")
+
+	now := time.Now()
+	inputs := system.Update(now, []*ecs.Entity{bot, near, far})
+
+	require.Len(t, inputs, 1)
+	assert.Equal(t, userIDOf(bot), inputs[0].PlayerID)
+	assert.InDelta(t, 0, inputs[0].Vx, 1e-9)
+	assert.InDelta(t, 1, inputs[0].Vy, 1e-9)
+
+	// nothing new until the reaction time is up
+	assert.Empty(t, system.Update(now.Add(100*time.Millisecond), []*ecs.Entity{bot, near, far}))
+	assert.Len(t, system.Update(now.Add(500*time.Millisecond), []*ecs.Entity{bot, near, far}), 1)
+}
+
+// TestAIFollowsHumanTeammate tests bots with no enemies stay near a human on their team
+func TestAIFollowsHumanTeammate(t *testing.T) {
+	system := newTestAISystem(false)
+
+	bot := newAIPlayer(0, 0, 1, "hard")
+	otherBot := newAIPlayer(1, 0, 1, "hard")
+	human := newAIPlayer(-6, 0, 1, "")
+
+	inputs := system.Update(time.Now(), []*ecs.Entity{bot, otherBot, human})
+
+	byPlayer := make(map[uuid.UUID]BotInput, len(inputs))
+	for _, input := range inputs {
+		byPlayer[input.PlayerID] = input
+	}
+
+	require.Len(t, byPlayer, 2)
+	assert.InDelta(t, -1, byPlayer[userIDOf(bot)].Vx, 1e-9)
+
+	// close enough already, it waits where it is
+	closeBot := newAIPlayer(-5, 0, 1, "hard")
+	inputs = system.Update(time.Now(), []*ecs.Entity{closeBot, human})
+	require.Len(t, inputs, 1)
+	assert.Zero(t, inputs[0].Vx)
+	assert.Zero(t, inputs[0].Vy)
+}
+
+// TestAITargetsByFaction tests bots pick targets by faction relation, not team alone
+func TestAITargetsByFaction(t *testing.T) {
+	system := newTestAISystem(true)
+
+	// a teammate is still an ally with friendly fire on
+	bot := newAIPlayer(0, 0, 1, "hard")
+	teammate := newAIPlayer(0, 1, 1, "")
+
+	// teamless monsters are hostile to players
+	monster := newTeamEntity(components.NoTeam, components.FactionMonsters)
+	monster.AddComponent(components.NewPlayerComponent(uuid.New(), "monster"))
+	monster.AddComponent(components.NewTransformComponent(-8, 0))
+
+	inputs := system.Update(time.Now(), []*ecs.Entity{bot, teammate, monster})
+
+	require.Len(t, inputs, 1)
+	assert.InDelta(t, -1, inputs[0].Vx, 1e-9)
+	assert.InDelta(t, 0, inputs[0].Vy, 1e-9)
+}
@@ -0,0 +1,86 @@
+package systems
+
+import "math"
+
+/**
+* Swept collision helpers. Both return the fraction t (0 to 1) along the
+* segment from (x0, y0) to (x1, y1) where it first touches the shape.
+**/
+
+/**
+* segment against a circle, a segment starting inside the circle hits at t = 0.
+**/
+func sweepCircle(x0, y0, x1, y1, cx, cy, radius float64) (float64, bool) {
+	dx := x1 - x0
+	dy := y1 - y0
+	fx := x0 - cx
+	fy := y0 - cy
+
+	c := fx*fx + fy*fy - radius*radius
+
+	// already overlapping
+	if c <= 0 {
+		return 0, true
+	}
+
+	a := dx*dx + dy*dy
+	if a == 0 {
+		return 0, false
+	}
+
+	b := 2 * (fx*dx + fy*dy)
+	discriminant := b*b - 4*a*c
+
+	if discriminant < 0 {
+		return 0, false
+	}
+
+	t := (-b - math.Sqrt(discriminant)) / (2 * a)
+
+	if t < 0 || t > 1 {
+		return 0, false
+	}
+
+	return t, true
+}
+
+/**
+* segment against an axis aligned box using the slab method.
+**/
+func sweepBox(x0, y0, x1, y1, minX, minY, maxX, maxY float64) (float64, bool) {
+	tMin := 0.0
+	tMax := 1.0
+
+	axes := [2][4]float64{
+		{x0, x1 - x0, minX, maxX},
+		{y0, y1 - y0, minY, maxY},
+	}
+
+	for _, axis := range axes {
+		origin, delta, low, high := axis[0], axis[1], axis[2], axis[3]
+
+		if delta == 0 {
+			// parallel to this slab and outside it
+			if origin < low || origin > high {
+				return 0, false
+			}
+			continue
+		}
+
+		t1 := (low - origin) / delta
+		t2 := (high - origin) / delta
+
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+
+		tMin = math.Max(tMin, t1)
+		tMax = math.Min(tMax, t2)
+
+		if tMin > tMax {
+			return 0, false
+		}
+	}
+
+	return tMin, true
+}
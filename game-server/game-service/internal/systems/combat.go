@@ -1,6 +1,10 @@
 package systems
 
-import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+import (
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+)
 
 /*
 1. 條件判斷 (能不能攻擊)
@@ -9,10 +13,16 @@ import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
 4. 結果應用 (扣血) - 使用 DamageCalculator 計算傷害
 5. 狀態更新 (冷卻、動畫等)
 */
-type CombatSystem struct{}
+type CombatSystem struct {
+	factions     *FactionTable
+	friendlyFire bool
+}
 
-func NewCombatSystem() *CombatSystem {
-	return &CombatSystem{}
+func NewCombatSystem(factions *FactionTable, friendlyFire bool) *CombatSystem {
+	return &CombatSystem{
+		factions:     factions,
+		friendlyFire: friendlyFire,
+	}
 }
 
 // NOTE: this runs every game tick
@@ -20,3 +30,76 @@ func (s *CombatSystem) Update(deltaTime float64, entities []*ecs.Entity) {
 	// Combat logic to be implemented
 
 }
+
+/**
+* checks if the attacker is allowed to hit the target based on their
+* faction relation and the mode's friendly fire rule.
+**/
+func (s *CombatSystem) CanAttack(attacker, target *ecs.Entity) bool {
+	return s.factions.CanDamage(attacker, target, s.friendlyFire)
+}
+
+/**
+* filters a list of entities down to the ones the attacker can target.
+**/
+func (s *CombatSystem) ValidTargets(attacker *ecs.Entity, entities []*ecs.Entity) []*ecs.Entity {
+	targets := make([]*ecs.Entity, 0)
+
+	for _, entity := range entities {
+		if !entity.HasComponent(ecs.ComponentTypeHealth) {
+			continue
+		}
+
+		if s.CanAttack(attacker, entity) {
+			targets = append(targets, entity)
+		}
+	}
+
+	return targets
+}
+
+type HitResult struct {
+	AttackerID uuid.UUID
+	TargetID   uuid.UUID
+	Damage     int
+	Killed     bool
+}
+
+/**
+* Damage pipeline for a single hit: faction check, damage calculation, then
+* applying the result to the target's health.
+**/
+func (s *CombatSystem) ResolveHit(attacker, target *ecs.Entity, baseDamage int) (HitResult, bool) {
+	result := HitResult{AttackerID: attacker.ID, TargetID: target.ID}
+
+	if !s.CanAttack(attacker, target) {
+		return result, false
+	}
+
+	healthComp, hasHealth := target.GetComponent(ecs.ComponentTypeHealth)
+	if !hasHealth {
+		return result, false
+	}
+
+	health := healthComp.(*components.HealthComponent)
+
+	// already dead
+	if health.CurrentHealth <= 0 {
+		return result, false
+	}
+
+	damage := baseDamage
+	if damage < 1 {
+		damage = 1
+	}
+
+	health.CurrentHealth -= damage
+	if health.CurrentHealth <= 0 {
+		health.CurrentHealth = 0
+		result.Killed = true
+	}
+
+	result.Damage = damage
+
+	return result, true
+}
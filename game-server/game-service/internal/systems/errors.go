@@ -0,0 +1,12 @@
+package systems
+
+import "errors"
+
+var (
+	ErrNotInteractable   = errors.New("Entity can not be interacted with.")
+	ErrUnsupportedVerb   = errors.New("Entity does not support this interaction.")
+	ErrOutOfRange        = errors.New("Entity is out of range.")
+	ErrNoLineOfSight     = errors.New("Something is blocking the way.")
+	ErrInteractCooldown  = errors.New("Interacted too soon, try again shortly.")
+	ErrMissingComponents = errors.New("Entity is missing components required for this interaction.")
+)
@@ -0,0 +1,123 @@
+package systems
+
+import (
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+)
+
+/**
+* Faction relationships
+*
+* Decides how two entities regard each other. Combat, skills, targeting and
+* chat all consult the same table so sides stay consistent across systems.
+*
+* Resolution order:
+* 1. same entity -> ally
+* 2. both on a team -> ally when the team matches, hostile otherwise
+* 3. faction table lookup, same faction defaults to ally, unknown pairs to neutral
+**/
+
+type Relation int
+
+const (
+	RelationNeutral Relation = iota
+	RelationAlly
+	RelationHostile
+)
+
+func (r Relation) String() string {
+	switch r {
+	case RelationAlly:
+		return "ally"
+	case RelationHostile:
+		return "hostile"
+	default:
+		return "neutral"
+	}
+}
+
+type factionPair struct {
+	a, b components.Faction
+}
+
+type FactionTable struct {
+	relations map[factionPair]Relation
+}
+
+func NewFactionTable() *FactionTable {
+	table := &FactionTable{
+		relations: make(map[factionPair]Relation),
+	}
+
+	// defaults
+	table.SetRelation(components.FactionPlayers, components.FactionMonsters, RelationHostile)
+	table.SetRelation(components.FactionPlayers, components.FactionNeutral, RelationNeutral)
+	table.SetRelation(components.FactionMonsters, components.FactionNeutral, RelationNeutral)
+
+	return table
+}
+
+/**
+* sets the relationship between two factions, relationships are symmetric.
+**/
+func (t *FactionTable) SetRelation(a, b components.Faction, relation Relation) {
+	t.relations[factionPair{a, b}] = relation
+	t.relations[factionPair{b, a}] = relation
+}
+
+func (t *FactionTable) FactionRelation(a, b components.Faction) Relation {
+	if relation, ok := t.relations[factionPair{a, b}]; ok {
+		return relation
+	}
+
+	if a == b {
+		return RelationAlly
+	}
+
+	return RelationNeutral
+}
+
+/**
+* resolves the relation between two entities. Entities without a team
+* component are treated as neutral to everything.
+**/
+func (t *FactionTable) Relation(source, target *ecs.Entity) Relation {
+	if source.ID == target.ID {
+		return RelationAlly
+	}
+
+	sourceComp, sourceHasTeam := source.GetComponent(ecs.ComponentTypeTeam)
+	targetComp, targetHasTeam := target.GetComponent(ecs.ComponentTypeTeam)
+
+	if !sourceHasTeam || !targetHasTeam {
+		return RelationNeutral
+	}
+
+	sourceTeam := sourceComp.(*components.TeamComponent)
+	targetTeam := targetComp.(*components.TeamComponent)
+
+	if sourceTeam.TeamID != components.NoTeam && targetTeam.TeamID != components.NoTeam {
+		if sourceTeam.TeamID == targetTeam.TeamID {
+			return RelationAlly
+		}
+		return RelationHostile
+	}
+
+	return t.FactionRelation(sourceTeam.Faction, targetTeam.Faction)
+}
+
+/**
+* decides if the source is allowed to deal damage to the target. Allies can
+* only be damaged when friendly fire is on, and nobody can damage themselves.
+**/
+func (t *FactionTable) CanDamage(source, target *ecs.Entity, friendlyFire bool) bool {
+	if source.ID == target.ID {
+		return false
+	}
+
+	if t.Relation(source, target) == RelationAlly {
+		return friendlyFire
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+package systems
+
+import (
+	"testing"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTeamEntity(teamID int, faction components.Faction) *ecs.Entity {
+	entity := ecs.NewEntity()
+	entity.AddComponent(components.NewTeamComponent(teamID, faction))
+	entity.AddComponent(components.NewHealthComponent(100, 100))
+	return entity
+}
+
+type factionRelationTable []struct {
+	name                 string
+	source               *ecs.Entity
+	target               *ecs.Entity
+	expectedRelation     Relation
+	expectedCanDamage    bool
+	expectedFriendlyFire bool
+}
+
+func TestFactionTableRelation(t *testing.T) {
+	table := NewFactionTable()
+
+	tableTests := factionRelationTable{
+		{
+			name:                 "same team",
+			source:               newTeamEntity(1, components.FactionPlayers),
+			target:               newTeamEntity(1, components.FactionPlayers),
+			expectedRelation:     RelationAlly,
+			expectedCanDamage:    false,
+			expectedFriendlyFire: true,
+		},
+		{
+			name:                 "opposing teams",
+			source:               newTeamEntity(1, components.FactionPlayers),
+			target:               newTeamEntity(2, components.FactionPlayers),
+			expectedRelation:     RelationHostile,
+			expectedCanDamage:    true,
+			expectedFriendlyFire: true,
+		},
+		{
+			name:                 "player against monster",
+			source:               newTeamEntity(1, components.FactionPlayers),
+			target:               newTeamEntity(components.NoTeam, components.FactionMonsters),
+			expectedRelation:     RelationHostile,
+			expectedCanDamage:    true,
+			expectedFriendlyFire: true,
+		},
+		{
+			name:                 "player against neutral",
+			source:               newTeamEntity(1, components.FactionPlayers),
+			target:               newTeamEntity(components.NoTeam, components.FactionNeutral),
+			expectedRelation:     RelationNeutral,
+			expectedCanDamage:    true,
+			expectedFriendlyFire: true,
+		},
+	}
+
+	for _, tableTest := range tableTests {
+		relation := table.Relation(tableTest.source, tableTest.target)
+		assert.Equal(t, tableTest.expectedRelation, relation, tableTest.name)
+
+		assert.Equal(t, tableTest.expectedCanDamage, table.CanDamage(tableTest.source, tableTest.target, false), tableTest.name)
+		assert.Equal(t, tableTest.expectedFriendlyFire, table.CanDamage(tableTest.source, tableTest.target, true), tableTest.name)
+	}
+
+	// can never damage yourself, even with friendly fire
+	self := newTeamEntity(1, components.FactionPlayers)
+	assert.False(t, table.CanDamage(self, self, true))
+}
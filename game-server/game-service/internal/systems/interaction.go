@@ -2,59 +2,206 @@ package systems
 
 import (
 	"math"
+	"sync"
+	"time"
 
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
 )
 
-type InteractionSystem struct{}
+/**
+* Interaction System
+*
+* Everything a player can interact with carries an InteractableComponent that
+* lists its verbs (open, loot, talk, activate), range, cooldown and whether it
+* needs line of sight. The system validates those rules and then hands off to
+* the handler registered for the verb, so new kinds of interactables only need
+* a new handler instead of changes to the session.
+**/
+
+type InteractionContext struct {
+	Player *ecs.Entity
+	Target *ecs.Entity
+	Verb   components.InteractionVerb
+}
+
+// handlers return data sent back to the player on success
+type InteractionHandler func(ctx InteractionContext) (map[string]interface{}, error)
+
+// stops a single player from spamming interactions across different entities
+const playerInteractCooldown = 100 * time.Millisecond
+
+type InteractionSystem struct {
+	handlers map[components.InteractionVerb]InteractionHandler
+
+	// [entityID] time the entity can next be interacted with
+	targetCooldowns map[uuid.UUID]time.Time
+	// [playerEntityID] time the player can next interact
+	playerCooldowns map[uuid.UUID]time.Time
+
+	mu sync.Mutex
+}
 
 func NewInterationSystem() *InteractionSystem {
-	return &InteractionSystem{}
+	return &InteractionSystem{
+		handlers:        make(map[components.InteractionVerb]InteractionHandler),
+		targetCooldowns: make(map[uuid.UUID]time.Time),
+		playerCooldowns: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (s *InteractionSystem) RegisterHandler(verb components.InteractionVerb, handler InteractionHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[verb] = handler
+}
+
+/**
+* Attempts an interaction between the player and target. An empty verb uses
+* the target's default verb. Entities are used for line of sight checks.
+**/
+func (s *InteractionSystem) Interact(player, target *ecs.Entity, verb components.InteractionVerb, entities []*ecs.Entity) (map[string]interface{}, error) {
+	interactableComp, hasInteractable := target.GetComponent(ecs.ComponentTypeInteractable)
+	if !hasInteractable {
+		return nil, ErrNotInteractable
+	}
+
+	interactable := interactableComp.(*components.InteractableComponent)
+
+	if len(interactable.Verbs) == 0 {
+		return nil, ErrNotInteractable
+	}
+
+	if verb == "" {
+		verb = interactable.Verbs[0]
+	}
+
+	if !supportsVerb(interactable, verb) {
+		return nil, ErrUnsupportedVerb
+	}
+
+	s.mu.Lock()
+	handler, hasHandler := s.handlers[verb]
+	onCooldown := s.isOnCooldown(player.ID, target.ID, time.Now())
+	s.mu.Unlock()
+
+	if !hasHandler {
+		return nil, ErrUnsupportedVerb
+	}
+
+	if onCooldown {
+		return nil, ErrInteractCooldown
+	}
+
+	playerTransformComp, playerHasTransform := player.GetComponent(ecs.ComponentTypeTransform)
+	targetTransformComp, targetHasTransform := target.GetComponent(ecs.ComponentTypeTransform)
+
+	if !playerHasTransform || !targetHasTransform {
+		return nil, ErrMissingComponents
+	}
+
+	playerTransform := playerTransformComp.(*components.TransformComponent)
+	targetTransform := targetTransformComp.(*components.TransformComponent)
+
+	// area in a circle around the target, sized by its own range
+	xDiff := math.Pow(playerTransform.X-targetTransform.X, 2)
+	yDiff := math.Pow(playerTransform.Y-targetTransform.Y, 2)
+	distanceBetween := math.Sqrt(xDiff + yDiff)
+
+	if distanceBetween > interactable.Range {
+		return nil, ErrOutOfRange
+	}
+
+	if interactable.RequiresLineOfSight && !hasLineOfSight(playerTransform, targetTransform, entities) {
+		return nil, ErrNoLineOfSight
+	}
+
+	result, err := handler(InteractionContext{Player: player, Target: target, Verb: verb})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.playerCooldowns[player.ID] = now.Add(playerInteractCooldown)
+	if interactable.Cooldown > 0 {
+		s.targetCooldowns[target.ID] = now.Add(interactable.Cooldown)
+	}
+	s.mu.Unlock()
+
+	return result, nil
 }
 
+// NOTE: this runs every game tick, clears out expired cooldowns
 func (s *InteractionSystem) Update(entities []*ecs.Entity) {
-	for _, entity := range entities {
-		// validation for player
-		_, hasPlayer := entity.GetComponent(ecs.ComponentTypePlayer)
-		transformComp, hasTransform := entity.GetComponent(ecs.ComponentTypeTransform)
+	now := time.Now()
 
-		if !hasTransform || !hasPlayer {
-			continue
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, readyAt := range s.targetCooldowns {
+		if now.After(readyAt) {
+			delete(s.targetCooldowns, id)
 		}
+	}
 
-		transform := transformComp.(*components.TransformComponent)
+	for id, readyAt := range s.playerCooldowns {
+		if now.After(readyAt) {
+			delete(s.playerCooldowns, id)
+		}
+	}
+}
 
-		// --- check door within range ---
+// NOTE: caller must hold the lock
+func (s *InteractionSystem) isOnCooldown(playerID, targetID uuid.UUID, now time.Time) bool {
+	if readyAt, ok := s.playerCooldowns[playerID]; ok && now.Before(readyAt) {
+		return true
+	}
 
-		for _, entity := range entities {
-			interactableComp, hasInteractable := entity.GetComponent(ecs.ComponentTypeInteractable)
-			openableComp, hasOpenable := entity.GetComponent(ecs.ComponentTypeOpenable)
-			doorTransformComp, hasTransform := entity.GetComponent(ecs.ComponentTypeTransform)
+	if readyAt, ok := s.targetCooldowns[targetID]; ok && now.Before(readyAt) {
+		return true
+	}
 
-			if !hasInteractable || !hasOpenable || !hasTransform {
-				continue
-			}
+	return false
+}
 
-			interactable := interactableComp.(*components.InteractableComponent)
-			openable := openableComp.(*components.OpenableComponent)
-			doorTransform := doorTransformComp.(*components.TransformComponent)
+func supportsVerb(interactable *components.InteractableComponent, verb components.InteractionVerb) bool {
+	for _, supported := range interactable.Verbs {
+		if supported == verb {
+			return true
+		}
+	}
 
-			// area in a circle around point from main entity (player)
+	return false
+}
 
-			// calculate range via range provided by interactable
-			xDiff := math.Pow(transform.X-doorTransform.X, 2)
-			yDiff := math.Pow(transform.Y-doorTransform.Y, 2)
-			distanceBetween := math.Sqrt(xDiff + yDiff)
+/**
+* checks that no wall sits on the straight line between the two points.
+**/
+func hasLineOfSight(from, to *components.TransformComponent, entities []*ecs.Entity) bool {
+	for _, entity := range entities {
+		wallComp, isWall := entity.GetComponent(ecs.ComponentTypeWall)
+		transformComp, hasTransform := entity.GetComponent(ecs.ComponentTypeTransform)
 
-			// too far
-			if distanceBetween > interactable.Range {
-				continue
-			}
+		if !isWall || !hasTransform {
+			continue
+		}
 
-			// object close enough to be interacted
-			// flip object's openable state to open or closed
-			openable.IsOpen = !openable.IsOpen
+		wall := wallComp.(*components.WallComponent)
+		wallTransform := transformComp.(*components.TransformComponent)
+
+		t, hit := sweepBox(from.X, from.Y, to.X, to.Y,
+			wallTransform.X, wallTransform.Y,
+			wallTransform.X+wall.Width, wallTransform.Y+wall.Height,
+		)
+
+		// touching a wall right at the target doesn't count as blocked
+		if hit && t < 1 {
+			return false
 		}
 	}
+
+	return true
 }
@@ -0,0 +1,101 @@
+package systems
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInteractionPlayer(x, y float64) *ecs.Entity {
+	entity := ecs.NewEntity()
+	entity.AddComponent(components.NewTransformComponent(x, y))
+	return entity
+}
+
+func newLever(x, y, interactRange float64, cooldown time.Duration, lineOfSight bool) *ecs.Entity {
+	entity := ecs.NewEntity()
+	entity.AddComponent(components.NewTransformComponent(x, y))
+	entity.AddComponent(components.NewActivatableComponent(false))
+	entity.AddComponent(components.NewInteractableComponent(interactRange, cooldown, lineOfSight, components.VerbActivate))
+	return entity
+}
+
+func newInteractionSystem(calls *int) *InteractionSystem {
+	system := NewInterationSystem()
+	system.RegisterHandler(components.VerbActivate, func(ctx InteractionContext) (map[string]interface{}, error) {
+		*calls++
+		return map[string]interface{}{"verb": string(ctx.Verb)}, nil
+	})
+	return system
+}
+
+func TestInteractUsesEntityRange(t *testing.T) {
+	calls := 0
+	system := newInteractionSystem(&calls)
+	player := newInteractionPlayer(0, 0)
+
+	farReaching := newLever(3, 0, 5, 0, false)
+	shortReaching := newLever(3, 0, 1, 0, false)
+
+	_, err := system.Interact(player, farReaching, "", nil)
+	require.Nil(t, err)
+
+	time.Sleep(playerInteractCooldown + 10*time.Millisecond)
+
+	_, err = system.Interact(player, shortReaching, "", nil)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	assert.Equal(t, 1, calls)
+}
+
+func TestInteractRejectsUnsupportedVerb(t *testing.T) {
+	calls := 0
+	system := newInteractionSystem(&calls)
+	player := newInteractionPlayer(0, 0)
+	lever := newLever(0.5, 0, 1, 0, false)
+
+	_, err := system.Interact(player, lever, components.VerbLoot, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedVerb)
+
+	_, err = system.Interact(player, ecs.NewEntity(), "", nil)
+	assert.ErrorIs(t, err, ErrNotInteractable)
+
+	assert.Equal(t, 0, calls)
+}
+
+func TestInteractCooldown(t *testing.T) {
+	calls := 0
+	system := newInteractionSystem(&calls)
+	playerOne := newInteractionPlayer(0, 0)
+	playerTwo := newInteractionPlayer(0, 0)
+	lever := newLever(0.5, 0, 1, time.Second, false)
+
+	_, err := system.Interact(playerOne, lever, "", nil)
+	require.Nil(t, err)
+
+	// the lever itself is on cooldown for everyone
+	_, err = system.Interact(playerTwo, lever, "", nil)
+	assert.ErrorIs(t, err, ErrInteractCooldown)
+	assert.Equal(t, 1, calls)
+}
+
+func TestInteractLineOfSight(t *testing.T) {
+	calls := 0
+	system := newInteractionSystem(&calls)
+	player := newInteractionPlayer(0, 0)
+	lever := newLever(2, 0, 3, 0, true)
+
+	wall := ecs.NewEntity()
+	wall.AddComponent(components.NewTransformComponent(1, -1))
+	wall.AddComponent(components.NewWallComponent(0.2, 2))
+
+	_, err := system.Interact(player, lever, "", []*ecs.Entity{wall})
+	assert.ErrorIs(t, err, ErrNoLineOfSight)
+
+	_, err = system.Interact(player, lever, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, calls)
+}
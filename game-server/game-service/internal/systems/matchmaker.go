@@ -0,0 +1,256 @@
+package systems
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+)
+
+/**
+* Matchmaker
+*
+* Runs a set of named queues side by side. Matches and status updates from
+* every queue come out of the same two channels, tagged with the queue they
+* came from. A player waits in at most one queue at a time.
+**/
+
+var (
+	ErrQueueNotFound = errors.New("Queue does not exist.")
+	ErrAlreadyQueued = errors.New("Player is already waiting in a queue.")
+	ErrQueuePenalty  = errors.New("Player can't queue yet after missing a ready check.")
+	ErrPartyTooLarge = errors.New("Party is too large for this queue.")
+)
+
+type Matchmaker struct {
+	queues       map[string]*QueueSystem
+	defaultQueue string
+
+	// [playerId] to when they can queue again
+	penalties map[uuid.UUID]time.Time
+	mu        sync.Mutex
+
+	MatchedChan     chan Match
+	QueueStatusChan chan QueueStatus
+}
+
+/**
+* creates a queue for each config, the first one is the default queue for
+* players that don't pick one.
+**/
+func NewMatchmaker(configs []QueueConfig) (*Matchmaker, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("matchmaker needs at least one queue")
+	}
+
+	m := &Matchmaker{
+		queues:          make(map[string]*QueueSystem, len(configs)),
+		defaultQueue:    configs[0].ID,
+		penalties:       make(map[uuid.UUID]time.Time),
+		MatchedChan:     make(chan Match),
+		QueueStatusChan: make(chan QueueStatus),
+	}
+
+	for _, config := range configs {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+
+		if _, exists := m.queues[config.ID]; exists {
+			return nil, fmt.Errorf("queue %s is configured twice", config.ID)
+		}
+
+		queue := NewQueueSystem(config)
+		queue.MatchedChan = m.MatchedChan
+		queue.QueueStatusChan = m.QueueStatusChan
+		m.queues[config.ID] = queue
+	}
+
+	return m, nil
+}
+
+func (m *Matchmaker) Start() {
+	for _, queue := range m.queues {
+		queue.Start()
+	}
+}
+
+/**
+* stops every queue's loop. The channels stay open for whatever replaces the
+* matchmaker.
+**/
+func (m *Matchmaker) Stop() {
+	for _, queue := range m.queues {
+		queue.Stop()
+	}
+}
+
+func (m *Matchmaker) DefaultQueueID() string {
+	return m.defaultQueue
+}
+
+func (m *Matchmaker) Queue(queueID string) (*QueueSystem, bool) {
+	queue, exists := m.queues[queueID]
+	return queue, exists
+}
+
+func (m *Matchmaker) QueueConfig(queueID string) (QueueConfig, bool) {
+	queue, exists := m.queues[queueID]
+	if !exists {
+		return QueueConfig{}, false
+	}
+	return queue.Config(), true
+}
+
+func (m *Matchmaker) Matched() chan Match {
+	return m.MatchedChan
+}
+
+func (m *Matchmaker) QueueStatuses() chan QueueStatus {
+	return m.QueueStatusChan
+}
+
+/**
+* puts the player in the queue, an empty queue id means the default queue.
+* Returns the id of the queue the player ended up in.
+**/
+func (m *Matchmaker) AddPlayer(queueID string, player *types.Player) (string, error) {
+	return m.AddGroup(queueID, []*types.Player{player})
+}
+
+/**
+* queues players that are matched together and put on the same team, so the
+* group can't be larger than one of the queue's teams.
+**/
+func (m *Matchmaker) AddGroup(queueID string, players []*types.Player) (string, error) {
+	if queueID == "" {
+		queueID = m.defaultQueue
+	}
+
+	queue, exists := m.queues[queueID]
+	if !exists {
+		return queueID, ErrQueueNotFound
+	}
+
+	if len(players) > queue.Config().TeamSize() {
+		return queueID, ErrPartyTooLarge
+	}
+
+	for _, player := range players {
+		if m.PenaltyRemaining(player.ID) > 0 {
+			return queueID, ErrQueuePenalty
+		}
+
+		if _, queued := m.QueueOf(player.ID); queued {
+			return queueID, ErrAlreadyQueued
+		}
+	}
+
+	queue.GroupJoinQueue(players)
+	return queueID, nil
+}
+
+/**
+* takes the player out of whichever queue they're waiting in, reporting
+* whether they were waiting at all.
+**/
+func (m *Matchmaker) RemovePlayer(player *types.Player) bool {
+	queueID, queued := m.QueueOf(player.ID)
+	if !queued {
+		return false
+	}
+
+	m.queues[queueID].PlayerRemoveQueue(player)
+	return true
+}
+
+/**
+* puts players from a match that didn't start back at the front of the
+* queue they matched in.
+**/
+func (m *Matchmaker) RequeueFront(match Match, players []*types.Player) error {
+	queue, exists := m.queues[match.QueueID]
+	if !exists {
+		return ErrQueueNotFound
+	}
+
+	queue.RequeueFront(players, match.JoinedAt)
+	return nil
+}
+
+/**
+* opens a running session's slots to the queue it was matched in, see
+* QueueSystem.OpenBackfill.
+**/
+func (m *Matchmaker) OpenBackfill(queueID string, slot BackfillSlot) error {
+	queue, exists := m.queues[queueID]
+	if !exists {
+		return ErrQueueNotFound
+	}
+
+	queue.OpenBackfill(slot)
+	return nil
+}
+
+func (m *Matchmaker) CloseBackfill(queueID string, sessionID uuid.UUID) {
+	if queue, exists := m.queues[queueID]; exists {
+		queue.CloseBackfill(sessionID)
+	}
+}
+
+/**
+* stops the player from queueing for the given time.
+**/
+func (m *Matchmaker) Penalize(playerID uuid.UUID, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.penalties[playerID] = time.Now().Add(duration)
+}
+
+func (m *Matchmaker) PenaltyRemaining(playerID uuid.UUID) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	until, penalized := m.penalties[playerID]
+	if !penalized {
+		return 0
+	}
+
+	remaining := time.Until(until)
+	if remaining <= 0 {
+		delete(m.penalties, playerID)
+		return 0
+	}
+
+	return remaining
+}
+
+func (m *Matchmaker) QueueOf(playerID uuid.UUID) (string, bool) {
+	for id, queue := range m.queues {
+		if queue.Contains(playerID) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+/**
+* stats for every queue ordered by id.
+**/
+func (m *Matchmaker) Stats() []QueueStats {
+	stats := make([]QueueStats, 0, len(m.queues))
+	for _, queue := range m.queues {
+		stats = append(stats, queue.Stats())
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].QueueID < stats[j].QueueID
+	})
+
+	return stats
+}
@@ -0,0 +1,223 @@
+package systems
+
+import (
+	"errors"
+	"math"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+)
+
+/**
+* Progression System
+*
+* Awards experience and levels entities up along a configurable curve. On
+* level-up stats either grow automatically or the entity is handed points to
+* allocate itself, a config picks one of the two.
+**/
+
+type ExperienceSource string
+
+const (
+	ExperienceSourceKill      ExperienceSource = "kill"
+	ExperienceSourceObjective ExperienceSource = "objective"
+	ExperienceSourceTreasure  ExperienceSource = "treasure"
+)
+
+type Stat string
+
+const (
+	StatStrength     Stat = "strength"
+	StatAgility      Stat = "agility"
+	StatIntelligence Stat = "intelligence"
+)
+
+var (
+	ErrNoStats           = errors.New("Entity has no stats.")
+	ErrUnknownStat       = errors.New("Stat does not exist.")
+	ErrNotEnoughPoints   = errors.New("Not enough unspent stat points.")
+	ErrInvalidStatPoints = errors.New("Stat points must be positive.")
+)
+
+/**
+* experience needed to go from level n to n+1 is BaseExperience * Growth^(n-1)
+**/
+type LevelCurve struct {
+	BaseExperience int
+	Growth         float64
+	MaxLevel       int
+}
+
+func (c LevelCurve) ExperienceToNextLevel(level int) int {
+	return int(math.Round(float64(c.BaseExperience) * math.Pow(c.Growth, float64(level-1))))
+}
+
+type ProgressionConfig struct {
+	Curve LevelCurve
+
+	// stats added automatically on every level up, instead of points
+	AutoGrowth   bool
+	StrengthGain int
+	AgilityGain  int
+	IntGain      int
+
+	// points handed to the player to allocate on every level up, only
+	// without auto growth
+	PointsPerLevel int
+
+	// experience for each source
+	KillExperience      int
+	ObjectiveExperience int
+	TreasureExperience  int
+}
+
+func DefaultProgressionConfig() ProgressionConfig {
+	return ProgressionConfig{
+		Curve: LevelCurve{
+			BaseExperience: 100,
+			Growth:         1.5,
+			MaxLevel:       30,
+		},
+		// players choose where their stats go
+		AutoGrowth:          false,
+		PointsPerLevel:      3,
+		KillExperience:      50,
+		ObjectiveExperience: 100,
+		TreasureExperience:  25,
+	}
+}
+
+type ExperienceResult struct {
+	Gained    int
+	Level     int
+	Levels    int
+	Total     int
+	NextLevel int
+}
+
+type ProgressionSystem struct {
+	config ProgressionConfig
+}
+
+func NewProgressionSystem(config ProgressionConfig) *ProgressionSystem {
+	return &ProgressionSystem{
+		config: config,
+	}
+}
+
+/**
+* experience a source is worth under this config.
+**/
+func (s *ProgressionSystem) ExperienceFor(source ExperienceSource) int {
+	switch source {
+	case ExperienceSourceKill:
+		return s.config.KillExperience
+	case ExperienceSourceObjective:
+		return s.config.ObjectiveExperience
+	case ExperienceSourceTreasure:
+		return s.config.TreasureExperience
+	default:
+		return 0
+	}
+}
+
+/**
+* adds experience to the entity, levelling it up as many times as the
+* experience allows.
+**/
+func (s *ProgressionSystem) AwardExperience(entity *ecs.Entity, amount int) (ExperienceResult, error) {
+	statsComp, hasStats := entity.GetComponent(ecs.ComponentTypeStats)
+	if !hasStats {
+		return ExperienceResult{}, ErrNoStats
+	}
+
+	stats := statsComp.(*components.StatsComponent)
+
+	if amount < 0 {
+		amount = 0
+	}
+
+	result := ExperienceResult{Gained: amount}
+
+	if s.atMaxLevel(stats.Level) {
+		result.Level = stats.Level
+		result.Total = stats.Experience
+		return result, nil
+	}
+
+	stats.Experience += amount
+
+	for !s.atMaxLevel(stats.Level) {
+		required := s.config.Curve.ExperienceToNextLevel(stats.Level)
+		if stats.Experience < required {
+			break
+		}
+
+		stats.Experience -= required
+		stats.Level++
+		result.Levels++
+
+		s.applyLevelUp(stats)
+	}
+
+	// nothing to carry over once capped
+	if s.atMaxLevel(stats.Level) {
+		stats.Experience = 0
+	}
+
+	result.Level = stats.Level
+	result.Total = stats.Experience
+	result.NextLevel = s.config.Curve.ExperienceToNextLevel(stats.Level)
+
+	return result, nil
+}
+
+/**
+* spends unspent points on a stat.
+**/
+func (s *ProgressionSystem) AllocatePoints(entity *ecs.Entity, stat Stat, points int) error {
+	statsComp, hasStats := entity.GetComponent(ecs.ComponentTypeStats)
+	if !hasStats {
+		return ErrNoStats
+	}
+
+	stats := statsComp.(*components.StatsComponent)
+
+	if points <= 0 {
+		return ErrInvalidStatPoints
+	}
+
+	if points > stats.UnspentPoints {
+		return ErrNotEnoughPoints
+	}
+
+	switch stat {
+	case StatStrength:
+		stats.Strength += points
+	case StatAgility:
+		stats.Agility += points
+	case StatIntelligence:
+		stats.Intelligence += points
+	default:
+		return ErrUnknownStat
+	}
+
+	stats.UnspentPoints -= points
+
+	return nil
+}
+
+func (s *ProgressionSystem) applyLevelUp(stats *components.StatsComponent) {
+	if s.config.AutoGrowth {
+		stats.Strength += s.config.StrengthGain
+		stats.Agility += s.config.AgilityGain
+		stats.Intelligence += s.config.IntGain
+		return
+	}
+
+	stats.UnspentPoints += s.config.PointsPerLevel
+}
+
+func (s *ProgressionSystem) atMaxLevel(level int) bool {
+	return s.config.Curve.MaxLevel > 0 && level >= s.config.Curve.MaxLevel
+}
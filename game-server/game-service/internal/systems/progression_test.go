@@ -0,0 +1,82 @@
+package systems
+
+import (
+	"testing"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatsEntity() (*ecs.Entity, *components.StatsComponent) {
+	entity := ecs.NewEntity()
+	stats := components.NewStatsComponent()
+	entity.AddComponent(stats)
+	return entity, stats
+}
+
+func TestLevelCurve(t *testing.T) {
+	curve := LevelCurve{BaseExperience: 100, Growth: 1.5, MaxLevel: 10}
+
+	assert.Equal(t, 100, curve.ExperienceToNextLevel(1))
+	assert.Equal(t, 150, curve.ExperienceToNextLevel(2))
+	assert.Equal(t, 225, curve.ExperienceToNextLevel(3))
+}
+
+func TestAwardExperienceLevelsUp(t *testing.T) {
+	system := NewProgressionSystem(DefaultProgressionConfig())
+	entity, stats := newStatsEntity()
+
+	// enough for two levels (100 + 150) with 10 left over
+	result, err := system.AwardExperience(entity, 260)
+	require.Nil(t, err)
+
+	assert.Equal(t, 2, result.Levels)
+	assert.Equal(t, 3, stats.Level)
+	assert.Equal(t, 10, stats.Experience)
+	assert.Equal(t, 10, stats.Strength, "stats shouldn't grow without auto growth")
+	assert.Equal(t, 6, stats.UnspentPoints)
+}
+
+func TestAwardExperienceAutoGrowth(t *testing.T) {
+	config := DefaultProgressionConfig()
+	config.AutoGrowth = true
+	config.StrengthGain = 2
+	system := NewProgressionSystem(config)
+	entity, stats := newStatsEntity()
+
+	_, err := system.AwardExperience(entity, 260)
+	require.Nil(t, err)
+
+	assert.Equal(t, 14, stats.Strength, "auto growth should add 2 per level")
+	assert.Equal(t, 0, stats.UnspentPoints, "auto growth hands out no points")
+}
+
+func TestAwardExperienceStopsAtMaxLevel(t *testing.T) {
+	config := DefaultProgressionConfig()
+	config.Curve.MaxLevel = 2
+	system := NewProgressionSystem(config)
+	entity, stats := newStatsEntity()
+
+	_, err := system.AwardExperience(entity, 10000)
+	require.Nil(t, err)
+
+	assert.Equal(t, 2, stats.Level)
+	assert.Equal(t, 0, stats.Experience)
+}
+
+func TestAllocatePoints(t *testing.T) {
+	system := NewProgressionSystem(DefaultProgressionConfig())
+	entity, stats := newStatsEntity()
+
+	_, err := system.AwardExperience(entity, 100)
+	require.Nil(t, err)
+
+	assert.Nil(t, system.AllocatePoints(entity, StatStrength, 2))
+	assert.Equal(t, 12, stats.Strength)
+	assert.Equal(t, 1, stats.UnspentPoints)
+
+	assert.ErrorIs(t, system.AllocatePoints(entity, StatAgility, 2), ErrNotEnoughPoints)
+	assert.ErrorIs(t, system.AllocatePoints(entity, Stat("luck"), 1), ErrUnknownStat)
+}
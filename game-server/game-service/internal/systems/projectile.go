@@ -0,0 +1,191 @@
+package systems
+
+import (
+	"math"
+	"sort"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+)
+
+/**
+* Moves projectiles and resolves what they hit.
+*
+* Each tick a projectile sweeps the segment it travels this frame against
+* every hittable entity (transform + collider + health) and every wall. Hits
+* go through the combat system's damage pipeline, after which the projectile
+* either keeps going (pierce) or despawns.
+**/
+
+type ProjectileSystem struct {
+	combat *CombatSystem
+}
+
+func NewProjectileSystem(combat *CombatSystem) *ProjectileSystem {
+	return &ProjectileSystem{
+		combat: combat,
+	}
+}
+
+type ProjectileHit struct {
+	ProjectileID uuid.UUID
+	HitResult
+}
+
+// results of a single tick, the session decides what to do with them
+type ProjectileUpdate struct {
+	Hits []ProjectileHit
+	// projectiles that should be removed from the world
+	Despawned []uuid.UUID
+}
+
+type sweepCandidate struct {
+	t      float64
+	entity *ecs.Entity
+}
+
+// NOTE: this runs every game tick
+func (s *ProjectileSystem) Update(deltaTime float64, entities []*ecs.Entity) ProjectileUpdate {
+	update := ProjectileUpdate{
+		Hits:      make([]ProjectileHit, 0),
+		Despawned: make([]uuid.UUID, 0),
+	}
+
+	hittables := make([]*ecs.Entity, 0)
+	walls := make([]*ecs.Entity, 0)
+
+	for _, entity := range entities {
+		if !entity.HasComponent(ecs.ComponentTypeTransform) {
+			continue
+		}
+
+		if entity.HasComponent(ecs.ComponentTypeWall) {
+			walls = append(walls, entity)
+			continue
+		}
+
+		if entity.HasComponent(ecs.ComponentTypeCollider) &&
+			entity.HasComponent(ecs.ComponentTypeHealth) &&
+			!entity.HasComponent(ecs.ComponentTypeProjectile) {
+			hittables = append(hittables, entity)
+		}
+	}
+
+	for _, entity := range entities {
+		projectileComp, isProjectile := entity.GetComponent(ecs.ComponentTypeProjectile)
+		transformComp, hasTransform := entity.GetComponent(ecs.ComponentTypeTransform)
+
+		if !isProjectile || !hasTransform {
+			continue
+		}
+
+		projectile := projectileComp.(*components.ProjectileComponent)
+		transform := transformComp.(*components.TransformComponent)
+
+		hits, despawn := s.updateProjectile(deltaTime, entity, projectile, transform, hittables, walls)
+		update.Hits = append(update.Hits, hits...)
+
+		if despawn {
+			update.Despawned = append(update.Despawned, entity.ID)
+		}
+	}
+
+	return update
+}
+
+func (s *ProjectileSystem) updateProjectile(
+	deltaTime float64,
+	entity *ecs.Entity,
+	projectile *components.ProjectileComponent,
+	transform *components.TransformComponent,
+	hittables []*ecs.Entity,
+	walls []*ecs.Entity,
+) ([]ProjectileHit, bool) {
+	hits := make([]ProjectileHit, 0)
+
+	// don't travel further than the projectile has time left for
+	travelTime := math.Min(deltaTime, projectile.Lifetime)
+
+	x0, y0 := transform.X, transform.Y
+	x1 := x0 + projectile.DirX*projectile.Speed*travelTime
+	y1 := y0 + projectile.DirY*projectile.Speed*travelTime
+
+	// -- walls --
+	wallT := math.Inf(1)
+	for _, wall := range walls {
+		wc, _ := wall.GetComponent(ecs.ComponentTypeWall)
+		tc, _ := wall.GetComponent(ecs.ComponentTypeTransform)
+		wallComp := wc.(*components.WallComponent)
+		wallTransform := tc.(*components.TransformComponent)
+
+		t, hit := sweepBox(x0, y0, x1, y1,
+			wallTransform.X, wallTransform.Y,
+			wallTransform.X+wallComp.Width, wallTransform.Y+wallComp.Height,
+		)
+
+		if hit && t < wallT {
+			wallT = t
+		}
+	}
+
+	// -- hittable entities in front of the first wall --
+	candidates := make([]sweepCandidate, 0)
+	for _, target := range hittables {
+		if target.ID == projectile.OwnerID || projectile.HitEntities[target.ID] {
+			continue
+		}
+
+		cc, _ := target.GetComponent(ecs.ComponentTypeCollider)
+		tc, _ := target.GetComponent(ecs.ComponentTypeTransform)
+		collider := cc.(*components.ColliderComponent)
+		targetTransform := tc.(*components.TransformComponent)
+
+		t, hit := sweepCircle(x0, y0, x1, y1, targetTransform.X, targetTransform.Y, collider.Radius)
+
+		if hit && t <= wallT {
+			candidates = append(candidates, sweepCandidate{t: t, entity: target})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].t < candidates[j].t
+	})
+
+	for _, candidate := range candidates {
+		// allies and dead entities are passed through without using up pierce
+		result, applied := s.combat.ResolveHit(entity, candidate.entity, projectile.Damage)
+		if !applied {
+			continue
+		}
+
+		projectile.HitEntities[candidate.entity.ID] = true
+
+		// credit the hit to whoever fired the projectile
+		result.AttackerID = projectile.OwnerID
+		hits = append(hits, ProjectileHit{ProjectileID: entity.ID, HitResult: result})
+
+		if projectile.PierceCount > 0 {
+			projectile.PierceCount--
+			continue
+		}
+
+		transform.X = x0 + (x1-x0)*candidate.t
+		transform.Y = y0 + (y1-y0)*candidate.t
+
+		return hits, true
+	}
+
+	if !math.IsInf(wallT, 1) {
+		transform.X = x0 + (x1-x0)*wallT
+		transform.Y = y0 + (y1-y0)*wallT
+
+		return hits, true
+	}
+
+	transform.X = x1
+	transform.Y = y1
+	projectile.Lifetime -= travelTime
+
+	return hits, projectile.Lifetime <= 0
+}
@@ -0,0 +1,101 @@
+package systems
+
+import (
+	"testing"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/components"
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTarget(x, y float64, teamID int) *ecs.Entity {
+	entity := newTeamEntity(teamID, components.FactionPlayers)
+	entity.AddComponent(components.NewTransformComponent(x, y))
+	entity.AddComponent(components.NewColliderComponent(0.5))
+	return entity
+}
+
+func newProjectile(ownerID uuid.UUID, pierce int, teamID int) *ecs.Entity {
+	entity := ecs.NewEntity()
+	entity.AddComponent(components.NewTransformComponent(0, 0))
+	entity.AddComponent(components.NewProjectileComponent(ownerID, "Test", 1, 0, 10, 2, pierce, 10))
+	entity.AddComponent(components.NewTeamComponent(teamID, components.FactionPlayers))
+	return entity
+}
+
+func newProjectileSystem() *ProjectileSystem {
+	return NewProjectileSystem(NewCombatSystem(NewFactionTable(), false))
+}
+
+func TestProjectileHitsFirstTargetAndDespawns(t *testing.T) {
+	system := newProjectileSystem()
+
+	owner := newTarget(0, 0, 1)
+	near := newTarget(3, 0, 2)
+	far := newTarget(6, 0, 2)
+	projectile := newProjectile(owner.ID, 0, 1)
+
+	update := system.Update(1, []*ecs.Entity{owner, near, far, projectile})
+
+	require.Len(t, update.Hits, 1)
+	assert.Equal(t, near.ID, update.Hits[0].TargetID)
+	assert.Equal(t, owner.ID, update.Hits[0].AttackerID, "hit should be credited to the owner")
+	assert.Equal(t, []uuid.UUID{projectile.ID}, update.Despawned)
+
+	nearHealth, _ := near.GetComponent(ecs.ComponentTypeHealth)
+	farHealth, _ := far.GetComponent(ecs.ComponentTypeHealth)
+	assert.Equal(t, 90, nearHealth.(*components.HealthComponent).CurrentHealth)
+	assert.Equal(t, 100, farHealth.(*components.HealthComponent).CurrentHealth)
+}
+
+func TestProjectilePiercesAndSkipsAllies(t *testing.T) {
+	system := newProjectileSystem()
+
+	owner := newTarget(0, 0, 1)
+	ally := newTarget(2, 0, 1)
+	enemyOne := newTarget(4, 0, 2)
+	enemyTwo := newTarget(6, 0, 2)
+	projectile := newProjectile(owner.ID, 1, 1)
+
+	update := system.Update(1, []*ecs.Entity{owner, ally, enemyOne, enemyTwo, projectile})
+
+	require.Len(t, update.Hits, 2)
+	assert.Equal(t, enemyOne.ID, update.Hits[0].TargetID)
+	assert.Equal(t, enemyTwo.ID, update.Hits[1].TargetID)
+	assert.Equal(t, []uuid.UUID{projectile.ID}, update.Despawned, "pierce should be used up")
+}
+
+func TestProjectileStopsAtWall(t *testing.T) {
+	system := newProjectileSystem()
+
+	owner := newTarget(0, 0, 1)
+	enemy := newTarget(6, 0, 2)
+	projectile := newProjectile(owner.ID, 0, 1)
+
+	wall := ecs.NewEntity()
+	wall.AddComponent(components.NewTransformComponent(3, -1))
+	wall.AddComponent(components.NewWallComponent(1, 2))
+
+	update := system.Update(1, []*ecs.Entity{owner, enemy, wall, projectile})
+
+	assert.Len(t, update.Hits, 0)
+	assert.Equal(t, []uuid.UUID{projectile.ID}, update.Despawned)
+
+	transformComp, _ := projectile.GetComponent(ecs.ComponentTypeTransform)
+	assert.InDelta(t, 3, transformComp.(*components.TransformComponent).X, 0.0001)
+}
+
+func TestProjectileExpiresAfterLifetime(t *testing.T) {
+	system := newProjectileSystem()
+
+	owner := newTarget(0, 0, 1)
+	projectile := newProjectile(owner.ID, 0, 1)
+
+	update := system.Update(1, []*ecs.Entity{owner, projectile})
+	assert.Len(t, update.Despawned, 0)
+
+	update = system.Update(1, []*ecs.Entity{owner, projectile})
+	assert.Equal(t, []uuid.UUID{projectile.ID}, update.Despawned)
+}
@@ -2,10 +2,13 @@ package systems
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
 )
 
 /*
@@ -14,31 +17,190 @@ Player queue system - 使用 channel 監聽玩家加入配對
 
 // QueueStatus 用於通知排隊狀態
 type QueueStatus struct {
+	QueueID string
 	Players []*types.Player
 	Current int
 	Total   int
 }
 
+// Match 配對成功的玩家以及他們排的 queue
+type Match struct {
+	QueueID  string
+	GameMode string
+	Players  []*types.Player
+	// when each player joined the queue, kept so players put back in the
+	// queue don't lose their wait
+	JoinedAt map[uuid.UUID]time.Time
+	// set when the players fill open slots in a session already being
+	// played instead of starting a new one
+	SessionID uuid.UUID
+}
+
+/**
+* the matched players who aren't bots.
+**/
+func (m Match) Humans() []*types.Player {
+	humans := make([]*types.Player, 0, len(m.Players))
+	for _, player := range m.Players {
+		if !player.Bot {
+			humans = append(humans, player)
+		}
+	}
+	return humans
+}
+
+/**
+* Open slots in a session already being played. Queues fill them before
+* starting new matches.
+**/
+type BackfillSlot struct {
+	SessionID uuid.UUID `json:"session_id"`
+	// [team] to how many more players it takes
+	Open map[int]int `json:"open"`
+	// game node hosting the session when queues are shared between nodes
+	Host string `json:"host,omitempty"`
+}
+
+func (b BackfillSlot) total() int {
+	total := 0
+	for _, open := range b.Open {
+		total += open
+	}
+	return total
+}
+
+/**
+* Settings for a single named queue.
+**/
+type QueueConfig struct {
+	ID       string
+	GameMode string
+	// players needed to start a match right away
+	MatchSize int
+	// once the oldest player has waited FillTimeout a match starts with as
+	// few as MinPlayers
+	MinPlayers  int
+	FillTimeout time.Duration
+	// most players the match's session can hold, the room left over
+	// after MatchSize is for players joining later
+	MaxPlayers int
+	// 配對成功後分成幾隊
+	TeamCount int
+	// rated queues only match players with close ratings, nil matches in
+	// the order players joined
+	Rating *RatingWindow
+	// once the oldest player has waited BotFill the rest of the match is
+	// filled with bots, 0 never adds bots
+	BotFill       time.Duration
+	BotDifficulty string
+}
+
+func (c QueueConfig) Validate() error {
+	if c.ID == "" {
+		return fmt.Errorf("queue id is required")
+	}
+
+	if c.MinPlayers < 1 || c.MinPlayers > c.MatchSize || c.MatchSize > c.MaxPlayers {
+		return fmt.Errorf("queue %s needs 1 <= min players (%d) <= match size (%d) <= max players (%d)",
+			c.ID, c.MinPlayers, c.MatchSize, c.MaxPlayers)
+	}
+
+	if c.MinPlayers < c.MatchSize && c.FillTimeout <= 0 {
+		return fmt.Errorf("queue %s starts short matches but has no fill timeout", c.ID)
+	}
+
+	if c.BotFill > 0 {
+		if _, ok := BotDifficultyByName(c.BotDifficulty); !ok {
+			return fmt.Errorf("queue %s has unknown bot difficulty %s", c.ID, c.BotDifficulty)
+		}
+	}
+
+	return nil
+}
+
+/**
+* most players a single team holds, and so the biggest party that can queue.
+**/
+func (c QueueConfig) TeamSize() int {
+	teams := c.TeamCount
+	if teams < 1 {
+		teams = 1
+	}
+
+	return (c.MatchSize + teams - 1) / teams
+}
+
+/**
+* Snapshot of a queue for players browsing queues.
+**/
+type QueueStats struct {
+	QueueID     string
+	GameMode    string
+	MatchSize   int
+	MinPlayers  int
+	MaxPlayers  int
+	Waiting     int
+	LongestWait time.Duration
+}
+
+/**
+* A queue entry in a form that can be stored outside the process, so queues
+* can be shared between game service instances.
+**/
+type QueuedGroup struct {
+	Players  []*types.Player `json:"players"`
+	JoinedAt time.Time       `json:"joined_at"`
+}
+
+// queueEntry 一起排隊的玩家，單人或整個隊伍 (party)
+// NOTE: entries are matched as a whole and always end up on the same team
+type queueEntry struct {
+	players  []*types.Player
+	joinedAt time.Time
+}
+
+func (e *queueEntry) rating() float64 {
+	var total float64
+	for _, player := range e.players {
+		total += player.Rating
+	}
+	return total / float64(len(e.players))
+}
+
 type QueueSystem struct {
 	// 接收要加入配對的玩家
 	playerChan chan *types.Player
-	queue      []*types.Player
-	// 需要多少人才能開始遊戲
-	matchSize int
+	queue      []*queueEntry
+	// [playerId] to the entry they're queued in
+	entries map[uuid.UUID]*queueEntry
+	// sessions with open slots, in the order they opened
+	backfills []*BackfillSlot
+
+	config QueueConfig
 
 	mu sync.RWMutex
 
-	MatchedChan     chan []*types.Player
+	MatchedChan     chan Match
 	QueueStatusChan chan QueueStatus
+
+	// closed once the queue stops matching
+	done     chan struct{}
+	stopOnce sync.Once
 }
 
-func NewQueueSystem(matchSize int) *QueueSystem {
+func NewQueueSystem(config QueueConfig) *QueueSystem {
+	if config.TeamCount < 1 {
+		config.TeamCount = 1
+	}
+
 	return &QueueSystem{
 		playerChan:      make(chan *types.Player),
-		matchSize:       matchSize,
-		queue:           make([]*types.Player, 0),
-		MatchedChan:     make(chan []*types.Player),
+		config:          config,
+		queue:           make([]*queueEntry, 0),
+		entries:         make(map[uuid.UUID]*queueEntry),
+		MatchedChan:     make(chan Match),
 		QueueStatusChan: make(chan QueueStatus),
+		done:            make(chan struct{}),
 	}
 }
 
@@ -46,7 +208,26 @@ func NewQueueSystem(matchSize int) *QueueSystem {
 func (q *QueueSystem) Start() {
 	go q.matchQueue()
 	go q.JoinQueue()
-	fmt.Println("QueueSystem started, listening for players...")
+	fmt.Printf("QueueSystem %s started, listening for players...\n", q.config.ID)
+}
+
+/**
+* stops matching the queue, players already in it stay where they are.
+**/
+func (q *QueueSystem) Stop() {
+	q.stopOnce.Do(func() {
+		close(q.done)
+	})
+}
+
+func (q *QueueSystem) ID() string {
+	return q.config.ID
+}
+
+func (q *QueueSystem) Config() QueueConfig {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.config
 }
 
 // AddPlayer 將玩家加入配對 queue（透過 channel）
@@ -59,6 +240,8 @@ func (q *QueueSystem) JoinQueue() {
 		select {
 		case player := <-q.playerChan:
 			q.PlayerJoinQueue(player)
+		case <-q.done:
+			return
 		}
 	}
 }
@@ -70,78 +253,561 @@ func (q *QueueSystem) matchQueue() {
 	defer ticker.Stop()
 
 	for {
-		// fmt.Println("match queue")
-
 		select {
+		case <-q.done:
+			return
 		// 每秒從chan送一次值
-		case <-ticker.C:
-			q.mu.Lock()
-			sizeLen := len(q.queue) >= q.matchSize
-			q.mu.Unlock()
-			defer q.mu.Unlock()
-			// 人數滿了
-			if sizeLen {
-				matched := make([]*types.Player, q.matchSize)
-				q.mu.Lock()
-				// 取前兩個
-				copy(matched, q.queue[:q.matchSize])
-				// 移除前兩個
-				q.queue = q.queue[q.matchSize:]
-				q.mu.Unlock()
-				fmt.Println("Match found!")
-				q.MatchedChan <- matched
+		case now := <-ticker.C:
+			// running games are filled before new ones start
+			if match := q.TakeBackfill(); match != nil {
+				fmt.Printf("Backfilling session %s from queue %s\n", match.SessionID, q.config.ID)
+				q.MatchedChan <- *match
 				continue
 			}
+
+			if match := q.TakeMatch(now); match != nil {
+				fmt.Printf("Match found in queue %s!\n", q.config.ID)
+				q.MatchedChan <- *match
+				continue
+			}
+
 			// 人數不足，通知玩家目前排隊人數
-			if len(q.queue) > 0 {
-				fmt.Printf("Waiting: %d/%d\n", len(q.queue), q.matchSize)
-				// 複製一份 queue 發送狀態
-				q.mu.Lock()
-				playersCopy := make([]*types.Player, len(q.queue))
-				copy(playersCopy, q.queue)
-				q.mu.Unlock()
+			q.mu.RLock()
+			playersCopy := make([]*types.Player, 0, len(q.entries))
+			for _, entry := range q.queue {
+				playersCopy = append(playersCopy, entry.players...)
+			}
+			q.mu.RUnlock()
+
+			if len(playersCopy) > 0 {
+				fmt.Printf("Queue %s waiting: %d/%d\n", q.config.ID, len(playersCopy), q.config.MatchSize)
 
 				// 發送到 QueueStatusChan（用 goroutine 避免阻塞）
 				go func() {
 					q.QueueStatusChan <- QueueStatus{
+						QueueID: q.config.ID,
 						Players: playersCopy,
 						Current: len(playersCopy),
-						Total:   q.matchSize,
+						Total:   q.config.MatchSize,
 					}
 				}()
-				continue
 			}
+		}
+	}
+}
 
+/**
+* removes and returns the players for the next match, or nil when the queue
+* can't start one yet. A full match starts right away, a short one only once
+* the oldest player has waited out the fill timeout.
+**/
+func (q *QueueSystem) TakeMatch(now time.Time) *Match {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.config.Rating != nil {
+		return q.takeRatedMatch(now)
+	}
+
+	return q.matchFrom(q.queue, now)
+}
+
+/**
+* looks for a match around each waiting entry, oldest first, only taking
+* entries whose rating is within that entry's current window. The closest
+* ratings fill the match.
+**/
+// NOTE: caller must hold the lock
+func (q *QueueSystem) takeRatedMatch(now time.Time) *Match {
+	for _, anchor := range q.queue {
+		window := q.config.Rating.At(now.Sub(anchor.joinedAt))
+		anchorRating := anchor.rating()
+
+		candidates := make([]*queueEntry, 0, len(q.queue))
+		for _, entry := range q.queue {
+			if math.Abs(entry.rating()-anchorRating) <= window {
+				candidates = append(candidates, entry)
+			}
+		}
+
+		// closest ratings first, ties keep the order they joined in
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return math.Abs(candidates[i].rating()-anchorRating) < math.Abs(candidates[j].rating()-anchorRating)
+		})
+
+		if match := q.matchFrom(candidates, now); match != nil {
+			return match
+		}
+	}
+
+	return nil
+}
+
+/**
+* fills a match from the entries in order, skipping entries that no longer
+* fit on any team.
+**/
+// NOTE: caller must hold the lock
+func (q *QueueSystem) matchFrom(entries []*queueEntry, now time.Time) *Match {
+	picked, teams := q.packTeams(entries)
+
+	total := 0
+	for _, entry := range picked {
+		total += len(entry.players)
+	}
+
+	if total == q.config.MatchSize || q.canStartShort(picked, total, now) {
+		return q.removeMatched(picked, teams)
+	}
+
+	if q.canFillWithBots(picked, total, now) {
+		return q.fillWithBots(q.removeMatched(picked, teams))
+	}
+
+	return nil
+}
+
+/**
+* places entries on teams in order. Each goes to the team with the most room
+* left, ties go to the team with the lowest total rating to keep teams even.
+**/
+// NOTE: caller must hold the lock
+func (q *QueueSystem) packTeams(entries []*queueEntry) ([]*queueEntry, map[*queueEntry]int) {
+	teamSize := q.config.TeamSize()
+	room := make([]int, q.config.TeamCount)
+	ratings := make([]float64, q.config.TeamCount)
+	for i := range room {
+		room[i] = teamSize
+	}
+
+	picked := make([]*queueEntry, 0, len(entries))
+	teams := make(map[*queueEntry]int, len(entries))
+	total := 0
+
+	for _, entry := range entries {
+		size := len(entry.players)
+		if total+size > q.config.MatchSize {
+			continue
+		}
+
+		best := 0
+		for team := 1; team < len(room); team++ {
+			if room[team] > room[best] || (room[team] == room[best] && ratings[team] < ratings[best]) {
+				best = team
+			}
+		}
+
+		if room[best] < size {
+			continue
+		}
+
+		room[best] -= size
+		ratings[best] += entry.rating() * float64(size)
+		teams[entry] = best + 1
+		picked = append(picked, entry)
+		total += size
+
+		if total == q.config.MatchSize {
+			break
+		}
+	}
+
+	return picked, teams
+}
+
+/**
+* whether the entries can start a match below the match size, the longest
+* waiting of them must have waited out the fill timeout.
+**/
+// NOTE: caller must hold the lock
+func (q *QueueSystem) canStartShort(entries []*queueEntry, total int, now time.Time) bool {
+	if total == 0 || total < q.config.MinPlayers {
+		return false
+	}
+
+	var longestWait time.Duration
+	for _, entry := range entries {
+		if wait := now.Sub(entry.joinedAt); wait > longestWait {
+			longestWait = wait
+		}
+	}
+
+	return longestWait >= q.config.FillTimeout
+}
+
+/**
+* whether the rest of the match can be bots, the longest waiting of the
+* entries must have waited out the bot fill timeout.
+**/
+// NOTE: caller must hold the lock
+func (q *QueueSystem) canFillWithBots(entries []*queueEntry, total int, now time.Time) bool {
+	if q.config.BotFill <= 0 || total == 0 {
+		return false
+	}
+
+	for _, entry := range entries {
+		if now.Sub(entry.joinedAt) >= q.config.BotFill {
+			return true
 		}
 	}
+
+	return false
+}
+
+/**
+* tops every team up to its full size with bots.
+**/
+func (q *QueueSystem) fillWithBots(match *Match) *Match {
+	teams := q.config.TeamCount
+	if teams < 1 {
+		teams = 1
+	}
+
+	counts := make(map[int]int, teams)
+	for _, player := range match.Players {
+		counts[player.Team]++
+	}
+
+	number := 1
+	for team := 1; team <= teams && len(match.Players) < q.config.MatchSize; team++ {
+		for counts[team] < q.config.TeamSize() && len(match.Players) < q.config.MatchSize {
+			bot := NewBotPlayer(q.config.BotDifficulty, number)
+			bot.Team = team
+			match.Players = append(match.Players, bot)
+			counts[team]++
+			number++
+		}
+	}
+
+	return match
+}
+
+// NOTE: caller must hold the lock
+func (q *QueueSystem) removeMatched(picked []*queueEntry, teams map[*queueEntry]int) *Match {
+	match := &Match{
+		QueueID:  q.config.ID,
+		GameMode: q.config.GameMode,
+		Players:  make([]*types.Player, 0, q.config.MatchSize),
+		JoinedAt: make(map[uuid.UUID]time.Time, q.config.MatchSize),
+	}
+
+	matched := make(map[*queueEntry]bool, len(picked))
+	for _, entry := range picked {
+		matched[entry] = true
+
+		for _, player := range entry.players {
+			// NOTE: 回傳玩家的副本，避免修改到其他地方共用的 player
+			p := *player
+			p.Team = teams[entry]
+			match.Players = append(match.Players, &p)
+			match.JoinedAt[player.ID] = entry.joinedAt
+			delete(q.entries, player.ID)
+		}
+	}
+
+	remaining := make([]*queueEntry, 0, len(q.queue))
+	for _, entry := range q.queue {
+		if !matched[entry] {
+			remaining = append(remaining, entry)
+		}
+	}
+	q.queue = remaining
+
+	return match
+}
+
+// SetTeamCount 設定配對成功後要分成幾隊
+func (q *QueueSystem) SetTeamCount(teamCount int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if teamCount < 1 {
+		teamCount = 1
+	}
+	q.config.TeamCount = teamCount
 }
 
 // handlePlayerJoinQueue 處理玩家加入 queue 的邏輯
 func (q *QueueSystem) PlayerJoinQueue(player *types.Player) {
+	q.GroupJoinQueue([]*types.Player{player})
+}
+
+/**
+* queues players that have to be matched together, e.g. a party.
+**/
+func (q *QueueSystem) GroupJoinQueue(players []*types.Player) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
 	// check if player in queue
-	for _, p := range q.queue {
-		if p.ID == player.ID {
+	for _, player := range players {
+		if _, queued := q.entries[player.ID]; queued {
 			fmt.Println("player already exists", player.ID)
 			return
 		}
 	}
 
 	// 加入 queue
-	q.queue = append(q.queue, player)
-	fmt.Printf("Player %s joined queue. Waiting: %d/%d\n", player.Username, len(q.queue), q.matchSize)
+	q.addEntry(&queueEntry{players: players, joinedAt: time.Now()}, false)
+	fmt.Printf("%d player(s) joined queue %s. Waiting: %d/%d\n", len(players), q.config.ID, len(q.entries), q.config.MatchSize)
 }
 
-// TODO: discconnect remove player
+// NOTE: caller must hold the lock
+func (q *QueueSystem) addEntry(entry *queueEntry, front bool) {
+	for _, player := range entry.players {
+		q.entries[player.ID] = entry
+	}
+
+	if front {
+		q.queue = append([]*queueEntry{entry}, q.queue...)
+		return
+	}
+
+	q.queue = append(q.queue, entry)
+}
+
+/**
+* removes the player and everyone queued with them.
+**/
 func (q *QueueSystem) PlayerRemoveQueue(player *types.Player) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	for i, queue := range q.queue {
-		if queue.ID == player.ID {
+
+	entry, queued := q.entries[player.ID]
+	if !queued {
+		return
+	}
+
+	for _, p := range entry.players {
+		delete(q.entries, p.ID)
+	}
+
+	for i, queuedEntry := range q.queue {
+		if queuedEntry == entry {
 			q.queue = append(q.queue[:i], q.queue[i+1:]...)
 			return
 		}
 	}
 }
+
+/**
+* puts players back at the front of the queue in the given order, keeping
+* the time they originally joined. Players sharing a party go back in
+* together.
+**/
+func (q *QueueSystem) RequeueFront(players []*types.Player, joinedAt map[uuid.UUID]time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	grouped := make([]*queueEntry, 0, len(players))
+	parties := make(map[uuid.UUID]*queueEntry)
+
+	for _, player := range players {
+		// bots were only made for the match
+		if player.Bot {
+			continue
+		}
+
+		if _, queued := q.entries[player.ID]; queued {
+			continue
+		}
+
+		requeued := *player
+		requeued.Team = 0
+
+		joined, ok := joinedAt[player.ID]
+		if !ok {
+			joined = time.Now()
+		}
+
+		if entry, exists := parties[player.PartyID]; exists && player.PartyID != uuid.Nil {
+			entry.players = append(entry.players, &requeued)
+			if joined.Before(entry.joinedAt) {
+				entry.joinedAt = joined
+			}
+			continue
+		}
+
+		entry := &queueEntry{players: []*types.Player{&requeued}, joinedAt: joined}
+		grouped = append(grouped, entry)
+		if player.PartyID != uuid.Nil {
+			parties[player.PartyID] = entry
+		}
+	}
+
+	// added back to front so they keep their order
+	for i := len(grouped) - 1; i >= 0; i-- {
+		q.addEntry(grouped[i], true)
+	}
+}
+
+func (q *QueueSystem) Contains(playerID uuid.UUID) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	_, queued := q.entries[playerID]
+	return queued
+}
+
+func (q *QueueSystem) Stats() QueueStats {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	stats := QueueStats{
+		QueueID:    q.config.ID,
+		GameMode:   q.config.GameMode,
+		MatchSize:  q.config.MatchSize,
+		MinPlayers: q.config.MinPlayers,
+		MaxPlayers: q.config.MaxPlayers,
+		Waiting:    len(q.entries),
+	}
+
+	for _, entry := range q.queue {
+		if wait := time.Since(entry.joinedAt); wait > stats.LongestWait {
+			stats.LongestWait = wait
+		}
+	}
+
+	return stats
+}
+
+/**
+* the waiting entries in queue order.
+**/
+func (q *QueueSystem) Snapshot() []QueuedGroup {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	groups := make([]QueuedGroup, 0, len(q.queue))
+	for _, entry := range q.queue {
+		players := make([]*types.Player, len(entry.players))
+		copy(players, entry.players)
+		groups = append(groups, QueuedGroup{Players: players, JoinedAt: entry.joinedAt})
+	}
+
+	return groups
+}
+
+/**
+* replaces everything waiting in the queue with the given entries.
+**/
+func (q *QueueSystem) Restore(groups []QueuedGroup) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.queue = make([]*queueEntry, 0, len(groups))
+	q.entries = make(map[uuid.UUID]*queueEntry)
+
+	for _, group := range groups {
+		q.addEntry(&queueEntry{players: group.Players, joinedAt: group.JoinedAt}, false)
+	}
+}
+
+/**
+* opens the session's slots to the queue, replacing whatever it had open
+* before. A slot with nothing open closes.
+**/
+func (q *QueueSystem) OpenBackfill(slot BackfillSlot) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.removeBackfill(slot.SessionID)
+
+	if slot.total() == 0 {
+		return
+	}
+
+	open := make(map[int]int, len(slot.Open))
+	for team, room := range slot.Open {
+		open[team] = room
+	}
+	slot.Open = open
+
+	q.backfills = append(q.backfills, &slot)
+}
+
+func (q *QueueSystem) CloseBackfill(sessionID uuid.UUID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.removeBackfill(sessionID)
+}
+
+// NOTE: caller must hold the lock
+func (q *QueueSystem) removeBackfill(sessionID uuid.UUID) {
+	for i, slot := range q.backfills {
+		if slot.SessionID == sessionID {
+			q.backfills = append(q.backfills[:i], q.backfills[i+1:]...)
+			return
+		}
+	}
+}
+
+/**
+* the sessions with open slots, in the order they opened.
+**/
+func (q *QueueSystem) Backfills() []BackfillSlot {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	slots := make([]BackfillSlot, 0, len(q.backfills))
+	for _, slot := range q.backfills {
+		open := make(map[int]int, len(slot.Open))
+		for team, room := range slot.Open {
+			open[team] = room
+		}
+		slots = append(slots, BackfillSlot{SessionID: slot.SessionID, Open: open, Host: slot.Host})
+	}
+
+	return slots
+}
+
+/**
+* removes and returns the players filling the oldest session with open
+* slots, or nil when nobody waiting fits. Entries are taken in the order
+* they joined, each onto the team with the most room, ratings aren't
+* considered since the game is already under way.
+**/
+func (q *QueueSystem) TakeBackfill() *Match {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, slot := range q.backfills {
+		picked := make([]*queueEntry, 0)
+		teams := make(map[*queueEntry]int)
+
+		for _, entry := range q.queue {
+			size := len(entry.players)
+
+			best := 0
+			for team, room := range slot.Open {
+				if room >= size && (best == 0 || room > slot.Open[best] || (room == slot.Open[best] && team < best)) {
+					best = team
+				}
+			}
+
+			if best == 0 {
+				continue
+			}
+
+			slot.Open[best] -= size
+			teams[entry] = best
+			picked = append(picked, entry)
+		}
+
+		if len(picked) == 0 {
+			continue
+		}
+
+		match := q.removeMatched(picked, teams)
+		match.SessionID = slot.SessionID
+
+		if slot.total() == 0 {
+			q.removeBackfill(slot.SessionID)
+		}
+
+		return match
+	}
+
+	return nil
+}
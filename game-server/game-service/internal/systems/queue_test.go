@@ -0,0 +1,285 @@
+package systems
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newQueuedPlayers(count int) []*types.Player {
+	players := make([]*types.Player, count)
+	for i := range players {
+		players[i] = &types.Player{ID: uuid.New(), Username: fmt.Sprintf("player%d", i)}
+	}
+	return players
+}
+
+func TestQueueTakeMatch(t *testing.T) {
+	queue := NewQueueSystem(QueueConfig{
+		ID:          "squad",
+		MatchSize:   4,
+		MinPlayers:  2,
+		FillTimeout: 30 * time.Second,
+		MaxPlayers:  4,
+		TeamCount:   2,
+	})
+
+	for _, player := range newQueuedPlayers(3) {
+		queue.PlayerJoinQueue(player)
+	}
+
+	now := time.Now()
+
+	// not full and nobody has waited long enough
+	assert.Nil(t, queue.TakeMatch(now))
+
+	// a short match once the oldest player waited out the fill timeout
+	match := queue.TakeMatch(now.Add(31 * time.Second))
+	require.NotNil(t, match)
+	matched := match.Players
+	require.Len(t, matched, 3)
+	assert.Equal(t, []int{1, 2, 1}, []int{matched[0].Team, matched[1].Team, matched[2].Team})
+	assert.Equal(t, 0, queue.Stats().Waiting)
+
+	// a full match starts right away and leaves the rest queued
+	for _, player := range newQueuedPlayers(5) {
+		queue.PlayerJoinQueue(player)
+	}
+
+	match = queue.TakeMatch(time.Now())
+	require.NotNil(t, match)
+	matched = match.Players
+	assert.Len(t, matched, 4)
+	assert.Equal(t, 1, queue.Stats().Waiting)
+}
+
+func TestQueueConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  QueueConfig
+		wantErr bool
+	}{
+		{name: "valid", config: QueueConfig{ID: "duo", MatchSize: 2, MinPlayers: 2, MaxPlayers: 4}},
+		{name: "missing id", config: QueueConfig{MatchSize: 2, MinPlayers: 2, MaxPlayers: 2}, wantErr: true},
+		{name: "match bigger than session", config: QueueConfig{ID: "big", MatchSize: 6, MinPlayers: 6, MaxPlayers: 4}, wantErr: true},
+		{name: "short matches without timeout", config: QueueConfig{ID: "short", MatchSize: 4, MinPlayers: 2, MaxPlayers: 4}, wantErr: true},
+		{name: "unknown bot difficulty", config: QueueConfig{ID: "bots", MatchSize: 2, MinPlayers: 2, MaxPlayers: 2, BotFill: time.Minute, BotDifficulty: "impossible"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestMatchmakerQueues(t *testing.T) {
+	_, err := NewMatchmaker([]QueueConfig{
+		{ID: "duo", MatchSize: 2, MinPlayers: 2, MaxPlayers: 2},
+		{ID: "duo", MatchSize: 2, MinPlayers: 2, MaxPlayers: 2},
+	})
+	assert.Error(t, err)
+
+	matchmaker, err := NewMatchmaker([]QueueConfig{
+		{ID: "duo", GameMode: "coop", MatchSize: 2, MinPlayers: 2, MaxPlayers: 2},
+		{ID: "1v1", GameMode: "team_deathmatch", MatchSize: 2, MinPlayers: 2, MaxPlayers: 2, TeamCount: 2},
+	})
+	require.NoError(t, err)
+
+	player := newQueuedPlayers(1)[0]
+
+	_, err = matchmaker.AddPlayer("ranked", player)
+	assert.ErrorIs(t, err, ErrQueueNotFound)
+
+	// no queue id means the first queue
+	queueID, err := matchmaker.AddPlayer("", player)
+	require.NoError(t, err)
+	assert.Equal(t, "duo", queueID)
+
+	_, err = matchmaker.AddPlayer("1v1", player)
+	assert.ErrorIs(t, err, ErrAlreadyQueued)
+	_, err = matchmaker.AddPlayer("duo", player)
+	assert.ErrorIs(t, err, ErrAlreadyQueued)
+
+	stats := matchmaker.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "1v1", stats[0].QueueID)
+	assert.Equal(t, 0, stats[0].Waiting)
+	assert.Equal(t, 1, stats[1].Waiting)
+
+	matchmaker.RemovePlayer(player)
+	_, queued := matchmaker.QueueOf(player.ID)
+	assert.False(t, queued)
+}
+
+func TestQueuePartiesStayTogether(t *testing.T) {
+	queue := NewQueueSystem(QueueConfig{
+		ID:         "4v4",
+		MatchSize:  8,
+		MinPlayers: 8,
+		MaxPlayers: 8,
+		TeamCount:  2,
+	})
+
+	newParty := func(size int) []*types.Player {
+		partyID := uuid.New()
+		party := newQueuedPlayers(size)
+		for _, player := range party {
+			player.PartyID = partyID
+		}
+		return party
+	}
+
+	party, otherParty, duo := newParty(3), newParty(3), newParty(2)
+	queue.GroupJoinQueue(party)
+	queue.GroupJoinQueue(otherParty)
+
+	// the duo would have to be split across teams
+	queue.GroupJoinQueue(duo)
+	assert.Nil(t, queue.TakeMatch(time.Now()))
+
+	for _, player := range newQueuedPlayers(2) {
+		queue.PlayerJoinQueue(player)
+	}
+
+	match := queue.TakeMatch(time.Now())
+	require.NotNil(t, match)
+	require.Len(t, match.Players, 8)
+
+	teams := make(map[uuid.UUID]int)
+	for _, player := range match.Players {
+		teams[player.ID] = player.Team
+	}
+	assert.Equal(t, teams[party[0].ID], teams[party[1].ID])
+	assert.Equal(t, teams[party[0].ID], teams[party[2].ID])
+	assert.Equal(t, teams[otherParty[0].ID], teams[otherParty[2].ID])
+	assert.NotEqual(t, teams[party[0].ID], teams[otherParty[0].ID])
+	assert.NotContains(t, teams, duo[0].ID)
+
+	// leaving takes the whole party out
+	queue.PlayerRemoveQueue(duo[1])
+	assert.Equal(t, 0, queue.Stats().Waiting)
+
+	// requeued party members go back in as one entry
+	queue.RequeueFront(party, map[uuid.UUID]time.Time{})
+	assert.Equal(t, 3, queue.Stats().Waiting)
+	queue.PlayerRemoveQueue(party[0])
+	assert.False(t, queue.Contains(party[2].ID))
+}
+
+func TestMatchmakerRejectsOversizedParty(t *testing.T) {
+	matchmaker, err := NewMatchmaker([]QueueConfig{
+		{ID: "1v1", MatchSize: 2, MinPlayers: 2, MaxPlayers: 2, TeamCount: 2},
+	})
+	require.NoError(t, err)
+
+	_, err = matchmaker.AddGroup("1v1", newQueuedPlayers(2))
+	assert.ErrorIs(t, err, ErrPartyTooLarge)
+}
+
+func TestQueueTakeBackfill(t *testing.T) {
+	queue := NewQueueSystem(QueueConfig{
+		ID:         "2v2",
+		MatchSize:  4,
+		MinPlayers: 4,
+		MaxPlayers: 4,
+		TeamCount:  2,
+	})
+
+	sessionID := uuid.New()
+	queue.OpenBackfill(BackfillSlot{SessionID: sessionID, Open: map[int]int{1: 1, 2: 2}})
+
+	duo := newQueuedPlayers(2)
+	partyID := uuid.New()
+	for _, player := range duo {
+		player.PartyID = partyID
+	}
+	solo := newQueuedPlayers(2)
+
+	// the duo only fits on team 2, the first solo player takes team 1
+	queue.GroupJoinQueue(duo)
+	queue.PlayerJoinQueue(solo[0])
+	queue.PlayerJoinQueue(solo[1])
+
+	match := queue.TakeBackfill()
+	require.NotNil(t, match)
+	assert.Equal(t, sessionID, match.SessionID)
+	require.Len(t, match.Players, 3)
+	assert.Equal(t, []int{2, 2, 1}, []int{match.Players[0].Team, match.Players[1].Team, match.Players[2].Team})
+
+	// the session is full, the last player waits for a new match
+	assert.Empty(t, queue.Backfills())
+	assert.Nil(t, queue.TakeBackfill())
+	assert.True(t, queue.Contains(solo[1].ID))
+
+	queue.OpenBackfill(BackfillSlot{SessionID: sessionID, Open: map[int]int{1: 1}})
+	queue.CloseBackfill(sessionID)
+	assert.Nil(t, queue.TakeBackfill())
+}
+
+// TestQueueFillsWithBots tests players waiting past the bot fill timeout get bots to play with
+func TestQueueFillsWithBots(t *testing.T) {
+	queue := NewQueueSystem(QueueConfig{
+		ID:            "duel",
+		MatchSize:     4,
+		MinPlayers:    4,
+		MaxPlayers:    4,
+		TeamCount:     2,
+		BotFill:       time.Minute,
+		BotDifficulty: "easy",
+	})
+
+	for _, player := range newQueuedPlayers(1) {
+		queue.PlayerJoinQueue(player)
+	}
+
+	now := time.Now()
+	assert.Nil(t, queue.TakeMatch(now.Add(30*time.Second)))
+
+	match := queue.TakeMatch(now.Add(61 * time.Second))
+	require.NotNil(t, match)
+	require.Len(t, match.Players, 4)
+	require.Len(t, match.Humans(), 1)
+
+	teams := make(map[int]int)
+	for _, player := range match.Players[1:] {
+		assert.True(t, player.Bot)
+		assert.Equal(t, "easy", player.BotDifficulty)
+		teams[player.Team]++
+	}
+	assert.Equal(t, map[int]int{1: 1, 2: 2}, teams)
+
+	// bots never go back in the queue
+	queue.RequeueFront(match.Players, match.JoinedAt)
+	assert.Equal(t, 1, queue.Stats().Waiting)
+}
+
+// TestMatchmakerStop tests a stopped matchmaker's queue loops exit
+func TestMatchmakerStop(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	matchmaker, err := NewMatchmaker([]QueueConfig{
+		{ID: "duo", MatchSize: 2, MinPlayers: 2, MaxPlayers: 2},
+		{ID: "squad", MatchSize: 4, MinPlayers: 4, MaxPlayers: 4},
+	})
+	require.NoError(t, err)
+
+	matchmaker.Start()
+	matchmaker.Stop()
+	// stopping twice is fine
+	matchmaker.Stop()
+
+	// polled here, Eventually runs its condition on goroutines of its own
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
@@ -0,0 +1,120 @@
+package systems
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/**
+* Skill rating
+*
+* Players carry an Elo rating per game mode. Rated queues only match players
+* whose ratings are close, and the allowed gap widens the longer the oldest
+* player has been waiting until, past the max wait, anyone will do.
+**/
+
+const DefaultRating float64 = 1500
+
+type EloConfig struct {
+	// most rating a single match can move
+	K float64
+}
+
+func DefaultEloConfig() EloConfig {
+	return EloConfig{K: 32}
+}
+
+/**
+* chance of a player with the given rating beating the opponent.
+**/
+func ExpectedScore(rating, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/400))
+}
+
+/**
+* new ratings after a team match. Each player's change is worked out from
+* their team's average rating against the average of everyone else. A
+* winning team of 0 is a draw.
+**/
+func UpdateTeamRatings(config EloConfig, ratings map[uuid.UUID]float64, teams map[uuid.UUID]int, winningTeam int) map[uuid.UUID]float64 {
+	teamTotals := make(map[int]float64)
+	teamSizes := make(map[int]int)
+	for playerID, team := range teams {
+		teamTotals[team] += ratings[playerID]
+		teamSizes[team]++
+	}
+
+	updated := make(map[uuid.UUID]float64, len(teams))
+
+	// nobody to be rated against
+	if len(teamSizes) < 2 {
+		for playerID := range teams {
+			updated[playerID] = ratings[playerID]
+		}
+		return updated
+	}
+
+	var total float64
+	for _, teamTotal := range teamTotals {
+		total += teamTotal
+	}
+
+	for playerID, team := range teams {
+		teamAverage := teamTotals[team] / float64(teamSizes[team])
+		opponentAverage := (total - teamTotals[team]) / float64(len(teams)-teamSizes[team])
+
+		score := 0.5
+		if winningTeam != 0 {
+			score = 0
+			if team == winningTeam {
+				score = 1
+			}
+		}
+
+		change := config.K * (score - ExpectedScore(teamAverage, opponentAverage))
+		updated[playerID] = ratings[playerID] + change
+	}
+
+	return updated
+}
+
+/**
+* How far apart ratings in a rated queue are allowed to be.
+**/
+type RatingWindow struct {
+	// allowed gap as soon as a player joins
+	Initial float64
+	// extra gap for every second spent waiting
+	WidenPerSecond float64
+	// gap never grows past this before MaxWait
+	Max float64
+	// after waiting this long any rating will do
+	MaxWait time.Duration
+}
+
+func DefaultRatingWindow() RatingWindow {
+	return RatingWindow{
+		Initial:        100,
+		WidenPerSecond: 10,
+		Max:            500,
+		MaxWait:        2 * time.Minute,
+	}
+}
+
+/**
+* the allowed rating gap after waiting for the given time.
+**/
+func (w RatingWindow) At(wait time.Duration) float64 {
+	if w.MaxWait > 0 && wait >= w.MaxWait {
+		return math.Inf(1)
+	}
+
+	window := w.Initial + w.WidenPerSecond*wait.Seconds()
+	if w.Max > 0 && window > w.Max {
+		window = w.Max
+	}
+
+	return window
+}
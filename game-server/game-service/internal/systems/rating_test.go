@@ -0,0 +1,91 @@
+package systems
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateTeamRatings(t *testing.T) {
+	config := DefaultEloConfig()
+	strong, weak := uuid.New(), uuid.New()
+
+	ratings := map[uuid.UUID]float64{strong: 1700, weak: 1300}
+	teams := map[uuid.UUID]int{strong: 1, weak: 2}
+
+	// an expected win barely moves ratings
+	updated := UpdateTeamRatings(config, ratings, teams, 1)
+	assert.InDelta(t, 1702.9, updated[strong], 0.1)
+	assert.InDelta(t, 1297.1, updated[weak], 0.1)
+
+	// an upset moves them a lot
+	updated = UpdateTeamRatings(config, ratings, teams, 2)
+	assert.InDelta(t, 1670.9, updated[strong], 0.1)
+	assert.InDelta(t, 1329.1, updated[weak], 0.1)
+
+	// a draw pulls the ratings together
+	updated = UpdateTeamRatings(config, ratings, teams, 0)
+	assert.Less(t, updated[strong], ratings[strong])
+	assert.Greater(t, updated[weak], ratings[weak])
+
+	// rating gained and lost always balance out
+	assert.InDelta(t, ratings[strong]+ratings[weak], updated[strong]+updated[weak], 0.001)
+
+	// a single team has nobody to be rated against
+	updated = UpdateTeamRatings(config, ratings, map[uuid.UUID]int{strong: 1, weak: 1}, 1)
+	assert.Equal(t, ratings, updated)
+}
+
+func TestRatingWindowWidens(t *testing.T) {
+	window := RatingWindow{Initial: 100, WidenPerSecond: 10, Max: 300, MaxWait: time.Minute}
+
+	assert.Equal(t, float64(100), window.At(0))
+	assert.Equal(t, float64(200), window.At(10*time.Second))
+	assert.Equal(t, float64(300), window.At(50*time.Second))
+	assert.True(t, math.IsInf(window.At(time.Minute), 1))
+}
+
+func TestRatedQueueMatchesCloseRatings(t *testing.T) {
+	queue := NewQueueSystem(QueueConfig{
+		ID:         "ranked",
+		MatchSize:  2,
+		MinPlayers: 2,
+		MaxPlayers: 2,
+		TeamCount:  2,
+		Rating:     &RatingWindow{Initial: 100, WidenPerSecond: 10, Max: 500, MaxWait: time.Minute},
+	})
+
+	players := newQueuedPlayers(3)
+	players[0].Rating = 1000
+	players[1].Rating = 1800
+	players[2].Rating = 1750
+	for _, player := range players {
+		queue.PlayerJoinQueue(player)
+	}
+
+	now := time.Now()
+
+	// the two high rated players match, skipping the one who joined first
+	match := queue.TakeMatch(now)
+	require.NotNil(t, match)
+	matched := match.Players
+	require.Len(t, matched, 2)
+	assert.ElementsMatch(t, []uuid.UUID{players[1].ID, players[2].ID}, []uuid.UUID{matched[0].ID, matched[1].ID})
+	assert.NotEqual(t, matched[0].Team, matched[1].Team)
+
+	late := newQueuedPlayers(1)[0]
+	late.Rating = 1600
+	queue.PlayerJoinQueue(late)
+
+	// 600 apart is too far until the window has widened past the max
+	assert.Nil(t, queue.TakeMatch(now.Add(30*time.Second)))
+
+	match = queue.TakeMatch(now.Add(61 * time.Second))
+	require.NotNil(t, match)
+	matched = match.Players
+	assert.Len(t, matched, 2)
+}
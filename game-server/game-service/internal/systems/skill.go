@@ -27,13 +27,32 @@ import "github.com/darkphotonKN/cosmic-void-server/game-service/internal/ecs"
 5. 消耗資源（如果有 MP 系統）
 */
 
-type SkillSystem struct{}
+type SkillSystem struct {
+	factions     *FactionTable
+	friendlyFire bool
+}
 
-func NewSkillSystem() *SkillSystem {
-	return &SkillSystem{}
+func NewSkillSystem(factions *FactionTable, friendlyFire bool) *SkillSystem {
+	return &SkillSystem{
+		factions:     factions,
+		friendlyFire: friendlyFire,
+	}
 }
 
 func (s *SkillSystem) Update(deltaTime float64, entities []*ecs.Entity) {
 	// Skill logic to be implemented
 
 }
+
+/**
+* checks if a skill cast by the caster can affect the target. Beneficial
+* skills (heals, shields) only land on allies, harmful ones follow the same
+* rules as regular attacks.
+**/
+func (s *SkillSystem) CanAffect(caster, target *ecs.Entity, beneficial bool) bool {
+	if beneficial {
+		return s.factions.Relation(caster, target) == RelationAlly
+	}
+
+	return s.factions.CanDamage(caster, target, s.friendlyFire)
+}
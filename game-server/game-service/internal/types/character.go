@@ -0,0 +1,34 @@
+package types
+
+import "github.com/google/uuid"
+
+/**
+* Snapshot of a player's persistent character, loaded into a session when it
+* starts and written back when the match ends.
+**/
+type CharacterState struct {
+	MemberID uuid.UUID
+	Name     string
+
+	Level         int
+	Experience    int
+	Strength      int
+	Agility       int
+	Intelligence  int
+	UnspentPoints int
+
+	CurrentHealth int
+	MaxHealth     int
+
+	Inventory []CharacterItem
+	// [slot] equipped item
+	Equipment map[string]CharacterItem
+}
+
+type CharacterItem struct {
+	ID       uuid.UUID
+	Type     string
+	Name     string
+	Quantity int
+	LockID   string
+}
@@ -1,16 +1,34 @@
 package types
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Player struct {
 	ID       uuid.UUID
 	Username string
+	// team assigned by matchmaking or room setup, 0 lets the session decide
+	Team int
+	// skill rating in the mode being queued for, only set for rated queues
+	Rating float64
+	// party the player queued with, nil when queueing alone
+	PartyID uuid.UUID
+	// server controlled players filling a match, they never connect and are
+	// left out of results and ratings
+	Bot           bool
+	BotDifficulty string
+	// round trip to the server when the player queued, zero if unmeasured
+	Latency time.Duration
 }
 
 type PlayerState struct {
 	ID        uuid.UUID        `json:"id"`
 	EntityID  uuid.UUID        `json:"entity_id"`
 	Username  string           `json:"username"`
+	Team      int              `json:"team"`
+	Bot       bool             `json:"bot"`
 	Position  *Position        `json:"position"`
 	Direction *PlayerDirection `json:"direction"`
 }
@@ -15,6 +15,14 @@ import (
 type Message struct {
 	Action  string                 `json:"action"`
 	Payload map[string]interface{} `json:"payload"`
+	// optional id a client gives a message, echoed on the replies to it so
+	// they can be matched to what was sent
+	RequestID string `json:"request_id,omitempty"`
+
+	// replies from the ResponseBuilder wait in the connection's message
+	// queue like everything else sent to it, and are written as this
+	// response instead
+	Response *ServerResponse `json:"-"`
 }
 
 /**
@@ -27,15 +35,18 @@ type ClientPackage struct {
 }
 
 type ServerResponse struct {
-	Action  string                 `json:"action"`
-	Payload map[string]interface{} `json:"payload"`
-	Success bool                   `json:"success,omitempty"`
-	Error   *ErrorResponse         `json:"error,omitempty"`
+	Action    string                 `json:"action"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Payload   map[string]interface{} `json:"payload"`
+	Success   bool                   `json:"success,omitempty"`
+	Error     *ErrorResponse         `json:"error,omitempty"`
 }
 
 type ErrorResponse struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// the fields at fault when a payload was rejected
+	Fields []FieldError `json:"fields,omitempty"`
 }
 
 // represents entire game state that client receives
@@ -46,39 +57,12 @@ type ClientGameState struct {
 	Doors     []*DoorState
 }
 
-func (m *Message) ParsePayload() (interface{}, error) {
-
-	switch constants.Action(m.Action) {
-	case constants.ActionMove:
-		parsedPayload := PlayerSessionMovePayload{
-			PlayerSessionPayload: PlayerSessionPayload{
-				SessionID: m.Payload["session_id"].(string),
-				PlayerID:  m.Payload["player_id"].(string),
-			},
-			Vx: m.Payload["vx"].(float64),
-			Vy: m.Payload["vy"].(float64),
-		}
-
-		fmt.Printf("\n\npayload of action move was: %+v\n", parsedPayload)
-
-		return parsedPayload, nil
-
-	case constants.ActionInteract:
-		parsedPayload := PlayerSessionInteractPayload{
-			PlayerSessionPayload: PlayerSessionPayload{
-				SessionID: m.Payload["session_id"].(string),
-				PlayerID:  m.Payload["player_id"].(string),
-			},
-			EntityID: m.Payload["entity_id"].(string),
-		}
-
-		fmt.Printf("\n\npayload of action interact was: %+v\n", parsedPayload)
-
-		return parsedPayload, nil
-	default:
-		return nil, fmt.Errorf("No matching actions.")
-	}
-
+/**
+* decodes and validates the payload into the action's typed payload, see
+* DecodePayload.
+**/
+func (m *Message) ParsePayload() (ActionPayload, error) {
+	return DecodePayload(constants.Action(m.Action), m.Payload)
 }
 
 /**
@@ -102,6 +86,25 @@ func (m *Message) GetSessionID() (uuid.UUID, error) {
 	return sessionID, nil
 }
 
+/**
+* helper to extract playerID.
+**/
+func (m *Message) GetPlayerID() (uuid.UUID, error) {
+	playerIDStr, ok := m.Payload["player_id"].(string)
+
+	if !ok {
+		return uuid.Nil, fmt.Errorf("PlayerID does not exist in the payload.")
+	}
+
+	playerID, err := uuid.Parse(playerIDStr)
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("PlayerID in payload is not a UUID.")
+	}
+
+	return playerID, nil
+}
+
 /**
 * Payloads for players in ongoing games
 **/
@@ -119,4 +122,25 @@ type PlayerSessionMovePayload struct {
 type PlayerSessionInteractPayload struct {
 	PlayerSessionPayload
 	EntityID string `json:"entity_id"`
+	Verb     string `json:"verb,omitempty"`
+}
+
+type PlayerSessionChatPayload struct {
+	PlayerSessionPayload
+	Channel string `json:"channel"`
+	Message string `json:"message"`
+}
+
+// fires the projectile in the direction given
+type PlayerSessionAttackPayload struct {
+	PlayerSessionPayload
+	Projectile string  `json:"projectile"`
+	Dx         float64 `json:"dx"`
+	Dy         float64 `json:"dy"`
+}
+
+type PlayerSessionAllocateStatPayload struct {
+	PlayerSessionPayload
+	Stat   string `json:"stat"`
+	Points int    `json:"points"`
 }
@@ -0,0 +1,243 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/google/uuid"
+)
+
+/**
+* Typed payloads for client actions. Each action maps to the struct its
+* payload decodes into, the fields it can't go without and the rules its
+* values have to follow. Anything that doesn't fit is rejected with the
+* fields at fault instead of reaching the game.
+**/
+
+var ErrUnknownAction = errors.New("Action has no payload registered.")
+
+// what's wrong with one field of a payload
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+/**
+* a payload that couldn't be decoded or broke its action's rules.
+**/
+type PayloadError struct {
+	Action string
+	Fields []FieldError
+}
+
+func (e *PayloadError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		problems[i] = fmt.Sprintf("%s %s", field.Field, field.Message)
+	}
+
+	return fmt.Sprintf("Invalid %s payload: %s.", e.Action, strings.Join(problems, ", "))
+}
+
+/**
+* a decoded payload checking its own values. Only called once every required
+* field was present and had the right type.
+**/
+type ActionPayload interface {
+	Validate() []FieldError
+}
+
+type payloadSpec struct {
+	required []string
+	new      func() ActionPayload
+}
+
+// every in game action names the session and player it's for
+func sessionFields(fields ...string) []string {
+	return append([]string{"session_id", "player_id"}, fields...)
+}
+
+// [action] to how its payload is decoded
+var actionPayloads = map[constants.Action]payloadSpec{
+	constants.ActionMove: {
+		required: sessionFields("vx", "vy"),
+		new:      func() ActionPayload { return &PlayerSessionMovePayload{} },
+	},
+	constants.ActionInteract: {
+		required: sessionFields("entity_id"),
+		new:      func() ActionPayload { return &PlayerSessionInteractPayload{} },
+	},
+	constants.ActionChat: {
+		required: sessionFields("message"),
+		new:      func() ActionPayload { return &PlayerSessionChatPayload{} },
+	},
+	constants.ActionAttack: {
+		required: sessionFields("projectile", "dx", "dy"),
+		new:      func() ActionPayload { return &PlayerSessionAttackPayload{} },
+	},
+	constants.ActionAllocateStat: {
+		required: sessionFields("stat", "points"),
+		new:      func() ActionPayload { return &PlayerSessionAllocateStatPayload{} },
+	},
+}
+
+/**
+* decodes the payload into the action's struct and validates it, returning a
+* *PayloadError listing every field at fault.
+**/
+func DecodePayload(action constants.Action, payload map[string]interface{}) (ActionPayload, error) {
+	spec, exists := actionPayloads[action]
+	if !exists {
+		return nil, ErrUnknownAction
+	}
+
+	var problems []FieldError
+	for _, field := range spec.required {
+		if value, present := payload[field]; !present || value == nil {
+			problems = append(problems, FieldError{Field: field, Message: "is required"})
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &PayloadError{Action: string(action), Fields: problems}
+	}
+
+	decoded := spec.new()
+	if err := decodeInto(payload, decoded); err != nil {
+		return nil, &PayloadError{Action: string(action), Fields: []FieldError{decodeFailure(err)}}
+	}
+
+	if problems := decoded.Validate(); len(problems) > 0 {
+		return nil, &PayloadError{Action: string(action), Fields: problems}
+	}
+
+	return decoded, nil
+}
+
+func decodeInto(payload map[string]interface{}, v interface{}) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+/**
+* names the field a decode failed on, e.g. a string sent for a number.
+**/
+func decodeFailure(err error) FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return FieldError{Field: typeErr.Field, Message: fmt.Sprintf("must be a %s", jsonTypeName(typeErr.Type.Kind().String()))}
+	}
+
+	return FieldError{Field: "payload", Message: "could not be decoded"}
+}
+
+func jsonTypeName(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"):
+		return "whole number"
+	case strings.HasPrefix(kind, "float"):
+		return "number"
+	case kind == "bool":
+		return "boolean"
+	default:
+		return kind
+	}
+}
+
+/**
+* --- Validation Rules ---
+**/
+
+func (p PlayerSessionPayload) validate() []FieldError {
+	var problems []FieldError
+	problems = appendIfInvalid(problems, "session_id", validUUID(p.SessionID))
+	problems = appendIfInvalid(problems, "player_id", validUUID(p.PlayerID))
+	return problems
+}
+
+func (p *PlayerSessionMovePayload) Validate() []FieldError {
+	problems := p.validate()
+	problems = appendIfInvalid(problems, "vx", withinUnit(p.Vx))
+	problems = appendIfInvalid(problems, "vy", withinUnit(p.Vy))
+	return problems
+}
+
+func (p *PlayerSessionInteractPayload) Validate() []FieldError {
+	problems := p.validate()
+	problems = appendIfInvalid(problems, "entity_id", validUUID(p.EntityID))
+	return problems
+}
+
+func (p *PlayerSessionChatPayload) Validate() []FieldError {
+	problems := p.validate()
+
+	switch {
+	case strings.TrimSpace(p.Message) == "":
+		problems = append(problems, FieldError{Field: "message", Message: "must not be empty"})
+	case utf8.RuneCountInString(p.Message) > constants.MaxChatMessageLength:
+		problems = append(problems, FieldError{
+			Field:   "message",
+			Message: fmt.Sprintf("must be at most %d characters", constants.MaxChatMessageLength),
+		})
+	}
+
+	return problems
+}
+
+func (p *PlayerSessionAttackPayload) Validate() []FieldError {
+	problems := p.validate()
+
+	if p.Projectile == "" {
+		problems = append(problems, FieldError{Field: "projectile", Message: "must not be empty"})
+	}
+	// any length works, the direction is normalized when fired
+	if p.Dx == 0 && p.Dy == 0 {
+		problems = append(problems, FieldError{Field: "dx", Message: "and dy must not both be zero"})
+	}
+
+	return problems
+}
+
+func (p *PlayerSessionAllocateStatPayload) Validate() []FieldError {
+	problems := p.validate()
+
+	if p.Stat == "" {
+		problems = append(problems, FieldError{Field: "stat", Message: "must not be empty"})
+	}
+	if p.Points < 1 {
+		problems = append(problems, FieldError{Field: "points", Message: "must be at least 1"})
+	}
+
+	return problems
+}
+
+func appendIfInvalid(problems []FieldError, field, message string) []FieldError {
+	if message == "" {
+		return problems
+	}
+
+	return append(problems, FieldError{Field: field, Message: message})
+}
+
+func validUUID(value string) string {
+	if _, err := uuid.Parse(value); err != nil {
+		return "must be a uuid"
+	}
+	return ""
+}
+
+// velocities are inputs between -1 and 1
+func withinUnit(value float64) string {
+	if math.IsNaN(value) || value < -1 || value > 1 {
+		return "must be between -1 and 1"
+	}
+	return ""
+}
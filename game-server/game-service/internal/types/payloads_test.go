@@ -0,0 +1,126 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darkphotonKN/cosmic-void-server/game-service/common/constants"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+/**
+* testing client payloads are decoded into their typed structs and checked.
+**/
+
+func sessionPayload(fields map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{
+		"session_id": uuid.New().String(),
+		"player_id":  uuid.New().String(),
+	}
+	for key, value := range fields {
+		payload[key] = value
+	}
+	return payload
+}
+
+// TestDecodePayload tests valid payloads decode and invalid ones name their fields
+func TestDecodePayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  constants.Action
+		payload map[string]interface{}
+		fields  []FieldError
+	}{
+		{
+			name:    "valid move",
+			action:  constants.ActionMove,
+			payload: sessionPayload(map[string]interface{}{"vx": 0.5, "vy": -1.0}),
+		},
+		{
+			name:    "missing fields",
+			action:  constants.ActionMove,
+			payload: map[string]interface{}{"vx": 0.5},
+			fields: []FieldError{
+				{Field: "session_id", Message: "is required"},
+				{Field: "player_id", Message: "is required"},
+				{Field: "vy", Message: "is required"},
+			},
+		},
+		{
+			name:    "wrong type",
+			action:  constants.ActionMove,
+			payload: sessionPayload(map[string]interface{}{"vx": "fast", "vy": 0.0}),
+			fields:  []FieldError{{Field: "vx", Message: "must be a number"}},
+		},
+		{
+			name:    "out of range",
+			action:  constants.ActionMove,
+			payload: sessionPayload(map[string]interface{}{"vx": 5.0, "vy": 0.0}),
+			fields:  []FieldError{{Field: "vx", Message: "must be between -1 and 1"}},
+		},
+		{
+			name:    "bad ids",
+			action:  constants.ActionInteract,
+			payload: map[string]interface{}{"session_id": "abc", "player_id": uuid.New().String(), "entity_id": "door"},
+			fields: []FieldError{
+				{Field: "session_id", Message: "must be a uuid"},
+				{Field: "entity_id", Message: "must be a uuid"},
+			},
+		},
+		{
+			name:    "empty chat",
+			action:  constants.ActionChat,
+			payload: sessionPayload(map[string]interface{}{"message": "   "}),
+			fields:  []FieldError{{Field: "message", Message: "must not be empty"}},
+		},
+		{
+			name:    "long chat",
+			action:  constants.ActionChat,
+			payload: sessionPayload(map[string]interface{}{"message": strings.Repeat("a", constants.MaxChatMessageLength+1)}),
+			fields:  []FieldError{{Field: "message", Message: "must be at most 500 characters"}},
+		},
+		{
+			name:    "fractional points",
+			action:  constants.ActionAllocateStat,
+			payload: sessionPayload(map[string]interface{}{"stat": "strength", "points": 1.5}),
+			fields:  []FieldError{{Field: "points", Message: "must be a whole number"}},
+		},
+		{
+			name:    "no direction",
+			action:  constants.ActionAttack,
+			payload: sessionPayload(map[string]interface{}{"projectile": "Fireball", "dx": 0.0, "dy": 0.0}),
+			fields:  []FieldError{{Field: "dx", Message: "and dy must not both be zero"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodePayload(tt.action, tt.payload)
+
+			if tt.fields == nil {
+				require.NoError(t, err)
+				assert.NotNil(t, decoded)
+				return
+			}
+
+			var payloadErr *PayloadError
+			require.ErrorAs(t, err, &payloadErr)
+			assert.Equal(t, tt.fields, payloadErr.Fields)
+		})
+	}
+}
+
+// TestDecodePayloadTyped tests the decoded payload is the action's struct
+func TestDecodePayloadTyped(t *testing.T) {
+	decoded, err := DecodePayload(constants.ActionChat, sessionPayload(map[string]interface{}{"message": "hi"}))
+	require.NoError(t, err)
+
+	chat, ok := decoded.(*PlayerSessionChatPayload)
+	require.True(t, ok)
+	assert.Equal(t, "hi", chat.Message)
+
+	_, err = DecodePayload(constants.ActionFindGame, nil)
+	assert.ErrorIs(t, err, ErrUnknownAction)
+}
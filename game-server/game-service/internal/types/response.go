@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
@@ -11,12 +12,22 @@ type MessageWriter interface {
 	WriteJSON(v interface{}) error
 }
 
-type ResponseBuilder struct{}
+type ResponseBuilder struct {
+	// echoed on every response so clients can match it to their message
+	requestID string
+}
 
 func NewResponseBuilder() *ResponseBuilder {
 	return &ResponseBuilder{}
 }
 
+/**
+* a builder whose responses answer the client message with the request id.
+**/
+func (rb *ResponseBuilder) ForRequest(requestID string) *ResponseBuilder {
+	return &ResponseBuilder{requestID: requestID}
+}
+
 func (rb *ResponseBuilder) Success(writer MessageWriter, action string, payload map[string]interface{}) error {
 	response := ServerResponse{
 		Action:  action,
@@ -40,7 +51,31 @@ func (rb *ResponseBuilder) Error(writer MessageWriter, action string, errCode co
 	return rb.send(writer, errorResponse, action)
 }
 
+/**
+* rejects a payload that failed to decode or validate, listing the fields at
+* fault when err is a *PayloadError.
+**/
+func (rb *ResponseBuilder) InvalidPayload(writer MessageWriter, action string, err error) error {
+	errorResponse := ServerResponse{
+		Action:  action,
+		Success: false,
+		Error: &ErrorResponse{
+			Code:    string(constants.ErrorInvalidPayload),
+			Message: err.Error(),
+		},
+	}
+
+	var payloadErr *PayloadError
+	if errors.As(err, &payloadErr) {
+		errorResponse.Error.Fields = payloadErr.Fields
+	}
+
+	return rb.send(writer, errorResponse, action)
+}
+
 func (rb *ResponseBuilder) send(writer MessageWriter, response ServerResponse, action string) error {
+	response.RequestID = rb.requestID
+
 	if writer == nil {
 		log.Printf("[ResponseBuilder] Warning: nil writer for action '%s', skipping send", action)
 		return nil
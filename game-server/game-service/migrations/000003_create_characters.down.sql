@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS character_items;
+DROP TABLE IF EXISTS characters;
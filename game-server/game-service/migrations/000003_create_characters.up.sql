@@ -0,0 +1,35 @@
+CREATE TABLE characters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    member_id UUID NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+
+    -- progression --
+    level INTEGER NOT NULL DEFAULT 1 CHECK (level >= 1),
+    experience INTEGER NOT NULL DEFAULT 0 CHECK (experience >= 0),
+    strength INTEGER NOT NULL DEFAULT 10,
+    agility INTEGER NOT NULL DEFAULT 10,
+    intelligence INTEGER NOT NULL DEFAULT 10,
+    unspent_points INTEGER NOT NULL DEFAULT 0 CHECK (unspent_points >= 0),
+
+    -- health --
+    current_health INTEGER NOT NULL DEFAULT 100 CHECK (current_health >= 0),
+    max_health INTEGER NOT NULL DEFAULT 100 CHECK (max_health > 0),
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- inventory and equipment, equipped items have a slot
+CREATE TABLE character_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    character_id UUID NOT NULL REFERENCES characters(id) ON DELETE CASCADE,
+    item_type VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    lock_id VARCHAR(100) NOT NULL DEFAULT '',
+    equipped_slot VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_character_items_character_id ON character_items(character_id);
+CREATE UNIQUE INDEX idx_character_items_equipped_slot ON character_items(character_id, equipped_slot) WHERE equipped_slot IS NOT NULL;
@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS item_instance_mods;
+DROP TABLE IF EXISTS item_instances;
+DROP TABLE IF EXISTS item_mods;
+
+DELETE FROM items WHERE category <> '';
+DROP INDEX IF EXISTS idx_items_category;
+
+ALTER TABLE items
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS slot,
+    DROP COLUMN IF EXISTS image_url,
+    DROP COLUMN IF EXISTS is_two_hands,
+    DROP COLUMN IF EXISTS required_level,
+    DROP COLUMN IF EXISTS required_strength,
+    DROP COLUMN IF EXISTS required_agility,
+    DROP COLUMN IF EXISTS required_intelligence,
+    DROP COLUMN IF EXISTS damage_min,
+    DROP COLUMN IF EXISTS damage_max,
+    DROP COLUMN IF EXISTS attack_speed,
+    DROP COLUMN IF EXISTS armour,
+    DROP COLUMN IF EXISTS evasion,
+    DROP COLUMN IF EXISTS energy_shield,
+    DROP COLUMN IF EXISTS implicit;
@@ -0,0 +1,88 @@
+-- base item definitions, extends the existing items table into the catalog --
+ALTER TABLE items
+    ADD COLUMN category VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN slot VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN image_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN is_two_hands BOOLEAN NOT NULL DEFAULT FALSE,
+
+    ADD COLUMN required_level INTEGER NOT NULL DEFAULT 1 CHECK (required_level >= 1),
+    ADD COLUMN required_strength INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN required_agility INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN required_intelligence INTEGER NOT NULL DEFAULT 0,
+
+    ADD COLUMN damage_min INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN damage_max INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN attack_speed DOUBLE PRECISION NOT NULL DEFAULT 0,
+
+    ADD COLUMN armour INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN evasion INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN energy_shield INTEGER NOT NULL DEFAULT 0,
+
+    ADD COLUMN implicit TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_items_category ON items(category);
+
+-- modifiers that can roll onto items, # in stat is replaced by the rolled value --
+CREATE TABLE item_mods (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    affix VARCHAR(10) NOT NULL CHECK (affix IN ('prefix', 'suffix')),
+    name VARCHAR(100) NOT NULL,
+    level INTEGER NOT NULL DEFAULT 1 CHECK (level >= 1),
+    stat VARCHAR(255) NOT NULL,
+    min_value INTEGER NOT NULL,
+    max_value INTEGER NOT NULL,
+    tags VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (max_value >= min_value)
+);
+
+CREATE INDEX idx_item_mods_level ON item_mods(level);
+
+-- items owned by members --
+CREATE TABLE item_instances (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    member_id UUID NOT NULL,
+    item_id UUID NOT NULL REFERENCES items(id),
+    rarity VARCHAR(20) NOT NULL DEFAULT 'normal' CHECK (rarity IN ('normal', 'magic', 'rare')),
+    item_level INTEGER NOT NULL DEFAULT 1 CHECK (item_level >= 1),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_item_instances_member_id ON item_instances(member_id);
+
+CREATE TABLE item_instance_mods (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    instance_id UUID NOT NULL REFERENCES item_instances(id) ON DELETE CASCADE,
+    mod_id UUID NOT NULL REFERENCES item_mods(id),
+    value INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_item_instance_mods_instance_id ON item_instance_mods(instance_id);
+
+-- starter catalog --
+INSERT INTO items (name, type, category, slot, is_two_hands, required_level, required_strength, required_agility, required_intelligence, damage_min, damage_max, attack_speed, armour, evasion, energy_shield, implicit) VALUES
+    ('Rusty Sword', 'weapon', 'sword', 'weapon', FALSE, 1, 8, 0, 0, 4, 9, 1.5, 0, 0, 0, '{}'),
+    ('Iron Greatsword', 'weapon', 'sword', 'weapon', TRUE, 8, 20, 0, 0, 14, 28, 1.2, 0, 0, 0, '{}'),
+    ('Short Bow', 'weapon', 'bow', 'weapon', TRUE, 3, 0, 14, 0, 5, 12, 1.4, 0, 0, 0, '{}'),
+    ('Driftwood Wand', 'weapon', 'wand', 'weapon', FALSE, 1, 0, 0, 12, 3, 8, 1.4, 0, 0, 0, '{"+10% increased Spell Damage"}'),
+    ('Plate Vest', 'armour', 'body_armour', 'body', FALSE, 1, 12, 0, 0, 0, 0, 0, 19, 0, 0, '{}'),
+    ('Shabby Jerkin', 'armour', 'body_armour', 'body', FALSE, 1, 0, 12, 0, 0, 0, 0, 0, 25, 0, '{}'),
+    ('Simple Robe', 'armour', 'body_armour', 'body', FALSE, 1, 0, 0, 12, 0, 0, 0, 0, 0, 11, '{}'),
+    ('Leather Cap', 'armour', 'helmet', 'helmet', FALSE, 1, 0, 9, 0, 0, 0, 0, 0, 12, 0, '{}'),
+    ('Iron Ring', 'jewellery', 'ring', 'ring', FALSE, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, '{"Adds 1 to 4 Physical Damage to Attacks"}');
+
+INSERT INTO item_mods (affix, name, level, stat, min_value, max_value, tags) VALUES
+    ('prefix', 'Healthy', 1, '+# to maximum Life', 10, 19, 'life'),
+    ('prefix', 'Sanguine', 11, '+# to maximum Life', 20, 29, 'life'),
+    ('prefix', 'Heavy', 1, '#% increased Physical Damage', 15, 24, 'damage,physical'),
+    ('prefix', 'Serrated', 11, '#% increased Physical Damage', 25, 34, 'damage,physical'),
+    ('prefix', 'Lacquered', 1, '+# to Armour', 5, 15, 'defences'),
+    ('prefix', 'Protective', 1, '+# to maximum Energy Shield', 3, 8, 'defences'),
+    ('suffix', 'of the Brute', 1, '+# to Strength', 8, 12, 'attribute'),
+    ('suffix', 'of the Mongoose', 1, '+# to Agility', 8, 12, 'attribute'),
+    ('suffix', 'of the Pupil', 1, '+# to Intelligence', 8, 12, 'attribute'),
+    ('suffix', 'of Skill', 1, '#% increased Attack Speed', 5, 7, 'attack,speed'),
+    ('suffix', 'of the Whelpling', 5, '+#% to Fire Resistance', 6, 11, 'elemental,resistance');
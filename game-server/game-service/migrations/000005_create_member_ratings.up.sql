@@ -0,0 +1,16 @@
+-- skill rating per member and game mode, used by rated matchmaking
+CREATE TABLE member_ratings (
+    member_id UUID NOT NULL,
+    game_mode VARCHAR(50) NOT NULL,
+    rating DOUBLE PRECISION NOT NULL DEFAULT 1500,
+    games_played INTEGER NOT NULL DEFAULT 0 CHECK (games_played >= 0),
+    wins INTEGER NOT NULL DEFAULT 0 CHECK (wins >= 0),
+    losses INTEGER NOT NULL DEFAULT 0 CHECK (losses >= 0),
+    draws INTEGER NOT NULL DEFAULT 0 CHECK (draws >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (member_id, game_mode)
+);
+
+CREATE INDEX idx_member_ratings_game_mode_rating ON member_ratings(game_mode, rating);